@@ -5,25 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"sort"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
-type listrules struct {
-	orders map[string]map[string]int
-	sync.Mutex
-}
-
-var rules = listrules{
-	orders: make(map[string]map[string]int),
-}
-
 // Common values shared between Service Edge Groups and App Connector Groups
 var versionProfileNameIDMapping map[string]string = map[string]string{
 	"Default":          "0",
@@ -47,24 +42,239 @@ func validateAndSetProfileNameID(d *schema.ResourceData) error {
 	return nil
 }
 
-func ValidateConditions(conditions []policysetcontroller.Conditions, zClient *Client) bool {
-	for _, condition := range conditions {
-		if !validateOperands(condition.Operands, zClient) {
-			return false
+// customizeDiffPolicyConditions resolves the operand names to IDs and validates the condition operands of a
+// policy rule at plan time. Operands that reference values not known until apply are skipped, and unchanged
+// conditions and names are not looked up again.
+func customizeDiffPolicyConditions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("conditions") {
+		return nil
+	}
+	zClient := m.(*Client).withContext(ctx)
+	conditions, errs := resolvePolicyConditionNames(d, zClient)
+	if len(errs) > 0 {
+		return errs.diffError()
+	}
+	if conditions != nil {
+		if err := d.SetNew("conditions", conditions); err != nil {
+			return err
 		}
 	}
-	return true
+	if d.Id() != "" && !policyConditionsChanged(d) {
+		return nil
+	}
+	conditions, _ = d.Get("conditions").([]interface{})
+	return validatePolicyConditions(conditions, func(condition, operand int) bool {
+		return policyOperandKnown(d, condition, operand)
+	}, zClient).diffError()
 }
-func validateOperands(operands []policysetcontroller.Operands, zClient *Client) bool {
-	for _, operand := range operands {
-		if !validateOperand(operand, zClient) {
-			return false
+
+// policyConditionsChanged reports whether the planned conditions differ from the state. d.HasChange can't tell
+// once SetNew replaced the conditions, reflect.DeepEqual never finds two rhs_list sets equal.
+func policyConditionsChanged(d *schema.ResourceDiff) bool {
+	old, planned := d.GetChange("conditions")
+	return !reflect.DeepEqual(listSets(old), listSets(planned))
+}
+
+// listSets returns v with every set replaced by the list of its elements.
+func listSets(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return listSets(v.List())
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, e := range v {
+			list[i] = listSets(e)
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = listSets(e)
+		}
+		return m
+	}
+	return v
+}
+
+// validatePolicyConditionsOnApply validates the operands again before a rule is created or its conditions are
+// updated. customizeDiffPolicyConditions skips the operands that reference values not known at plan time.
+func validatePolicyConditionsOnApply(d *schema.ResourceData, zClient *Client) diag.Diagnostics {
+	if d.Id() != "" && !d.HasChange("conditions") {
+		return nil
+	}
+	conditions, _ := d.Get("conditions").([]interface{})
+	return validatePolicyConditions(conditions, func(int, int) bool { return true }, zClient).diagnostics()
+}
+
+// policyOperandError describes why one operand of a policy rule condition is invalid.
+type policyOperandError struct {
+	key        string
+	attribute  string
+	objectType string
+	expected   string
+	value      string
+	err        error
+}
+
+func (e *policyOperandError) Error() string {
+	msg := fmt.Sprintf("%s.%s: when operand object type is %s %s must be %s, value is %q", e.key, e.attribute, e.objectType, e.attribute, e.expected, e.value)
+	if e.err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.err)
+	}
+	return msg
+}
+
+// path returns the attribute path of the operand attribute, for example conditions.0.operands.2.rhs.
+func (e *policyOperandError) path() cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(e.key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path.GetAttr(e.attribute)
+}
+
+type policyOperandErrors []*policyOperandError
+
+// diffError returns nil when there are no errors. A CustomizeDiff error carries a single path, the error has the
+// path of the first operand attribute and its text lists every invalid operand.
+func (errs policyOperandErrors) diffError() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0].path().NewError(errs[0])
+	default:
+		return errs[0].path().NewError(errs)
+	}
+}
+
+// diagnostics returns one error diagnostic per invalid operand, each with the path of the operand attribute.
+func (errs policyOperandErrors) diagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: err.path(),
+		})
+	}
+	return diags
+}
+
+func (errs policyOperandErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = "  - " + err.Error()
+	}
+	return fmt.Sprintf("%d policy rule operands are invalid:\n%s", len(errs), strings.Join(msgs, "\n"))
+}
+
+func lhsError(operand policysetcontroller.Operands, expected string, err error) *policyOperandError {
+	return &policyOperandError{attribute: "lhs", objectType: operand.ObjectType, expected: expected, value: operand.LHS, err: err}
+}
+
+func rhsError(operand policysetcontroller.Operands, expected string, err error) *policyOperandError {
+	return &policyOperandError{attribute: "rhs", objectType: operand.ObjectType, expected: expected, value: operand.RHS, err: err}
+}
+
+// validatePolicyConditions returns one error per invalid operand, each rhs_list value is validated on its own.
+// The operands known reports false for aren't validated.
+func validatePolicyConditions(conditions []interface{}, known func(condition, operand int) bool, zClient *Client) policyOperandErrors {
+	var errs policyOperandErrors
+	for i, condition := range conditions {
+		conditionSet, _ := condition.(map[string]interface{})
+		if conditionSet == nil {
+			continue
+		}
+		operands, _ := conditionSet["operands"].([]interface{})
+		for j, operand := range operands {
+			key := fmt.Sprintf("conditions.%d.operands.%d", i, j)
+			operandSet, _ := operand.(map[string]interface{})
+			if operandSet == nil || !known(i, j) {
+				continue
+			}
+			op := policysetcontroller.Operands{}
+			op.IdpID, _ = operandSet["idp_id"].(string)
+			op.LHS, _ = operandSet["lhs"].(string)
+			op.ObjectType, _ = operandSet["object_type"].(string)
+			op.RHS, _ = operandSet["rhs"].(string)
+			values, rhsAttribute := []string{op.RHS}, "rhs"
+			if rhsList, ok := operandSet["rhs_list"].(*schema.Set); op.RHS == "" && ok && rhsList.Len() > 0 {
+				values, rhsAttribute = SetToStringSlice(rhsList), "rhs_list"
+			}
+			for _, value := range values {
+				op.RHS = value
+				if err := validateOperand(op, zClient); err != nil {
+					err.key = key
+					if err.attribute == "rhs" {
+						err.attribute = rhsAttribute
+					}
+					errs = append(errs, err)
+					break
+				}
+			}
 		}
 	}
-	return true
+	return errs
 }
-func validateOperand(operand policysetcontroller.Operands, zClient *Client) bool {
+
+// policyOperandKnown reports whether every attribute of the operand is known in the configuration. The plan
+// can't be used for this, it leaves the computed attributes the configuration doesn't set unknown on create.
+func policyOperandKnown(d *schema.ResourceDiff, condition, operand int) bool {
+	config := d.GetRawConfig()
+	if !config.IsKnown() || config.IsNull() {
+		return false
+	}
+	conditions := config.GetAttr("conditions")
+	if !conditions.IsKnown() || conditions.IsNull() || conditions.LengthInt() <= condition {
+		return false
+	}
+	operands := conditions.Index(cty.NumberIntVal(int64(condition))).GetAttr("operands")
+	if !operands.IsKnown() || operands.IsNull() || operands.LengthInt() <= operand {
+		return false
+	}
+	return operands.Index(cty.NumberIntVal(int64(operand))).IsWhollyKnown()
+}
+
+func validateOperand(operand policysetcontroller.Operands, zClient *Client) *policyOperandError {
 	switch operand.ObjectType {
+	case "USER", "USER_GROUP":
+		// there's no API to look up users and user groups, only the IdP they come from can be checked
+		if operand.IdpID != "" {
+			if _, _, err := zClient.idpcontroller.Get(operand.IdpID); err != nil {
+				return &policyOperandError{attribute: "idp_id", objectType: operand.ObjectType, expected: "valid IDP Controller ID", value: operand.IdpID, err: err}
+			}
+		}
+		return customValidate(operand, []string{"id"}, "user or user group ID", Getter(func(id string) error {
+			return nil
+		}))
+	case "CONSOLE":
+		return customValidate(operand, []string{"id"}, "PRA console ID", Getter(func(id string) error {
+			_, err := getSummaryByID(zClient.praconsole, id)
+			return err
+		}))
+	case "LOCATION":
+		return customValidate(operand, []string{"id"}, "location ID", Getter(func(id string) error {
+			_, err := getSummaryByID(zClient.locationcontroller, id)
+			return err
+		}))
+	case "BRANCH_CONNECTOR_GROUP":
+		return customValidate(operand, []string{"id"}, "branch connector group ID", Getter(func(id string) error {
+			_, err := getSummaryByID(zClient.branchconnectorgroup, id)
+			return err
+		}))
+	case "COUNTRY_CODE":
+		if _, ok := countryCodes[operand.LHS]; !ok {
+			return lhsError(operand, "ISO 3166-1 alpha-2 country code", nil)
+		}
+		if operand.RHS != "true" {
+			return rhsError(operand, "\"true\"", nil)
+		}
+		return nil
 	case "APP":
 		return customValidate(operand, []string{"id"}, "application segment ID", Getter(func(id string) error {
 			_, _, err := zClient.applicationsegment.Get(id)
@@ -81,15 +291,15 @@ func validateOperand(operand policysetcontroller.Operands, zClient *Client) bool
 			_, _, err := zClient.idpcontroller.Get(id)
 			return err
 		}))
-	case "EDGE_CONNECTOR_GROUP":
+	case "EDGE_CONNECTOR_GROUP", "CLOUD_CONNECTOR_GROUP":
 		return customValidate(operand, []string{"id"}, "cloud connector group ID", Getter(func(id string) error {
 			_, _, err := zClient.cloudconnectorgroup.Get(id)
 			return err
 		}))
 	case "CLIENT_TYPE":
-		return customValidate(operand, []string{"id"}, "'zpn_client_type_zapp' or 'zpn_client_type_exporter' or 'zpn_client_type_ip_anchoring' or 'zpn_client_type_browser_isolation' or 'zpn_client_type_machine_tunnel' or 'zpn_client_type_edge_connector' or 'zpn_client_type_exporter_noauth' or 'zpn_client_type_slogger' or 'zpn_client_type_branch_connector'", Getter(func(id string) error {
-			if id != "zpn_client_type_zapp" && id != "zpn_client_type_exporter" && id != "zpn_client_type_exporter_noauth" && id != "zpn_client_type_ip_anchoring" && id != "zpn_client_type_browser_isolation" && id != "zpn_client_type_machine_tunnel" && id != "zpn_client_type_edge_connector" && id != "zpn_client_type_slogger" && id != "zpn_client_type_branch_connector" {
-				return fmt.Errorf("RHS values must be 'zpn_client_type_zapp' or 'zpn_client_type_exporter' or 'zpn_client_type_exporter_noauth' or 'zpn_client_type_ip_anchoring' or 'zpn_client_type_browser_isolation' or 'zpn_client_type_machine_tunnel' or 'zpn_client_type_edge_connector' or 'zpn_client_type_slogger' or 'zpn_client_type_branch_connector' when object type is CLIENT_TYPE")
+		return customValidate(operand, []string{"id"}, "one of "+quoteJoin(policyClientTypes), Getter(func(id string) error {
+			if !contains(policyClientTypes, id) {
+				return fmt.Errorf("unknown client type %q", id)
 			}
 			return nil
 		}))
@@ -100,214 +310,247 @@ func validateOperand(operand policysetcontroller.Operands, zClient *Client) bool
 		}))
 	case "POSTURE":
 		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid posture network ID", operand.LHS, nil)
-			return false
+			return lhsError(operand, "valid posture network ID", nil)
 		}
 		_, _, err := zClient.postureprofile.GetByPostureUDID(operand.LHS)
 		if err != nil {
-			lhsWarn(operand.ObjectType, "valid posture network ID", operand.LHS, err)
-			return false
+			return lhsError(operand, "valid posture network ID", err)
 		}
 		if !contains([]string{"true", "false"}, operand.RHS) {
-			rhsWarn(operand.ObjectType, "\"true\"/\"false\"", operand.RHS, nil)
-			return false
+			return rhsError(operand, "\"true\" or \"false\"", nil)
 		}
-		return true
+		return nil
 	case "TRUSTED_NETWORK":
 		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid trusted network ID", operand.LHS, nil)
-			return false
+			return lhsError(operand, "valid trusted network ID", nil)
 		}
 		_, _, err := zClient.trustednetwork.GetByNetID(operand.LHS)
 		if err != nil {
-			lhsWarn(operand.ObjectType, "valid trusted network ID", operand.LHS, err)
-			return false
+			return lhsError(operand, "valid trusted network ID", err)
 		}
 		if operand.RHS != "true" {
-			rhsWarn(operand.ObjectType, "\"true\"", operand.RHS, nil)
-			return false
+			return rhsError(operand, "\"true\"", nil)
 		}
-		return true
+		return nil
 	case "PLATFORM":
-		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid platform ID", operand.LHS, nil)
-			return false
-		}
-		_, _, err := zClient.platforms.GetAllPlatforms()
-		if err != nil {
-			lhsWarn(operand.ObjectType, "valid platform ID", operand.LHS, err)
-			return false
+		if !contains(platformOperandLHS, operand.LHS) {
+			return lhsError(operand, "one of "+quoteJoin(platformOperandLHS), nil)
 		}
 		if operand.RHS != "true" {
-			rhsWarn(operand.ObjectType, "\"true\"", operand.RHS, nil)
-			return false
+			return rhsError(operand, "\"true\"", nil)
 		}
-		return true
+		return nil
 	case "SAML":
 		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid SAML Attribute ID", operand.LHS, nil)
-			return false
+			return lhsError(operand, "valid SAML Attribute ID", nil)
 		}
 		_, _, err := zClient.samlattribute.Get(operand.LHS)
 		if err != nil {
-			lhsWarn(operand.ObjectType, "valid SAML Attribute ID", operand.LHS, err)
-			return false
+			return lhsError(operand, "valid SAML Attribute ID", err)
 		}
 		if operand.RHS == "" {
-			rhsWarn(operand.ObjectType, "SAML Attribute Value", operand.RHS, nil)
-			return false
+			return rhsError(operand, "SAML Attribute Value", nil)
 		}
-		return true
+		return nil
 	case "SCIM":
 		if operand.IdpID == "" {
-			log.Printf("[WARN] when operand object type is %v Idp ID must be set\n", operand.ObjectType)
-			return false
+			return &policyOperandError{attribute: "idp_id", objectType: operand.ObjectType, expected: "valid IDP Controller ID", value: operand.IdpID}
 		}
 		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid SCIM Attribute ID", operand.LHS, nil)
-			return false
+			return lhsError(operand, "valid SCIM Attribute ID", nil)
 		}
 		scim, _, err := zClient.scimattributeheader.Get(operand.IdpID, operand.LHS)
 		if err != nil {
-			lhsWarn(operand.ObjectType, "valid SCIM Attribute ID", operand.LHS, err)
-			return false
+			return lhsError(operand, "valid SCIM Attribute ID", err)
 		}
 		if operand.RHS == "" {
-			rhsWarn(operand.ObjectType, "SCIM Attribute Value", operand.RHS, nil)
-			return false
+			return rhsError(operand, "SCIM Attribute Value", nil)
 		}
 		values, _ := zClient.scimattributeheader.GetValues(scim.IdpID, scim.ID)
-		if len(values) > 0 {
-			found := false
-			for _, v := range values {
-				if v == operand.RHS {
-					found = true
-					break
-				}
-			}
-			if !found {
-				rhsWarn(operand.ObjectType, fmt.Sprintf("valid SCIM Attribute Value (%s)", values), operand.RHS, nil)
-				return false
-			}
+		if len(values) > 0 && !contains(values, operand.RHS) {
+			return rhsError(operand, "one of "+quoteJoin(values), nil)
 		}
-		return true
+		return nil
 	case "SCIM_GROUP":
 		if operand.LHS == "" {
-			lhsWarn(operand.ObjectType, "valid IDP Controller ID", operand.LHS, nil)
-			return false
+			return lhsError(operand, "valid IDP Controller ID", nil)
 		}
 		_, _, err := zClient.idpcontroller.Get(operand.LHS)
 		if err != nil {
-			lhsWarn(operand.ObjectType, "valid IDP Controller ID", operand.LHS, err)
-			return false
+			return lhsError(operand, "valid IDP Controller ID", err)
 		}
 		if operand.RHS == "" {
-			rhsWarn(operand.ObjectType, "SCIM Group ID", operand.RHS, nil)
-			return false
+			return rhsError(operand, "SCIM Group ID", nil)
 		}
 		_, _, err = zClient.scimgroup.Get(operand.RHS)
 		if err != nil {
-			rhsWarn(operand.ObjectType, "SCIM Group ID", operand.RHS, err)
-			return false
+			return rhsError(operand, "SCIM Group ID", err)
 		}
-		return true
+		return nil
 	default:
-		log.Printf("[WARN] invalid operand object type %s\n", operand.ObjectType)
-		return false
+		return &policyOperandError{attribute: "object_type", objectType: operand.ObjectType, expected: "an object type with operand validation", value: operand.ObjectType}
 	}
 }
 
+// platformOperandLHS are the platforms PLATFORM operands can match, the keys of the platform API response.
+var platformOperandLHS = []string{"linux", "android", "windows", "ios", "mac"}
+
+// policyClientTypes are the client types CLIENT_TYPE operands can match.
+var policyClientTypes = []string{"zpn_client_type_zapp", "zpn_client_type_exporter", "zpn_client_type_exporter_noauth", "zpn_client_type_ip_anchoring", "zpn_client_type_browser_isolation", "zpn_client_type_machine_tunnel", "zpn_client_type_edge_connector", "zpn_client_type_slogger", "zpn_client_type_branch_connector"}
+
 type Getter func(id string) error
 
 func (g Getter) Get(id string) error {
 	return g(id)
 }
-func customValidate(operand policysetcontroller.Operands, expectedLHS []string, expectedRHS string, clientRHS Getter) bool {
+func customValidate(operand policysetcontroller.Operands, expectedLHS []string, expectedRHS string, clientRHS Getter) *policyOperandError {
 	if operand.LHS == "" || !contains(expectedLHS, operand.LHS) {
-		lhsWarn(operand.ObjectType, expectedLHS, operand.LHS, nil)
-		return false
+		return lhsError(operand, quoteJoin(expectedLHS), nil)
 	}
 	if operand.RHS == "" {
-		rhsWarn(operand.ObjectType, expectedRHS, operand.RHS, nil)
-		return false
+		return rhsError(operand, expectedRHS, nil)
 	}
 	err := clientRHS.Get(operand.RHS)
 	if err != nil {
-		rhsWarn(operand.ObjectType, expectedRHS, operand.RHS, err)
-		return false
+		return rhsError(operand, expectedRHS, err)
 	}
-	return true
-}
-func rhsWarn(objType, expected, rhs interface{}, err error) {
-	log.Printf("[WARN] when operand object type is %v RHS must be %#v, value is \"%v\", %v\n", objType, expected, rhs, err)
-}
-func lhsWarn(objType, expected, lhs interface{}, err error) {
-	log.Printf("[WARN] when operand object type is %v LHS must be %#v value is \"%v\", %v\n", objType, expected, lhs, err)
+	return nil
 }
 
-func reorder(orderI interface{}, policySetID, policyType, id string, zClient *Client) {
-	defer reorderAll(policySetID, policyType, zClient)
-	if orderI == nil {
-		log.Printf("[WARN] Invalid order for policy set %s: %v\n", id, orderI)
-		return
-	}
-	order, ok := orderI.(string)
-	if !ok || order == "" {
-		log.Printf("[WARN] Invalid order for policy set %s: %v\n", id, order)
-		return
+func quoteJoin(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
 	}
+	return strings.Join(quoted, ", ")
+}
+
+// reorder moves a single rule to the given order. Moving rules one at a time gives results that depend on the
+// order rules are applied in, the zpa_policy_rule_order resource sets the order of a whole policy set at once.
+func reorder(orderI interface{}, policySetID, policyType, id string, zClient *Client) error {
+	order, _ := orderI.(string)
 	orderInt, err := strconv.Atoi(order)
 	if err != nil || orderInt < 0 {
-		log.Printf("[ERROR] couldn't reorder the policy set, the order may not have taken place:%v %v\n", orderInt, err)
-		return
+		return fmt.Errorf("invalid rule_order %q for the %s rule %s, it must be a positive number", order, policyType, id)
 	}
-	rules.Lock()
-	if len(rules.orders[policyType]) == 0 {
-		rules.orders[policyType] = map[string]int{}
+	if _, err := zClient.policysetcontroller.Reorder(policySetID, id, orderInt); err != nil {
+		return fmt.Errorf("couldn't move the %s rule %s to order %d: %w", policyType, id, orderInt, err)
 	}
-	rules.orders[policyType][id] = orderInt
-	rules.Unlock()
+	return nil
 }
 
-func sortOrders(ruleOrderMap map[string]int) RuleIDOrderPairList {
-	pl := make(RuleIDOrderPairList, len(ruleOrderMap))
-	i := 0
-	for k, v := range ruleOrderMap {
-		pl[i] = RuleIDOrderPair{k, v}
-		i++
+// placeRule moves the rule next to the rule named by insert_after or insert_before, which may be given by ID
+// or by name. The placement is resolved against the current rules of the policy set.
+func placeRule(d *schema.ResourceData, policySetID, policyType, id string, zClient *Client) error {
+	attribute, anchor := rulePlacement(d)
+	if anchor == "" {
+		return nil
+	}
+	list, err := getOrderedRules(zClient, policyType)
+	if err != nil {
+		return err
+	}
+	var others []policysetcontroller.PolicyRule
+	current := -1
+	for i, rule := range list {
+		if rule.ID == id {
+			current = i
+		} else {
+			others = append(others, rule)
+		}
 	}
-	sort.Sort(pl)
-	return pl
+	target := -1
+	for i, rule := range others {
+		if rule.ID == anchor || rule.Name == anchor {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return fmt.Errorf("%s: rule %q was not found in the %s policy set", attribute, anchor, policyType)
+	}
+	if attribute == "insert_after" {
+		target++
+	}
+	if target == current {
+		return nil
+	}
+	log.Printf("[INFO] Moving policy rule %s to order %d, %s %q\n", id, target+1, attribute, anchor)
+	_, err = zClient.policysetcontroller.Reorder(policySetID, id, target+1)
+	return err
 }
 
-type RuleIDOrderPair struct {
-	ID    string
-	Order int
+// readRulePlacement keeps insert_after or insert_before while the rule sits next to the rule it names,
+// otherwise it records the actual neighbour in the same form, ID or name, so that the plan moves the rule back.
+func readRulePlacement(d *schema.ResourceData, policyType, id string, zClient *Client) error {
+	attribute, anchor := rulePlacement(d)
+	if anchor == "" {
+		return nil
+	}
+	list, err := getOrderedRules(zClient, policyType)
+	if err != nil {
+		return err
+	}
+	neighbour := -1
+	byID := false
+	for i, rule := range list {
+		if rule.ID == id {
+			neighbour = i - 1
+			if attribute == "insert_before" {
+				neighbour = i + 1
+			}
+		}
+		if rule.ID == anchor {
+			byID = true
+		}
+	}
+	actual := ""
+	if neighbour >= 0 && neighbour < len(list) {
+		actual = list[neighbour].Name
+		if byID {
+			actual = list[neighbour].ID
+		}
+	}
+	if actual != anchor {
+		log.Printf("[WARN] policy rule %s is no longer placed %s %q, found %q\n", id, attribute, anchor, actual)
+		return d.Set(attribute, actual)
+	}
+	return nil
 }
 
-type RuleIDOrderPairList []RuleIDOrderPair
-
-func (p RuleIDOrderPairList) Len() int           { return len(p) }
-func (p RuleIDOrderPairList) Less(i, j int) bool { return p[i].Order < p[j].Order }
-func (p RuleIDOrderPairList) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func rulePlacement(d *schema.ResourceData) (string, string) {
+	if anchor, ok := d.GetOk("insert_after"); ok {
+		return "insert_after", anchor.(string)
+	}
+	if anchor, ok := d.GetOk("insert_before"); ok {
+		return "insert_before", anchor.(string)
+	}
+	return "", ""
+}
 
-// we keep calling reordering endpoint to reorder all rules after new rule was added
-// because the reorder endpoint shifts all order up to replace the new order.
-func reorderAll(policySetID, policyType string, zClient *Client) {
-	rules.Lock()
-	defer rules.Unlock()
-	list, _, _ := zClient.policysetcontroller.GetAllByType(policyType)
-	count := len(list)
-	// sort by order (ascending)
-	sorted := sortOrders(rules.orders[policyType])
-	for _, v := range sorted {
-		if v.Order <= count {
-			_, err := zClient.policysetcontroller.Reorder(policySetID, v.ID, v.Order)
-			if err != nil {
-				log.Printf("[ERROR] couldn't reorder the policy set, the order may not have taken place: %v\n", err)
-			}
+// getOrderedRules returns the rules of the policy type by order, without the default rule that always comes last.
+func getOrderedRules(zClient *Client, policyType string) ([]policysetcontroller.PolicyRule, error) {
+	list, _, err := zClient.policysetcontroller.GetAllByType(policyType)
+	if err != nil {
+		return nil, err
+	}
+	sortPolicyRules(list)
+	rules := list[:0]
+	for _, rule := range list {
+		if !rule.DefaultRule {
+			rules = append(rules, rule)
 		}
 	}
+	return rules, nil
+}
+
+// sortPolicyRules sorts the rules by rule order.
+func sortPolicyRules(rules []policysetcontroller.PolicyRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, _ := strconv.Atoi(rules[i].RuleOrder)
+		b, _ := strconv.Atoi(rules[j].RuleOrder)
+		return a < b
+	})
 }
 
 func GetPolicyConditionsSchema(objectTypes []string) *schema.Schema {
@@ -358,14 +601,26 @@ func GetPolicyConditionsSchema(objectTypes []string) *schema.Schema {
 							},
 							"lhs": {
 								Type:        schema.TypeString,
-								Required:    true,
-								Description: "This signifies the key for the object type. String ID example: id ",
+								Optional:    true,
+								Description: "This signifies the key for the object type. String ID example: id. Either lhs or lhs_name must be set.",
+							},
+							"lhs_name": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Description:  "The name of the object the lhs refers to, for the SAML, SCIM, SCIM_GROUP, POSTURE and TRUSTED_NETWORK object types. It is resolved to an ID at plan time and replaces lhs.",
+								ValidateFunc: validation.StringIsNotEmpty,
 							},
 							"rhs": {
 								Type:        schema.TypeString,
 								Optional:    true,
 								Description: "This denotes the value for the given object type. Its value depends upon the key.",
 							},
+							"rhs_name": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Description:  "The name of the object the rhs refers to, for the APP, APP_GROUP, IDP, EDGE_CONNECTOR_GROUP, CLOUD_CONNECTOR_GROUP, MACHINE_GRP, SCIM_GROUP, LOCATION and BRANCH_CONNECTOR_GROUP object types. It is resolved to an ID at plan time and replaces rhs.",
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
 							"rhs_list": {
 								Type:        schema.TypeSet,
 								Optional:    true,
@@ -472,6 +727,98 @@ func flattenPolicyConditions(conditions []policysetcontroller.Conditions) []inte
 	return ruleConditions
 }
 
+// flattenPolicyConditionsLike flattens the conditions in the shape of the current ones, so that reading back what
+// was applied doesn't show a diff. The operands an rhs_list was split into are grouped back into it, operands
+// keep their current order within a condition and their lhs_name and rhs_name, and operands that aren't in
+// the current conditions come last in the order of the API.
+func flattenPolicyConditionsLike(d *schema.ResourceData, conditions []policysetcontroller.Conditions) []interface{} {
+	current, _ := d.Get("conditions").([]interface{})
+	ruleConditions := flattenPolicyConditions(conditions)
+	for i, condition := range ruleConditions {
+		if i >= len(current) {
+			break
+		}
+		currentCondition, _ := current[i].(map[string]interface{})
+		if currentCondition == nil {
+			continue
+		}
+		currentOperands, _ := currentCondition["operands"].([]interface{})
+		condition := condition.(map[string]interface{})
+		condition["operands"] = regroupPolicyRuleOperands(currentOperands, condition["operands"].([]interface{}))
+	}
+	return ruleConditions
+}
+
+func regroupPolicyRuleOperands(current, operands []interface{}) []interface{} {
+	remaining := append([]interface{}{}, operands...)
+	var regrouped []interface{}
+	for _, c := range current {
+		currentOperand, _ := c.(map[string]interface{})
+		if currentOperand == nil {
+			continue
+		}
+		if rhsList, ok := currentOperand["rhs_list"].(*schema.Set); ok && rhsList.Len() > 0 && currentOperand["rhs"] == "" {
+			var group map[string]interface{}
+			var values []interface{}
+			remaining = filterOperands(remaining, func(operand map[string]interface{}) bool {
+				if !sameOperandKey(currentOperand, operand) {
+					return false
+				}
+				if group == nil {
+					group = operand
+				}
+				values = append(values, operand["rhs"])
+				return true
+			})
+			if group == nil {
+				continue
+			}
+			regrouped = append(regrouped, map[string]interface{}{
+				"idp_id":      group["idp_id"],
+				"lhs":         group["lhs"],
+				"lhs_name":    currentOperand["lhs_name"],
+				"object_type": group["object_type"],
+				"rhs_list":    values,
+				"name":        group["name"],
+			})
+			continue
+		}
+		matched := false
+		remaining = filterOperands(remaining, func(operand map[string]interface{}) bool {
+			if matched || !sameOperandKey(currentOperand, operand) || operand["rhs"] != currentOperand["rhs"] {
+				return false
+			}
+			matched = true
+			operand["lhs_name"] = currentOperand["lhs_name"]
+			operand["rhs_name"] = currentOperand["rhs_name"]
+			regrouped = append(regrouped, operand)
+			return true
+		})
+	}
+	return append(regrouped, remaining...)
+}
+
+// sameOperandKey reports whether the operands match the same object type and lhs. The idp_id is only compared
+// when the current operand has one, it isn't known before the first read.
+func sameOperandKey(current, operand map[string]interface{}) bool {
+	if current["object_type"] != operand["object_type"] || current["lhs"] != operand["lhs"] {
+		return false
+	}
+	idpID, _ := current["idp_id"].(string)
+	return idpID == "" || idpID == operand["idp_id"]
+}
+
+// filterOperands removes the operands for which take returns true.
+func filterOperands(operands []interface{}, take func(map[string]interface{}) bool) []interface{} {
+	var kept []interface{}
+	for _, operand := range operands {
+		if !take(operand.(map[string]interface{})) {
+			kept = append(kept, operand)
+		}
+	}
+	return kept
+}
+
 func flattenPolicyRuleOperands(conditionOperand []policysetcontroller.Operands) []interface{} {
 	conditionOperands := make([]interface{}, len(conditionOperand))
 	for i, operandItems := range conditionOperand {
@@ -577,9 +924,24 @@ func CommonPolicySchema() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"rule_order": {
-			Type:     schema.TypeString,
-			Optional: true,
-			Computed: true,
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Moves the rule to this order in the policy set. Don't set it on a rule listed in a zpa_policy_rule_order resource, the two would keep moving the rule against each other.",
+		},
+		"insert_after": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The ID or name of the rule this rule is placed directly after.",
+			ConflictsWith: []string{"insert_before", "rule_order"},
+			ValidateFunc:  validation.StringIsNotEmpty,
+		},
+		"insert_before": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The ID or name of the rule this rule is placed directly before.",
+			ConflictsWith: []string{"insert_after", "rule_order"},
+			ValidateFunc:  validation.StringIsNotEmpty,
 		},
 		"lss_default_rule": {
 			Type:     schema.TypeBool,
@@ -588,6 +950,17 @@ func CommonPolicySchema() map[string]*schema.Schema {
 	}
 }
 
+// Default timeouts of the resource operations, users can override them in the timeouts block of each resource.
+// Deletes detach the object from every policy rule referencing it first, so they get as long as creates.
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(20 * time.Minute),
+		Read:   schema.DefaultTimeout(10 * time.Minute),
+		Update: schema.DefaultTimeout(20 * time.Minute),
+		Delete: schema.DefaultTimeout(20 * time.Minute),
+	}
+}
+
 func resourceNetworkPortsSchema(desc string) *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
@@ -668,8 +1041,8 @@ func resourceAppSegmentPortRange(desc string) *schema.Schema {
 }
 
 func importPolicyStateContextFunc(types []string) schema.StateContextFunc {
-	return func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-		zClient := m.(*Client)
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		zClient := m.(*Client).withContext(ctx)
 		id := d.Id()
 		_, parseIDErr := strconv.ParseInt(id, 10, 64)
 		if parseIDErr == nil {
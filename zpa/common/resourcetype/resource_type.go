@@ -14,10 +14,16 @@ const (
 	ZPAApplicationSegmentBrowserAccess = "zpa_application_segment_browser_access"
 	ZPAPolicyType                      = "zpa_policy_type"
 	ZPAPolicyAccessRule                = "zpa_policy_access_rule"
+	ZPAPolicyAccessRuleV2              = "zpa_policy_access_rule_v2"
 	ZPAPolicyTimeOutRule               = "zpa_policy_timeout_rule"
 	ZPAPolicyForwardingRule            = "zpa_policy_forwarding_rule"
 	ZPAPolicyInspectionRule            = "zpa_policy_inspection_rule"
 	ZPAPolicyIsolationRule             = "zpa_policy_isolation_rule"
+	ZPAPolicyCredentialRule            = "zpa_policy_credential_rule"
+	ZPAPolicyRedirectionRule           = "zpa_policy_redirection_rule"
+	ZPAPolicyCapabilitiesRule          = "zpa_policy_capabilities_rule"
+	ZPAPolicyRule                      = "zpa_policy_rule"
+	ZPAPolicyRuleOrder                 = "zpa_policy_rule_order"
 	ZPACustomerVersionProfile          = "zpa_customer_version_profile"
 	ZPAEnrollmentCertificate           = "zpa_enrollment_cert"
 	ZPALSSController                   = "zpa_lss_config_controller"
@@ -0,0 +1,242 @@
+package zpa
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+// policyConditionObjectTypes returns the object types accepted by the conditions of every policy rule resource,
+// the object types of policyRuleTypes the ValidateFunc of object_type accepts.
+func policyConditionObjectTypes(t *testing.T) map[string][]string {
+	objectTypes := map[string][]string{}
+	for name, r := range Provider().ResourcesMap {
+		conditions, ok := r.Schema["conditions"]
+		if !ok {
+			continue
+		}
+		operands := conditions.Elem.(*schema.Resource).Schema["operands"]
+		objectType := operands.Elem.(*schema.Resource).Schema["object_type"]
+		for _, candidate := range allPolicyRuleObjectTypes() {
+			if _, errs := objectType.ValidateFunc(candidate, "object_type"); len(errs) == 0 {
+				objectTypes[name] = append(objectTypes[name], candidate)
+			}
+		}
+		if len(objectTypes[name]) == 0 {
+			t.Fatalf("%s: object_type accepts none of the object types of policyRuleTypes", name)
+		}
+	}
+	if len(objectTypes) == 0 {
+		t.Fatal("no resource with policy conditions was found")
+	}
+	return objectTypes
+}
+
+func TestValidateOperandCoversSchemaObjectTypes(t *testing.T) {
+	zClient := newFakeClient()
+	for name, objectTypes := range policyConditionObjectTypes(t) {
+		for _, objectType := range objectTypes {
+			err := validateOperand(policysetcontroller.Operands{ObjectType: objectType}, zClient)
+			if err != nil && err.attribute == "object_type" {
+				t.Errorf("%s accepts object type %s, but there is no operand validation for it", name, objectType)
+			}
+		}
+	}
+}
+
+func TestValidateOperand(t *testing.T) {
+	zClient := newFakeClient()
+	locations, _, _ := zClient.locationcontroller.GetAll()
+	branchConnectorGroups, _, _ := zClient.branchconnectorgroup.GetAll()
+	cloudConnectorGroups, _, _ := zClient.cloudconnectorgroup.GetAll()
+	idps, _, _ := zClient.idpcontroller.GetAll()
+	consoles, _, _ := zClient.praconsole.GetAll()
+
+	cases := []struct {
+		operand   policysetcontroller.Operands
+		attribute string
+	}{
+		{policysetcontroller.Operands{ObjectType: "USER", LHS: "id", RHS: "user@bd-hashicorp.com", IdpID: idps[0].ID}, ""},
+		{policysetcontroller.Operands{ObjectType: "USER", LHS: "id", RHS: "user@bd-hashicorp.com", IdpID: "1"}, "idp_id"},
+		{policysetcontroller.Operands{ObjectType: "USER_GROUP", LHS: "id", RHS: "engineering"}, ""},
+		{policysetcontroller.Operands{ObjectType: "USER_GROUP", LHS: "id"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "LOCATION", LHS: "id", RHS: locations[0].ID}, ""},
+		{policysetcontroller.Operands{ObjectType: "LOCATION", LHS: "id", RHS: "1"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "LOCATION", LHS: "name", RHS: locations[0].ID}, "lhs"},
+		{policysetcontroller.Operands{ObjectType: "BRANCH_CONNECTOR_GROUP", LHS: "id", RHS: branchConnectorGroups[0].ID}, ""},
+		{policysetcontroller.Operands{ObjectType: "BRANCH_CONNECTOR_GROUP", LHS: "id", RHS: locations[0].ID}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "CLOUD_CONNECTOR_GROUP", LHS: "id", RHS: cloudConnectorGroups[0].ID}, ""},
+		{policysetcontroller.Operands{ObjectType: "CLOUD_CONNECTOR_GROUP", LHS: "id", RHS: "1"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "CONSOLE", LHS: "id", RHS: consoles[0].ID}, ""},
+		{policysetcontroller.Operands{ObjectType: "CONSOLE", LHS: "id", RHS: "1"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "COUNTRY_CODE", LHS: "CA", RHS: "true"}, ""},
+		{policysetcontroller.Operands{ObjectType: "COUNTRY_CODE", LHS: "ca", RHS: "true"}, "lhs"},
+		{policysetcontroller.Operands{ObjectType: "COUNTRY_CODE", LHS: "XX", RHS: "true"}, "lhs"},
+		{policysetcontroller.Operands{ObjectType: "COUNTRY_CODE", LHS: "US", RHS: "false"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "PLATFORM", LHS: "linux", RHS: "true"}, ""},
+		{policysetcontroller.Operands{ObjectType: "PLATFORM", LHS: "solaris", RHS: "true"}, "lhs"},
+		{policysetcontroller.Operands{ObjectType: "PLATFORM", LHS: "mac", RHS: "yes"}, "rhs"},
+		{policysetcontroller.Operands{ObjectType: "UNKNOWN", LHS: "id", RHS: "1"}, "object_type"},
+	}
+	for _, c := range cases {
+		err := validateOperand(c.operand, zClient)
+		switch {
+		case c.attribute == "" && err != nil:
+			t.Errorf("%+v: unexpected error: %v", c.operand, err)
+		case c.attribute != "" && err == nil:
+			t.Errorf("%+v: expected an invalid %s", c.operand, c.attribute)
+		case c.attribute != "" && err.attribute != c.attribute:
+			t.Errorf("%+v: expected an invalid %s, got %v", c.operand, c.attribute, err)
+		}
+	}
+}
+
+func TestPolicyOperandErrorsDiffError(t *testing.T) {
+	errs := policyOperandErrors{
+		{key: "conditions.0.operands.1", attribute: "rhs", objectType: "APP", expected: "application segment ID", value: "1"},
+		{key: "conditions.1.operands.0", attribute: "lhs", objectType: "PLATFORM", expected: "one of the platforms", value: "solaris"},
+	}
+	var pathErr cty.PathError
+	if !errors.As(errs.diffError(), &pathErr) {
+		t.Fatalf("expected a path error, got %v", errs.diffError())
+	}
+	if !pathErr.Path.Equals(errs[0].path()) {
+		t.Errorf("expected the path of the first operand, got %#v", pathErr.Path)
+	}
+	for _, err := range errs {
+		if !strings.Contains(pathErr.Error(), err.Error()) {
+			t.Errorf("expected %q to list %q", pathErr.Error(), err.Error())
+		}
+	}
+}
+
+func TestValidatePolicyConditionsOnApply(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicyAccessRule().Schema, map[string]interface{}{
+		"name": "rule",
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"operator": "OR",
+				"operands": []interface{}{
+					map[string]interface{}{"object_type": "APP", "lhs": "id", "rhs": "1"},
+					map[string]interface{}{"object_type": "COUNTRY_CODE", "lhs": "CA", "rhs": "true"},
+					map[string]interface{}{"object_type": "PLATFORM", "lhs": "solaris", "rhs": "true"},
+				},
+			},
+		},
+	})
+	diags := validatePolicyConditionsOnApply(d, newFakeClient())
+	expected := []cty.Path{
+		cty.GetAttrPath("conditions").IndexInt(0).GetAttr("operands").IndexInt(0).GetAttr("rhs"),
+		cty.GetAttrPath("conditions").IndexInt(0).GetAttr("operands").IndexInt(2).GetAttr("lhs"),
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diags)
+	}
+	for i, path := range expected {
+		if !diags[i].AttributePath.Equals(path) {
+			t.Errorf("diagnostic %d: expected path %#v, got %#v", i, path, diags[i].AttributePath)
+		}
+	}
+}
+
+// conditionsShape returns what the configuration sees of the conditions, without the computed IDs.
+func conditionsShape(conditions interface{}) []string {
+	var shape []string
+	for i, condition := range conditions.([]interface{}) {
+		for _, operand := range condition.(map[string]interface{})["operands"].([]interface{}) {
+			operand := operand.(map[string]interface{})
+			var rhsList []string
+			if set, ok := operand["rhs_list"].(*schema.Set); ok {
+				rhsList = SetToStringSlice(set)
+				sort.Strings(rhsList)
+			}
+			shape = append(shape, fmt.Sprintf("%d %v lhs=%v lhs_name=%v rhs=%v rhs_name=%v rhs_list=%v", i, operand["object_type"], operand["lhs"], operand["lhs_name"], operand["rhs"], operand["rhs_name"], rhsList))
+		}
+	}
+	return shape
+}
+
+func TestFlattenPolicyConditionsLikeRoundTrip(t *testing.T) {
+	for name, objectTypes := range policyConditionObjectTypes(t) {
+		t.Run(name, func(t *testing.T) {
+			r := Provider().ResourcesMap[name]
+			raw := map[string]interface{}{
+				"name": "rule",
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"operator": "OR",
+						"operands": []interface{}{
+							map[string]interface{}{"object_type": objectTypes[0], "lhs": "id", "rhs_list": []interface{}{"3", "1", "2"}},
+							map[string]interface{}{"object_type": objectTypes[1], "lhs": "id", "rhs": "b", "rhs_name": "B"},
+							map[string]interface{}{"object_type": objectTypes[1], "lhs": "id", "rhs": "a"},
+						},
+					},
+				},
+			}
+			d := schema.TestResourceDataRaw(t, r.Schema, raw)
+			conditions, err := ExpandPolicyConditions(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(conditions[0].Operands); n != 5 {
+				t.Fatalf("expected rhs_list to be split into 3 operands, got %d operands", n)
+			}
+
+			// the API returns the operands in another order, with IDs
+			operands := conditions[0].Operands
+			for i, j := 0, len(operands)-1; i < j; i, j = i+1, j-1 {
+				operands[i], operands[j] = operands[j], operands[i]
+			}
+			for i := range operands {
+				operands[i].ID = fmt.Sprintf("op%d", i)
+			}
+
+			expected := conditionsShape(d.Get("conditions"))
+			if err := d.Set("conditions", flattenPolicyConditionsLike(d, conditions)); err != nil {
+				t.Fatal(err)
+			}
+			if got := conditionsShape(d.Get("conditions")); !reflect.DeepEqual(expected, got) {
+				t.Errorf("the conditions didn't round-trip:\nexpected %v\ngot      %v", expected, got)
+			}
+
+			// a value added outside of Terraform shows up in the rhs_list
+			conditions[0].Operands = append(conditions[0].Operands, policysetcontroller.Operands{ObjectType: objectTypes[0], LHS: "id", RHS: "4"})
+			if err := d.Set("conditions", flattenPolicyConditionsLike(d, conditions)); err != nil {
+				t.Fatal(err)
+			}
+			if got := d.Get("conditions.0.operands.0.rhs_list").(*schema.Set).Len(); got != 4 {
+				t.Errorf("expected the added value in the rhs_list, got %d values", got)
+			}
+
+			// without current conditions, as on import, every operand is read on its own
+			imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "rule"})
+			if got := len(flattenPolicyConditionsLike(imported, conditions)[0].(map[string]interface{})["operands"].([]interface{})); got != 6 {
+				t.Errorf("expected 6 operands on import, got %d", got)
+			}
+		})
+	}
+}
+
+func TestReorderReportsErrors(t *testing.T) {
+	zClient := newFakeClient()
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, order := range []interface{}{"", "first", "-1"} {
+		if err := reorder(order, policySet.ID, "ACCESS_POLICY", "1", zClient); err == nil || !strings.Contains(err.Error(), "invalid rule_order") {
+			t.Errorf("order %q: expected an invalid rule_order error, got %v", order, err)
+		}
+	}
+	// the API refuses to move a rule that doesn't exist, the error isn't dropped
+	if err := reorder("1", policySet.ID, "ACCESS_POLICY", "missing", zClient); err == nil || !isNotFoundError(err) {
+		t.Errorf("expected the not found error of the API, got %v", err)
+	}
+}
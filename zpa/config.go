@@ -1,7 +1,18 @@
 package zpa
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 
 	gozscaler "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorcontroller"
@@ -43,37 +54,52 @@ func init() {
 }
 
 type Client struct {
-	appconnectorgroup              appconnectorgroup.Service
-	appconnectorcontroller         appconnectorcontroller.Service
-	applicationsegment             applicationsegment.Service
-	applicationsegmentpra          applicationsegmentpra.Service
-	applicationsegmentinspection   applicationsegmentinspection.Service
-	appservercontroller            appservercontroller.Service
-	bacertificate                  bacertificate.Service
-	cloudconnectorgroup            cloudconnectorgroup.Service
-	customerversionprofile         customerversionprofile.Service
-	enrollmentcert                 enrollmentcert.Service
-	idpcontroller                  idpcontroller.Service
-	lssconfigcontroller            lssconfigcontroller.Service
-	machinegroup                   machinegroup.Service
-	postureprofile                 postureprofile.Service
-	isolationprofile               isolationprofile.Service
-	policysetcontroller            policysetcontroller.Service
-	provisioningkey                provisioningkey.Service
-	samlattribute                  samlattribute.Service
-	scimgroup                      scimgroup.Service
-	scimattributeheader            scimattributeheader.Service
-	segmentgroup                   segmentgroup.Service
-	servergroup                    servergroup.Service
-	serviceedgegroup               serviceedgegroup.Service
-	serviceedgecontroller          serviceedgecontroller.Service
-	trustednetwork                 trustednetwork.Service
-	platforms                      platforms.Service
-	clienttypes                    clienttypes.Service
-	browseraccess                  browseraccess.Service
-	inspection_custom_controls     inspection_custom_controls.Service
-	inspection_predefined_controls inspection_predefined_controls.Service
-	inspection_profile             inspection_profile.Service
+	appconnectorgroup              appConnectorGroupService
+	appconnectorcontroller         appConnectorControllerService
+	applicationsegment             applicationSegmentService
+	applicationsegmentpra          applicationSegmentPRAService
+	applicationsegmentinspection   applicationSegmentInspectionService
+	appservercontroller            appServerControllerService
+	bacertificate                  baCertificateService
+	cloudconnectorgroup            cloudConnectorGroupService
+	customerversionprofile         customerVersionProfileService
+	enrollmentcert                 enrollmentCertService
+	idpcontroller                  idpControllerService
+	lssconfigcontroller            lssConfigControllerService
+	machinegroup                   machineGroupService
+	postureprofile                 postureProfileService
+	isolationprofile               isolationProfileService
+	policysetcontroller            policySetControllerService
+	provisioningkey                provisioningKeyService
+	samlattribute                  samlAttributeService
+	scimgroup                      scimGroupService
+	scimattributeheader            scimAttributeHeaderService
+	segmentgroup                   segmentGroupService
+	servergroup                    serverGroupService
+	serviceedgegroup               serviceEdgeGroupService
+	serviceedgecontroller          serviceEdgeControllerService
+	trustednetwork                 trustedNetworkService
+	platforms                      platformsService
+	clienttypes                    clientTypesService
+	browseraccess                  browserAccessService
+	inspection_custom_controls     inspectionCustomControlsService
+	inspection_predefined_controls inspectionPredefinedControlsService
+	inspection_profile             inspectionProfileService
+	locationcontroller             summaryService
+	branchconnectorgroup           summaryService
+	praconsole                     summaryService
+	policyrulereorder              policyRuleBulkReorderService
+	extendedpolicyrule             extendedPolicyRuleService
+
+	// the on_delete_references setting of the provider, see deleteReferencedObject
+	onDeleteReferences string
+	// nil when the read_cache setting of the provider is disabled, see useReadCache
+	cache *readCache
+
+	// shared by every context scoped copy of the client, see withContext
+	config    *gozscaler.Config
+	transport http.RoundTripper
+	tokens    *tokenStore
 }
 
 type Config struct {
@@ -87,53 +113,241 @@ type Config struct {
 	// ZPA Customer ID for API Client
 	CustomerID string
 
-	// ZPA Base URL for API Client
+	// ZPA Cloud name for API Client
+	Cloud string
+
+	// Overrides the API endpoint derived from Cloud when set
 	BaseURL string
 
 	// UserAgent for API Client
 	UserAgent string
+
+	// Retry and backoff settings applied to every API call
+	Retry RetryConfig
+
+	// Outbound proxy for API calls, the HTTPS_PROXY/HTTP_PROXY environment variables are used when empty
+	HTTPProxy string
+
+	// PEM file with additional CA certificates trusted for API calls, e.g. the CA of an inspecting proxy
+	CABundleFile string
+
+	// Minimum TLS version for API calls, "1.2" or "1.3"
+	MinTLSVersion string
+
+	// Disables the verification of the API certificate, for lab environments only
+	InsecureSkipVerify bool
+
+	// What deleting an object other objects refer to does, "fail" or "detach"
+	OnDeleteReferences string
+
+	// Caches the policy sets, platforms, client types, IdPs and SAML and SCIM attributes for the provider process
+	ReadCache bool
 }
 
 func (c *Config) Client() (*Client, error) {
-	config, err := gozscaler.NewConfig(c.ClientID, c.ClientSecret, c.CustomerID, c.BaseURL, c.UserAgent)
+	config, err := gozscaler.NewConfig(c.ClientID, c.ClientSecret, c.CustomerID, c.Cloud, c.UserAgent)
 	if err != nil {
 		return nil, err
 	}
-	zpaClient := gozscaler.NewClient(config)
-	client := &Client{
-		appconnectorgroup:              *appconnectorgroup.New(zpaClient),
-		appconnectorcontroller:         *appconnectorcontroller.New(zpaClient),
-		applicationsegment:             *applicationsegment.New(zpaClient),
-		applicationsegmentpra:          *applicationsegmentpra.New(zpaClient),
-		applicationsegmentinspection:   *applicationsegmentinspection.New(zpaClient),
-		appservercontroller:            *appservercontroller.New(zpaClient),
-		bacertificate:                  *bacertificate.New(zpaClient),
-		cloudconnectorgroup:            *cloudconnectorgroup.New(zpaClient),
-		customerversionprofile:         *customerversionprofile.New(zpaClient),
-		enrollmentcert:                 *enrollmentcert.New(zpaClient),
-		idpcontroller:                  *idpcontroller.New(zpaClient),
-		lssconfigcontroller:            *lssconfigcontroller.New(zpaClient),
-		machinegroup:                   *machinegroup.New(zpaClient),
-		postureprofile:                 *postureprofile.New(zpaClient),
-		isolationprofile:               *isolationprofile.New(zpaClient),
-		policysetcontroller:            *policysetcontroller.New(zpaClient),
-		provisioningkey:                *provisioningkey.New(zpaClient),
-		samlattribute:                  *samlattribute.New(zpaClient),
-		scimgroup:                      *scimgroup.New(zpaClient),
-		scimattributeheader:            *scimattributeheader.New(zpaClient),
-		segmentgroup:                   *segmentgroup.New(zpaClient),
-		servergroup:                    *servergroup.New(zpaClient),
-		serviceedgegroup:               *serviceedgegroup.New(zpaClient),
-		serviceedgecontroller:          *serviceedgecontroller.New(zpaClient),
-		trustednetwork:                 *trustednetwork.New(zpaClient),
-		platforms:                      *platforms.New(zpaClient),
-		clienttypes:                    *clienttypes.New(zpaClient),
-		browseraccess:                  *browseraccess.New(zpaClient),
-		inspection_custom_controls:     *inspection_custom_controls.New(zpaClient),
-		inspection_predefined_controls: *inspection_predefined_controls.New(zpaClient),
-		inspection_profile:             *inspection_profile.New(zpaClient),
+	if c.BaseURL != "" {
+		baseURL, err := parseBaseURL(c.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Overriding the ZPA API endpoint with %s\n", baseURL)
+		config.BaseURL = baseURL
+		// the SDK signs in against a dedicated endpoint for the DEV cloud, the override must apply to sign-in as well
+		config.Cloud = baseURL.String()
+	}
+	// the provider owns retries, disable the SDK backoff so calls are not retried twice
+	config.SetBackoffConfig(gozscaler.BackoffConfig{Enabled: false})
+	httpClient := config.GetHTTPClient()
+	httpClient.Timeout = 0
+	transport, err := c.httpTransport()
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = newRetryTransport(logging.NewSubsystemLoggingHTTPTransport("gozscaler", transport), c.Retry)
+
+	client := newClient(gozscaler.NewClient(config))
+	client.config = config
+	client.transport = httpClient.Transport
+	client.tokens = &tokenStore{}
+	client.onDeleteReferences = c.OnDeleteReferences
+	if c.ReadCache {
+		client.useReadCache(newReadCache())
+	} else {
+		log.Println("[INFO] ZPA read cache disabled")
 	}
 
 	log.Println("[INFO] initialized ZPA client")
 	return client, nil
 }
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// httpTransport returns the transport used by every SDK service, with the proxy and TLS settings of the provider.
+func (c *Config) httpTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.HTTPProxy != "" {
+		proxyURL, err := url.Parse(c.HTTPProxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid http_proxy %s: expected a URL such as http://proxy.example.com:3128", c.HTTPProxy)
+		}
+		log.Printf("[INFO] Sending ZPA API calls through proxy %s\n", proxyURL.Redacted())
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.MinTLSVersion != "" {
+		version, ok := tlsVersions[c.MinTLSVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid min_tls_version %s: expected 1.2 or 1.3", c.MinTLSVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+	if c.CABundleFile != "" {
+		pem, err := os.ReadFile(c.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read ca_bundle_file %s: %v", c.CABundleFile, err)
+		}
+		// the bundle extends the system trust store, the ZPA API certificate must keep validating without the proxy
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_bundle_file %s doesn't contain any PEM encoded certificate", c.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.InsecureSkipVerify {
+		log.Printf("[WARN] TLS certificate verification of the ZPA API is disabled, this must only be used in lab environments\n")
+		tlsConfig.InsecureSkipVerify = true
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+func newClient(zpaClient *gozscaler.Client) *Client {
+	return &Client{
+		appconnectorgroup:              appconnectorgroup.New(zpaClient),
+		appconnectorcontroller:         appconnectorcontroller.New(zpaClient),
+		applicationsegment:             applicationsegment.New(zpaClient),
+		applicationsegmentpra:          applicationsegmentpra.New(zpaClient),
+		applicationsegmentinspection:   applicationsegmentinspection.New(zpaClient),
+		appservercontroller:            appservercontroller.New(zpaClient),
+		bacertificate:                  bacertificate.New(zpaClient),
+		cloudconnectorgroup:            cloudconnectorgroup.New(zpaClient),
+		customerversionprofile:         customerversionprofile.New(zpaClient),
+		enrollmentcert:                 enrollmentcert.New(zpaClient),
+		idpcontroller:                  idpcontroller.New(zpaClient),
+		lssconfigcontroller:            lssconfigcontroller.New(zpaClient),
+		machinegroup:                   machinegroup.New(zpaClient),
+		postureprofile:                 postureprofile.New(zpaClient),
+		isolationprofile:               isolationprofile.New(zpaClient),
+		policysetcontroller:            policysetcontroller.New(zpaClient),
+		provisioningkey:                provisioningkey.New(zpaClient),
+		samlattribute:                  samlattribute.New(zpaClient),
+		scimgroup:                      scimgroup.New(zpaClient),
+		scimattributeheader:            scimattributeheader.New(zpaClient),
+		segmentgroup:                   segmentgroup.New(zpaClient),
+		servergroup:                    servergroup.New(zpaClient),
+		serviceedgegroup:               serviceedgegroup.New(zpaClient),
+		serviceedgecontroller:          serviceedgecontroller.New(zpaClient),
+		trustednetwork:                 trustednetwork.New(zpaClient),
+		platforms:                      platforms.New(zpaClient),
+		clienttypes:                    clienttypes.New(zpaClient),
+		browseraccess:                  browseraccess.New(zpaClient),
+		inspection_custom_controls:     inspection_custom_controls.New(zpaClient),
+		inspection_predefined_controls: inspection_predefined_controls.New(zpaClient),
+		inspection_profile:             inspection_profile.New(zpaClient),
+		locationcontroller:             newSummaryService(zpaClient, "/location/summary"),
+		branchconnectorgroup:           newSummaryService(zpaClient, "/branchConnectorGroup/summary"),
+		praconsole:                     newSummaryService(zpaClient, "/praConsole"),
+		policyrulereorder:              newPolicyRuleBulkReorder(zpaClient),
+		extendedpolicyrule:             newExtendedPolicyRules(zpaClient),
+	}
+}
+
+// withContext returns a copy of the client whose API calls are bound to ctx, so they are cancelled
+// when Terraform is interrupted or the timeout of the resource operation expires. The copy shares the
+// retrying transport, the API token and the read cache of the provider client.
+func (c *Client) withContext(ctx context.Context) *Client {
+	if c.config == nil {
+		return c
+	}
+	config := &gozscaler.Config{
+		BaseURL:      c.config.BaseURL,
+		Logger:       c.config.Logger,
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		CustomerID:   c.config.CustomerID,
+		Cloud:        c.config.Cloud,
+		UserAgent:    c.config.UserAgent,
+		AuthToken:    c.tokens.get(),
+	}
+	config.SetBackoffConfig(gozscaler.BackoffConfig{Enabled: false})
+	httpClient := config.GetHTTPClient()
+	httpClient.Timeout = 0
+	httpClient.Transport = &contextTransport{ctx: ctx, base: c.transport, tokens: c.tokens}
+
+	scoped := newClient(gozscaler.NewClient(config))
+	scoped.config = c.config
+	scoped.transport = c.transport
+	scoped.tokens = c.tokens
+	scoped.onDeleteReferences = c.onDeleteReferences
+	scoped.useReadCache(c.cache)
+	return scoped
+}
+
+// tokenStore keeps the latest API token so that context scoped clients don't sign in again on every operation.
+type tokenStore struct {
+	sync.Mutex
+	token *gozscaler.AuthToken
+}
+
+func (s *tokenStore) get() *gozscaler.AuthToken {
+	s.Lock()
+	defer s.Unlock()
+	return s.token
+}
+
+func (s *tokenStore) set(tokenType, accessToken string) {
+	s.Lock()
+	defer s.Unlock()
+	if s.token == nil || s.token.AccessToken != accessToken {
+		s.token = &gozscaler.AuthToken{TokenType: tokenType, AccessToken: accessToken}
+	}
+}
+
+type contextTransport struct {
+	ctx    context.Context
+	base   http.RoundTripper
+	tokens *tokenStore
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// every API call carries the token it was authenticated with, including the ones refreshed by a scoped client
+	if tokenType, accessToken, ok := strings.Cut(req.Header.Get("Authorization"), " "); ok && accessToken != "" {
+		t.tokens.set(tokenType, accessToken)
+	}
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+func parseBaseURL(rawURL string) (*url.URL, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base_url %s: %v", rawURL, err)
+	}
+	if (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base_url %s: expected an http or https URL", rawURL)
+	}
+	// the SDK appends the API paths to the base URL path
+	baseURL.Path = strings.TrimSuffix(baseURL.Path, "/")
+	baseURL.RawPath = ""
+	return baseURL, nil
+}
@@ -0,0 +1,233 @@
+package zpa
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAccessToken returns a JWT shaped token the SDK accepts as not expired.
+func testAccessToken() string {
+	payload, _ := json.Marshal(map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+	return "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestConfigBaseURLOverride(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zpa/signin", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"token_type": "Bearer", "access_token": testAccessToken()})
+	})
+	mux.HandleFunc("/zpa/mgmtconfig/v1/admin/customers/123/segmentGroup/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "42", "name": "mock segment group"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		CustomerID:   "123",
+		Cloud:        "DEV",
+		BaseURL:      server.URL + "/zpa/",
+		UserAgent:    "terraform-provider-zpa/test",
+		Retry:        RetryConfig{RetryOnStatus: defaultRetryOnStatus},
+	}
+	zClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	group, _, err := zClient.segmentgroup.Get("42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group.Name != "mock segment group" {
+		t.Errorf("expected the segment group of the mock API, got %+v", group)
+	}
+}
+
+func TestParseBaseURL(t *testing.T) {
+	for _, rawURL := range []string{"", "config.private.zscaler.com", "ftp://config.private.zscaler.com", "https://"} {
+		if _, err := parseBaseURL(rawURL); err == nil {
+			t.Errorf("expected %q to be rejected", rawURL)
+		}
+	}
+	baseURL, err := parseBaseURL("http://localhost:8080/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseURL.String() != "http://localhost:8080" {
+		t.Errorf("expected the trailing slash to be removed, got %s", baseURL)
+	}
+}
+
+func TestProviderCloudValidation(t *testing.T) {
+	validate := Provider().Schema["zpa_cloud"].ValidateFunc
+	for _, cloud := range []string{"PRODUCTION", "BETA", "GOV", "PREVIEW", "DEV", "preview"} {
+		if _, errs := validate(cloud, "zpa_cloud"); len(errs) > 0 {
+			t.Errorf("expected %s to be valid, got %v", cloud, errs)
+		}
+	}
+	if _, errs := validate("PREVIEW, DEV", "zpa_cloud"); len(errs) == 0 {
+		t.Error("expected \"PREVIEW, DEV\" to be rejected")
+	}
+}
+
+func newTestMockAPI() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/signin", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"token_type": "Bearer", "access_token": testAccessToken()})
+	})
+	mux.HandleFunc("/mgmtconfig/v1/admin/customers/123/segmentGroup/42", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "42", "name": "mock segment group"}`)
+	})
+	return mux
+}
+
+func TestConfigCABundleFile(t *testing.T) {
+	server := httptest.NewTLSServer(newTestMockAPI())
+	defer server.Close()
+
+	config := Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		CustomerID:   "123",
+		Cloud:        "PRODUCTION",
+		BaseURL:      server.URL,
+		UserAgent:    "terraform-provider-zpa/test",
+		Retry:        RetryConfig{MaxRetries: 3, RetryOnStatus: defaultRetryOnStatus},
+	}
+	zClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := zClient.segmentgroup.Get("42"); err == nil {
+		t.Fatal("expected the self signed certificate of the mock API to be rejected")
+	}
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certificate, 0600); err != nil {
+		t.Fatalf("couldn't write the CA bundle: %v", err)
+	}
+	config.CABundleFile = bundle
+	zClient, err = config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := zClient.segmentgroup.Get("42"); err != nil {
+		t.Errorf("expected the CA bundle to be trusted, got %v", err)
+	}
+}
+
+func TestConfigHTTPProxy(t *testing.T) {
+	var proxied int32
+	api := newTestMockAPI()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxied plain http request carries the absolute URL of the target
+		if r.URL.Host == "zpa.example.com" {
+			atomic.AddInt32(&proxied, 1)
+		}
+		api.ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	config := Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		CustomerID:   "123",
+		Cloud:        "PRODUCTION",
+		BaseURL:      "http://zpa.example.com",
+		UserAgent:    "terraform-provider-zpa/test",
+		HTTPProxy:    proxy.URL,
+	}
+	zClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := zClient.segmentgroup.Get("42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proxied != 2 {
+		t.Errorf("expected the sign-in and the GET to go through the proxy, got %d proxied calls", proxied)
+	}
+}
+
+func TestConfigHTTPTransport(t *testing.T) {
+	transport, err := (&Config{MinTLSVersion: "1.3", InsecureSkipVerify: true}).httpTransport()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("unexpected TLS config %+v", transport.TLSClientConfig)
+	}
+	if _, err := (&Config{MinTLSVersion: "1.0"}).httpTransport(); err == nil {
+		t.Error("expected TLS 1.0 to be rejected")
+	}
+	if _, err := (&Config{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")}).httpTransport(); err == nil {
+		t.Error("expected a missing CA bundle to be rejected")
+	}
+}
+
+func TestClientWithContextCancellation(t *testing.T) {
+	var signins int32
+	release := make(chan struct{})
+	mux := newTestMockAPI()
+	mux.HandleFunc("/mgmtconfig/v1/admin/customers/123/segmentGroup/blocked", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/signin" {
+			atomic.AddInt32(&signins, 1)
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		CustomerID:   "123",
+		Cloud:        "PRODUCTION",
+		BaseURL:      server.URL,
+		UserAgent:    "terraform-provider-zpa/test",
+		Retry:        RetryConfig{MaxRetries: 3, RetryOnStatus: defaultRetryOnStatus},
+	}
+	zClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := zClient.withContext(context.Background()).segmentgroup.Get("42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := zClient.withContext(ctx).segmentgroup.Get("blocked")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the call to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the in-flight call wasn't cancelled with its context")
+	}
+	if signins != 1 {
+		t.Errorf("expected the scoped clients to share the API token, got %d sign-ins", signins)
+	}
+}
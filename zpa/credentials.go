@@ -0,0 +1,192 @@
+package zpa
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	defaultProfile         = "default"
+	defaultCredentialsFile = "~/.zpa/credentials"
+)
+
+// Credentials holds the values that can be resolved outside of the provider block,
+// the json tags are the keys a credential_process must print on its standard output.
+type Credentials struct {
+	ClientID          string `json:"zpa_client_id"`
+	ClientSecret      string `json:"zpa_client_secret"`
+	CustomerID        string `json:"zpa_customer_id"`
+	Cloud             string `json:"zpa_cloud"`
+	CredentialProcess string `json:"-"`
+}
+
+func (c *Credentials) complete() bool {
+	return c.ClientID != "" && c.ClientSecret != "" && c.CustomerID != ""
+}
+
+// merge fills the values that are still empty, values already set always win.
+func (c *Credentials) merge(other *Credentials) {
+	if c.ClientID == "" {
+		c.ClientID = other.ClientID
+	}
+	if c.ClientSecret == "" {
+		c.ClientSecret = other.ClientSecret
+	}
+	if c.CustomerID == "" {
+		c.CustomerID = other.CustomerID
+	}
+	if c.Cloud == "" {
+		c.Cloud = other.Cloud
+	}
+	if c.CredentialProcess == "" {
+		c.CredentialProcess = other.CredentialProcess
+	}
+}
+
+// resolveCredentials completes the credentials set in the provider block or in the environment variables,
+// first with the output of the credential_process, then with the values of the named profile.
+func resolveCredentials(creds Credentials, credentialsFile, profile string) (Credentials, error) {
+	if creds.complete() {
+		return creds, nil
+	}
+	profileCreds, err := loadCredentialsProfile(credentialsFile, profile)
+	if err != nil {
+		return creds, err
+	}
+	if profileCreds != nil && creds.CredentialProcess == "" {
+		creds.CredentialProcess = profileCreds.CredentialProcess
+	}
+	if creds.CredentialProcess != "" {
+		processCreds, err := runCredentialProcess(creds.CredentialProcess)
+		if err != nil {
+			return creds, err
+		}
+		creds.merge(processCreds)
+	}
+	if profileCreds != nil {
+		creds.merge(profileCreds)
+	}
+	return creds, nil
+}
+
+// loadCredentialsProfile reads a profile from an ini style credentials file:
+//
+//	[default]
+//	zpa_client_id     = xxxxxxxxxxxxxxxx
+//	zpa_client_secret = xxxxxxxxxxxxxxxx
+//	zpa_customer_id   = xxxxxxxxxxxxxxxx
+//	zpa_cloud         = BETA
+//
+// A missing file is not an error unless a non default profile was requested.
+func loadCredentialsProfile(path, profile string) (*Credentials, error) {
+	if profile == "" {
+		profile = defaultProfile
+	}
+	if path == "" {
+		path = defaultCredentialsFile
+	}
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && profile == defaultProfile {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("couldn't read the credentials file %s: %v", path, err)
+	}
+	log.Printf("[INFO] Loading profile %s from credentials file %s\n", profile, path)
+	profiles, err := parseCredentialsFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse the credentials file %s: %v", path, err)
+	}
+	creds, ok := profiles[profile]
+	if !ok {
+		if profile == defaultProfile {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %s not found in the credentials file %s", profile, path)
+	}
+	return creds, nil
+}
+
+func parseCredentialsFile(content []byte) (map[string]*Credentials, error) {
+	profiles := map[string]*Credentials{}
+	var current *Credentials
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[1:len(line)-1]), "profile "))
+			current = &Credentials{}
+			profiles[name] = current
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || current == nil {
+			return nil, fmt.Errorf("line %d: expected a [profile] header or a key = value pair", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "zpa_client_id":
+			current.ClientID = value
+		case "zpa_client_secret":
+			current.ClientSecret = value
+		case "zpa_customer_id":
+			current.CustomerID = value
+		case "zpa_cloud":
+			current.Cloud = value
+		case "credential_process":
+			current.CredentialProcess = value
+		default:
+			log.Printf("[WARN] ignoring unknown key %s in credentials file, line %d\n", strings.TrimSpace(key), lineNumber)
+		}
+	}
+	return profiles, scanner.Err()
+}
+
+// runCredentialProcess runs the command through the system shell and decodes the JSON object it prints.
+func runCredentialProcess(command string) (*Credentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", command)
+	} else {
+		cmd = exec.Command("/bin/sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+	log.Printf("[INFO] Running credential_process\n")
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credential_process failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var creds Credentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("credential_process must print one json object with keys: zpa_client_id, zpa_client_secret, zpa_customer_id and optionally zpa_cloud. error: %v", err)
+	}
+	return &creds, nil
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
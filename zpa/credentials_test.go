@@ -0,0 +1,83 @@
+package zpa
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+const testCredentialsFile = `
+# comment
+[default]
+zpa_client_id     = default-id
+zpa_client_secret = default-secret
+zpa_customer_id   = default-customer
+
+[profile ci]
+zpa_client_id     = ci-id
+zpa_client_secret = ci-secret
+zpa_customer_id   = ci-customer
+zpa_cloud         = BETA
+`
+
+func writeTestCredentialsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("couldn't write the credentials file: %v", err)
+	}
+	return path
+}
+
+func TestResolveCredentialsFromProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+
+	creds, err := resolveCredentials(Credentials{}, path, "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Credentials{ClientID: "ci-id", ClientSecret: "ci-secret", CustomerID: "ci-customer", Cloud: "BETA"}
+	if creds != expected {
+		t.Errorf("expected %+v, got %+v", expected, creds)
+	}
+
+	creds, err = resolveCredentials(Credentials{ClientID: "hcl-id"}, path, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.ClientID != "hcl-id" || creds.ClientSecret != "default-secret" {
+		t.Errorf("expected the provider block values to win over the profile, got %+v", creds)
+	}
+}
+
+func TestResolveCredentialsMissingProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+	if _, err := resolveCredentials(Credentials{}, path, "unknown"); err == nil {
+		t.Error("expected an error for a missing profile")
+	}
+	if _, err := resolveCredentials(Credentials{}, filepath.Join(t.TempDir(), "missing"), ""); err != nil {
+		t.Errorf("a missing credentials file must be ignored for the default profile, got %v", err)
+	}
+}
+
+func TestResolveCredentialsFromProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential_process test relies on /bin/sh")
+	}
+	path := writeTestCredentialsFile(t, `
+[process]
+credential_process = echo '{"zpa_client_id": "process-id", "zpa_client_secret": "process-secret", "zpa_customer_id": "process-customer"}'
+zpa_cloud = GOV
+`)
+	creds, err := resolveCredentials(Credentials{}, path, "process")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.ClientID != "process-id" || creds.ClientSecret != "process-secret" || creds.CustomerID != "process-customer" || creds.Cloud != "GOV" {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+
+	if _, err := resolveCredentials(Credentials{CredentialProcess: "exit 1"}, path, ""); err == nil {
+		t.Error("expected an error for a failing credential_process")
+	}
+}
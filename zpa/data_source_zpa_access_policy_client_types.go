@@ -1,15 +1,17 @@
 package zpa
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceAccessPolicyClientTypes() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceAccessPolicyClientTypesRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceAccessPolicyClientTypesRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"zpn_client_type_exporter": {
@@ -52,13 +54,13 @@ func dataSourceAccessPolicyClientTypes() *schema.Resource {
 	}
 }
 
-func dataSourceAccessPolicyClientTypesRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceAccessPolicyClientTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for all client types set\n")
 
 	resp, _, err := zClient.clienttypes.GetAllClientTypes()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting data for all client types:\n%+v\n", resp)
@@ -1,15 +1,17 @@
 package zpa
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceAccessPolicyPlatforms() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceAccessPolicyPlatformsRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceAccessPolicyPlatformsRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"linux": {
@@ -36,13 +38,13 @@ func dataSourceAccessPolicyPlatforms() *schema.Resource {
 	}
 }
 
-func dataSourceAccessPolicyPlatformsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceAccessPolicyPlatformsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for all platforms set\n")
 
 	resp, _, err := zClient.platforms.GetAllPlatforms()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting data for all platforms:\n%+v\n", resp)
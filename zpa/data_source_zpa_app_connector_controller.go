@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorcontroller"
 )
 
 func dataSourceAppConnectorController() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceAppConnectorControllerRead,
+		ReadContext: dataSourceAppConnectorControllerRead,
 		Schema: map[string]*schema.Schema{
 			"application_start_time": {
 				Type:     schema.TypeString,
@@ -161,8 +162,8 @@ func dataSourceAppConnectorController() *schema.Resource {
 	}
 }
 
-func dataSourceAppConnectorControllerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceAppConnectorControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *appconnectorcontroller.AppConnector
 	id, ok := d.Get("id").(string)
@@ -170,7 +171,7 @@ func dataSourceAppConnectorControllerRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for app connector  %s\n", id)
 		res, _, err := zClient.appconnectorcontroller.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -179,7 +180,7 @@ func dataSourceAppConnectorControllerRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for app connector name %s\n", name)
 		res, _, err := zClient.appconnectorcontroller.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -222,7 +223,7 @@ func dataSourceAppConnectorControllerRead(d *schema.ResourceData, m interface{})
 		_ = d.Set("upgrade_status", resp.UpgradeStatus)
 
 	} else {
-		return fmt.Errorf("couldn't find any app connector with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any app connector with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -8,9 +8,9 @@ import (
 )
 
 func TestAccDataSourceAppConnectorController_Basic(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckDataSourceAppConnectorControllerConfig_basic,
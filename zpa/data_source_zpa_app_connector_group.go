@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
 )
 
 func dataSourceAppConnectorGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceConnectorGroupRead,
+		ReadContext: dataSourceConnectorGroupRead,
 		Schema: map[string]*schema.Schema{
 			"connectors": {
 				Type:     schema.TypeList,
@@ -316,8 +317,8 @@ func dataSourceAppConnectorGroup() *schema.Resource {
 	}
 }
 
-func dataSourceConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceConnectorGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *appconnectorgroup.AppConnectorGroup
 	id, ok := d.Get("id").(string)
@@ -325,7 +326,7 @@ func dataSourceConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for app connector group  %s\n", id)
 		res, _, err := zClient.appconnectorgroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -334,7 +335,7 @@ func dataSourceConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for app connector group name %s\n", name)
 		res, _, err := zClient.appconnectorgroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -366,10 +367,10 @@ func dataSourceConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("connectors", flattenConnectors(resp.Connectors))
 
 		if err := d.Set("server_groups", flattenServerGroups(resp)); err != nil {
-			return fmt.Errorf("failed to read server groups %s", err)
+			return diag.Errorf("failed to read server groups %s", err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any app connector group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any app connector group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -13,10 +13,10 @@ import (
 func TestAccDataSourceAppConnectorGroup_Basic(t *testing.T) {
 	resourceTypeAndName, dataSourceTypeAndName, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAppConnectorGroupDestroy,
+		CheckDestroy: testAccCheckAppConnectorGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckAppConnectorGroupConfigure(resourceTypeAndName, generatedName, variable.AppConnectorDescription, variable.AppConnectorEnabled),
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appservercontroller"
 )
 
 func dataSourceApplicationServer() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceApplicationServerRead,
+		ReadContext: dataSourceApplicationServerRead,
 		Schema: map[string]*schema.Schema{
 			"address": {
 				Type:     schema.TypeString,
@@ -57,8 +58,8 @@ func dataSourceApplicationServer() *schema.Resource {
 	}
 }
 
-func dataSourceApplicationServerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceApplicationServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *appservercontroller.ApplicationServer
 	id, ok := d.Get("id").(string)
@@ -66,7 +67,7 @@ func dataSourceApplicationServerRead(d *schema.ResourceData, m interface{}) erro
 		log.Printf("[INFO] Getting data for application server  %s\n", id)
 		res, _, err := zClient.appservercontroller.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -75,7 +76,7 @@ func dataSourceApplicationServerRead(d *schema.ResourceData, m interface{}) erro
 		log.Printf("[INFO] Getting data for application server name %s\n", name)
 		res, _, err := zClient.appservercontroller.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -92,7 +93,7 @@ func dataSourceApplicationServerRead(d *schema.ResourceData, m interface{}) erro
 		_ = d.Set("name", resp.Name)
 
 	} else {
-		return fmt.Errorf("couldn't find any application server with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any application server with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
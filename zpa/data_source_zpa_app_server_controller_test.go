@@ -13,10 +13,10 @@ import (
 func TestAccDataSourceApplicationServer_Basic(t *testing.T) {
 	resourceTypeAndName, dataSourceTypeAndName, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAApplicationServer)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationServerDestroy,
+		CheckDestroy: testAccCheckApplicationServerDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationServerConfigure(resourceTypeAndName, generatedName, variable.AppServerDescription, variable.AppServerAddress, variable.AppServerEnabled),
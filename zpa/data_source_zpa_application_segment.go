@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
 )
 
 func dataSourceApplicationSegment() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceApplicationSegmentRead,
+		ReadContext: dataSourceApplicationSegmentRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -166,15 +167,15 @@ func dataSourceApplicationSegment() *schema.Resource {
 	}
 }
 
-func dataSourceApplicationSegmentRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceApplicationSegmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	var resp *applicationsegment.ApplicationSegmentResource
 	id, ok := d.Get("id").(string)
 	if ok && id != "" {
 		log.Printf("[INFO] Getting data for server group %s\n", id)
 		res, _, err := zClient.applicationsegment.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -183,7 +184,7 @@ func dataSourceApplicationSegmentRead(d *schema.ResourceData, m interface{}) err
 		log.Printf("[INFO] Getting data for server group name %s\n", name)
 		res, _, err := zClient.applicationsegment.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -213,19 +214,19 @@ func dataSourceApplicationSegmentRead(d *schema.ResourceData, m interface{}) err
 		_ = d.Set("udp_port_ranges", convertPortsToListString(resp.UDPAppPortRange))
 
 		if err := d.Set("server_groups", flattenAppServerGroups(resp)); err != nil {
-			return fmt.Errorf("failed to read app server groups %s", err)
+			return diag.Errorf("failed to read app server groups %s", err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("udp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 	} else {
-		return fmt.Errorf("couldn't find any application segment with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any application segment with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/browseraccess"
 )
 
 func dataSourceApplicationSegmentBrowserAccess() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceApplicationSegmentBrowserAccessRead,
+		ReadContext: dataSourceApplicationSegmentBrowserAccessRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -186,15 +187,15 @@ func dataSourceApplicationSegmentBrowserAccess() *schema.Resource {
 	}
 }
 
-func dataSourceApplicationSegmentBrowserAccessRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceApplicationSegmentBrowserAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	var resp *browseraccess.BrowserAccess
 	id, ok := d.Get("id").(string)
 	if ok && id != "" {
 		log.Printf("[INFO] Getting data for browser access application %s\n", id)
 		res, _, err := zClient.browseraccess.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -203,7 +204,7 @@ func dataSourceApplicationSegmentBrowserAccessRead(d *schema.ResourceData, m int
 		log.Printf("[INFO] Getting data for browser access application name %s\n", name)
 		res, _, err := zClient.browseraccess.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -227,22 +228,22 @@ func dataSourceApplicationSegmentBrowserAccessRead(d *schema.ResourceData, m int
 		_ = d.Set("udp_port_ranges", resp.UDPPortRanges)
 
 		if err := d.Set("clientless_apps", flattenBaClientlessApps(resp)); err != nil {
-			return fmt.Errorf("failed to read clientless apps %s", err)
+			return diag.Errorf("failed to read clientless apps %s", err)
 		}
 
 		if err := d.Set("server_groups", flattenClientlessAppServerGroups(resp.AppServerGroups)); err != nil {
-			return fmt.Errorf("failed to read app server groups %s", err)
+			return diag.Errorf("failed to read app server groups %s", err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any browser access application with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any browser access application with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -20,10 +20,10 @@ func TestAccDataSourceApplicationSegmentBrowserAccess_Basic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationSegmentBrowserAccessDestroy,
+		CheckDestroy: testAccCheckApplicationSegmentBrowserAccessDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationSegmentBrowserAccessConfigure(resourceTypeAndName, generatedName, generatedName, generatedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.BrowserAccessEnabled, variable.BrowserAccessCnameEnabled),
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentinspection"
 )
 
 func dataSourceApplicationSegmentInspection() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceApplicationSegmentInspectionRead,
+		ReadContext: dataSourceApplicationSegmentInspectionRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -178,15 +179,15 @@ func dataSourceApplicationSegmentInspection() *schema.Resource {
 	}
 }
 
-func dataSourceApplicationSegmentInspectionRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceApplicationSegmentInspectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	var resp *applicationsegmentinspection.AppSegmentInspection
 	id, ok := d.Get("id").(string)
 	if ok && id != "" {
 		log.Printf("[INFO] Getting data for inspection application segment %s\n", id)
 		res, _, err := zClient.applicationsegmentinspection.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -195,7 +196,7 @@ func dataSourceApplicationSegmentInspectionRead(d *schema.ResourceData, m interf
 		log.Printf("[INFO] Getting data for inspection application segment name %s\n", name)
 		res, _, err := zClient.applicationsegmentinspection.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -223,22 +224,22 @@ func dataSourceApplicationSegmentInspectionRead(d *schema.ResourceData, m interf
 		_ = d.Set("udp_port_ranges", resp.UDPPortRanges)
 
 		if err := d.Set("inspection_apps", flattenInspectionApps(resp)); err != nil {
-			return fmt.Errorf("failed to read inspection apps %s", err)
+			return diag.Errorf("failed to read inspection apps %s", err)
 		}
 
 		if err := d.Set("server_groups", flattenInspectionAppServerGroups(resp.AppServerGroups)); err != nil {
-			return fmt.Errorf("failed to read server groups for inspection app %s", err)
+			return diag.Errorf("failed to read server groups for inspection app %s", err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any inspection application segment with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any inspection application segment with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
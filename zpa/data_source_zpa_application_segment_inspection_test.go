@@ -19,10 +19,10 @@ func TestAccDataSourceApplicationSegmentInspection_Basic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationSegmentInspectionDestroy,
+		CheckDestroy: testAccCheckApplicationSegmentInspectionDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationSegmentInspectionConfigure(resourceTypeAndName, generatedName, generatedName, generatedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.AppSegmentEnabled, variable.AppSegmentCnameEnabled),
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentpra"
 )
 
 func dataSourceApplicationSegmentPRA() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceApplicationSegmentPRARead,
+		ReadContext: dataSourceApplicationSegmentPRARead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -174,15 +175,15 @@ func dataSourceApplicationSegmentPRA() *schema.Resource {
 	}
 }
 
-func dataSourceApplicationSegmentPRARead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceApplicationSegmentPRARead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	var resp *applicationsegmentpra.AppSegmentPRA
 	id, ok := d.Get("id").(string)
 	if ok && id != "" {
 		log.Printf("[INFO] Getting data for sra application %s\n", id)
 		res, _, err := zClient.applicationsegmentpra.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -191,7 +192,7 @@ func dataSourceApplicationSegmentPRARead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for sra application name %s\n", name)
 		res, _, err := zClient.applicationsegmentpra.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -215,22 +216,22 @@ func dataSourceApplicationSegmentPRARead(d *schema.ResourceData, m interface{})
 		_ = d.Set("udp_port_ranges", resp.UDPPortRanges)
 
 		if err := d.Set("sra_apps", flattenSRAApps(resp)); err != nil {
-			return fmt.Errorf("failed to read sra apps %s", err)
+			return diag.Errorf("failed to read sra apps %s", err)
 		}
 
 		if err := d.Set("server_groups", flattenSRAAppServerGroups(resp.ServerGroups)); err != nil {
-			return fmt.Errorf("failed to read app server groups %s", err)
+			return diag.Errorf("failed to read app server groups %s", err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any browser access application with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any browser access application with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/bacertificate"
 )
 
 func dataSourceBaCertificate() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBaCertificateRead,
+		ReadContext: dataSourceBaCertificateRead,
 		Schema: map[string]*schema.Schema{
 			"cname": {
 				Type:     schema.TypeString,
@@ -77,8 +78,8 @@ func dataSourceBaCertificate() *schema.Resource {
 	}
 }
 
-func dataSourceBaCertificateRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceBaCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *bacertificate.BaCertificate
 	id, ok := d.Get("id").(string)
@@ -86,7 +87,7 @@ func dataSourceBaCertificateRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for browser certificate %s\n", id)
 		res, _, err := zClient.bacertificate.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -96,7 +97,7 @@ func dataSourceBaCertificateRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for browser certificate name %s\n", name)
 		res, _, err := zClient.bacertificate.GetIssuedByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -118,7 +119,7 @@ func dataSourceBaCertificateRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("valid_from_in_epochsec", resp.ValidFromInEpochSec)
 		_ = d.Set("valid_to_in_epochsec", resp.ValidToInEpochSec)
 	} else {
-		return fmt.Errorf("couldn't find any browser certificate with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any browser certificate with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/cloudconnectorgroup"
 )
 
 func dataSourceCloudConnectorGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCloudConnectorGroupRead,
+		ReadContext: dataSourceCloudConnectorGroupRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -110,8 +111,8 @@ func dataSourceCloudConnectorGroup() *schema.Resource {
 	}
 }
 
-func dataSourceCloudConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceCloudConnectorGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *cloudconnectorgroup.CloudConnectorGroup
 	id, ok := d.Get("id").(string)
@@ -119,7 +120,7 @@ func dataSourceCloudConnectorGroupRead(d *schema.ResourceData, m interface{}) er
 		log.Printf("[INFO] Getting data for cloud connector group  %s\n", id)
 		res, _, err := zClient.cloudconnectorgroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -128,7 +129,7 @@ func dataSourceCloudConnectorGroupRead(d *schema.ResourceData, m interface{}) er
 		log.Printf("[INFO] Getting data for cloud connector group name %s\n", name)
 		res, _, err := zClient.cloudconnectorgroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -147,7 +148,7 @@ func dataSourceCloudConnectorGroupRead(d *schema.ResourceData, m interface{}) er
 		_ = d.Set("cloud_connectors", flattenCloudConnectors(resp))
 
 	} else {
-		return fmt.Errorf("couldn't find any cloud connector group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any cloud connector group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/customerversionprofile"
 )
 
 func dataSourceCustomerVersionProfile() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCustomerVersionProfileRead,
+		ReadContext: dataSourceCustomerVersionProfileRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -137,8 +138,8 @@ func dataSourceCustomerVersionProfile() *schema.Resource {
 	}
 }
 
-func dataSourceCustomerVersionProfileRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceCustomerVersionProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *customerversionprofile.CustomerVersionProfile
 	id, ok := d.Get("id").(string)
@@ -146,7 +147,7 @@ func dataSourceCustomerVersionProfileRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for customer version profile %s\n", id)
 		res, _, err := zClient.customerversionprofile.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -155,7 +156,7 @@ func dataSourceCustomerVersionProfileRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for customer version profile name %s\n", name)
 		res, _, err := zClient.customerversionprofile.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -174,14 +175,14 @@ func dataSourceCustomerVersionProfileRead(d *schema.ResourceData, m interface{})
 		// _ = d.Set("custom_scope_request_customer_ids.delete_customer_ids", resp.CustomScopeRequestCustomerIDs.DeletecustomerIDs)
 
 		if err := d.Set("custom_scope_customer_ids", flattenCustomerIDName(resp.CustomScopeCustomerIDs)); err != nil {
-			return fmt.Errorf("failed to read custom scope customer ids %s", err)
+			return diag.Errorf("failed to read custom scope customer ids %s", err)
 		}
 
 		if err := d.Set("versions", flattenVersions(resp.Versions)); err != nil {
-			return fmt.Errorf("failed to read versions %s", err)
+			return diag.Errorf("failed to read versions %s", err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any customer version profile with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any customer version profile with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/enrollmentcert"
 )
 
 func dataSourceEnrollmentCert() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceEnrollmentCertRead,
+		ReadContext: dataSourceEnrollmentCertRead,
 		Schema: map[string]*schema.Schema{
 			"allow_signing": {
 				Type:     schema.TypeBool,
@@ -105,8 +106,8 @@ func dataSourceEnrollmentCert() *schema.Resource {
 	}
 }
 
-func dataSourceEnrollmentCertRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceEnrollmentCertRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *enrollmentcert.EnrollmentCert
 	id, ok := d.Get("id").(string)
@@ -114,7 +115,7 @@ func dataSourceEnrollmentCertRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for signing certificate %s\n", id)
 		res, _, err := zClient.enrollmentcert.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -123,7 +124,7 @@ func dataSourceEnrollmentCertRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for signing certificate name %s\n", name)
 		res, _, err := zClient.enrollmentcert.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -151,7 +152,7 @@ func dataSourceEnrollmentCertRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("zrsa_encrypted_private_key", resp.ZrsaEncryptedPrivateKey)
 		_ = d.Set("zrsa_encrypted_session_key", resp.ZrsaEncryptedSessionKey)
 	} else {
-		return fmt.Errorf("couldn't find any signing certificate with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any signing certificate with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
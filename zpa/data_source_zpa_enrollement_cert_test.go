@@ -7,9 +7,9 @@ import (
 )
 
 func TestAccDataSourceEnrollmentCert_Basic(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckDataSourceEnrollmentCertConfig_basic,
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
 )
 
 func dataSourceIdpController() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIdpControllerRead,
+		ReadContext: dataSourceIdpControllerRead,
 		Schema: map[string]*schema.Schema{
 			"admin_metadata": {
 				Type:     schema.TypeList,
@@ -185,8 +186,8 @@ func dataSourceIdpController() *schema.Resource {
 	}
 }
 
-func dataSourceIdpControllerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceIdpControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *idpcontroller.IdpController
 	id, ok := d.Get("id").(string)
@@ -194,7 +195,7 @@ func dataSourceIdpControllerRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for idp controller %s\n", id)
 		res, _, err := zClient.idpcontroller.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 
@@ -204,7 +205,7 @@ func dataSourceIdpControllerRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for idp controller name %s\n", name)
 		res, _, err := zClient.idpcontroller.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -244,7 +245,7 @@ func dataSourceIdpControllerRead(d *schema.ResourceData, m interface{}) error {
 		}
 
 	} else {
-		return fmt.Errorf("couldn't find any idp controller with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any idp controller with name '%s' or id '%s'", name, id)
 	}
 	return nil
 }
@@ -1,15 +1,16 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_predefined_controls"
 )
 
 func dataSourceInspectionAllPredefinedControls() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceInspectionAllPredefinedControlsRead,
+		ReadContext: dataSourceInspectionAllPredefinedControlsRead,
 		Schema: map[string]*schema.Schema{
 			"version": {
 				Type:     schema.TypeString,
@@ -121,11 +122,11 @@ func dataSourceInspectionAllPredefinedControls() *schema.Resource {
 	}
 }
 
-func dataSourceInspectionAllPredefinedControlsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceInspectionAllPredefinedControlsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	version, versionSet := d.Get("version").(string)
 	if !versionSet || version == "" {
-		return fmt.Errorf("when the name is set, version must be set as well")
+		return diag.Errorf("when the name is set, version must be set as well")
 	}
 	var list []inspection_predefined_controls.PredefinedControls
 	var err error
@@ -136,7 +137,7 @@ func dataSourceInspectionAllPredefinedControlsRead(d *schema.ResourceData, m int
 		list, err = zClient.inspection_predefined_controls.GetAll(version)
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("predefined_controls")
 	_ = d.Set("list", flattenList(list))
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_custom_controls"
 )
 
 func dataSourceInspectionCustomControls() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceInspectionCustomControlsRead,
+		ReadContext: dataSourceInspectionCustomControlsRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -130,8 +131,8 @@ func dataSourceInspectionCustomControls() *schema.Resource {
 	}
 }
 
-func dataSourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceInspectionCustomControlsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *inspection_custom_controls.InspectionCustomControl
 	id, ok := d.Get("id").(string)
@@ -139,7 +140,7 @@ func dataSourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{
 		log.Printf("[INFO] Getting data for custom inspection control %s\n", id)
 		res, _, err := zClient.inspection_custom_controls.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -148,7 +149,7 @@ func dataSourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{
 		log.Printf("[INFO] Getting data for custom inspection control name %s\n", name)
 		res, _, err := zClient.inspection_custom_controls.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -173,11 +174,11 @@ func dataSourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{
 		_ = d.Set("type", resp.Type)
 
 		if err := d.Set("rules", flattenInspectionCustomRules(resp.Rules)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 	} else {
-		return fmt.Errorf("couldn't find any custom inspection controls with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any custom inspection controls with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -11,10 +11,10 @@ import (
 func TestAccDataSourceInspectionCustomControls_Basic(t *testing.T) {
 	resourceTypeAndName, dataSourceTypeAndName, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAInspectionCustomControl)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckInspectionCustomControlsDestroy,
+		CheckDestroy: testAccCheckInspectionCustomControlsDestroy(provider),
 		Steps: []resource.TestStep{
 
 			{
@@ -30,7 +30,6 @@ func TestAccDataSourceInspectionCustomControls_Basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceTypeAndName, "type", resourceTypeAndName, "type"),
 					resource.TestCheckResourceAttr(dataSourceTypeAndName, "rules.#", "2"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 		},
 	})
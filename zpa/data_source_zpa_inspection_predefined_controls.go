@@ -1,9 +1,10 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_predefined_controls"
@@ -11,7 +12,7 @@ import (
 
 func dataSourceInspectionPredefinedControls() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceInspectionPredefinedControlsRead,
+		ReadContext: dataSourceInspectionPredefinedControlsRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -108,8 +109,8 @@ func dataSourceInspectionPredefinedControls() *schema.Resource {
 	}
 }
 
-func dataSourceInspectionPredefinedControlsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceInspectionPredefinedControlsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *inspection_predefined_controls.PredefinedControls
 	id, ok := d.Get("id").(string)
@@ -117,7 +118,7 @@ func dataSourceInspectionPredefinedControlsRead(d *schema.ResourceData, m interf
 		log.Printf("[INFO] Getting data for predefined controls %s\n", id)
 		res, _, err := zClient.inspection_predefined_controls.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -125,12 +126,12 @@ func dataSourceInspectionPredefinedControlsRead(d *schema.ResourceData, m interf
 	if ok && name != "" {
 		version, versionSet := d.Get("version").(string)
 		if !versionSet || version == "" {
-			return fmt.Errorf("when the name is set, version must be set as well")
+			return diag.Errorf("when the name is set, version must be set as well")
 		}
 		log.Printf("[INFO] Getting data for predefined controls name %s\n", name)
 		res, _, err := zClient.inspection_predefined_controls.GetByName(name, version)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -155,7 +156,7 @@ func dataSourceInspectionPredefinedControlsRead(d *schema.ResourceData, m interf
 		_ = d.Set("version", resp.Version)
 		_ = d.Set("associated_inspection_profile_names", flattenInspectionProfileNames(resp.AssociatedInspectionProfileNames))
 	} else {
-		return fmt.Errorf("couldn't find any predefined inspection controls with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any predefined inspection controls with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_profile"
 )
 
 func dataSourceInspectionProfile() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceInspectionProfileRead,
+		ReadContext: dataSourceInspectionProfileRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -359,8 +360,8 @@ func dataSourceInspectionProfile() *schema.Resource {
 	}
 }
 
-func dataSourceInspectionProfileRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceInspectionProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *inspection_profile.InspectionProfile
 	id, ok := d.Get("id").(string)
@@ -368,7 +369,7 @@ func dataSourceInspectionProfileRead(d *schema.ResourceData, m interface{}) erro
 		log.Printf("[INFO] Getting data for inspection profile  %s\n", id)
 		res, _, err := zClient.inspection_profile.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -377,7 +378,7 @@ func dataSourceInspectionProfileRead(d *schema.ResourceData, m interface{}) erro
 		log.Printf("[INFO] Getting data for inspection profile name %s\n", name)
 		res, _, err := zClient.inspection_profile.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -395,22 +396,22 @@ func dataSourceInspectionProfileRead(d *schema.ResourceData, m interface{}) erro
 		_ = d.Set("predefined_controls_version", resp.PredefinedControlsVersion)
 
 		if err := d.Set("controls_info", flattenControlInfoResource(resp.ControlInfoResource)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("custom_controls", flattenCustomControls(resp.CustomControls)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("predefined_controls", flattenPredefinedControls(resp.PredefinedControls)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("web_socket_controls", flattenPredefinedControlsSimple(resp.WebSocketControls)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any inspection profile with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any inspection profile with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
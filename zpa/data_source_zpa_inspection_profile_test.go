@@ -12,10 +12,10 @@ import (
 func TestAccDataSourceInspectionProfile_Basic(t *testing.T) {
 	resourceTypeAndName, dataSourceTypeAndName, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAInspectionProfile)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckInspectionProfileDestroy,
+		CheckDestroy: testAccCheckInspectionProfileDestroy(provider),
 		Steps: []resource.TestStep{
 
 			{
@@ -1,15 +1,17 @@
 package zpa
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceLSSClientTypes() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceLSSClientTypesRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceLSSClientTypesRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"zpn_client_type_exporter": {
@@ -40,13 +42,13 @@ func dataSourceLSSClientTypes() *schema.Resource {
 	}
 }
 
-func dataSourceLSSClientTypesRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceLSSClientTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for global policy set\n")
 
 	resp, _, err := zClient.lssconfigcontroller.GetClientTypes()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting Policy Set Global Rules:\n%+v\n", resp)
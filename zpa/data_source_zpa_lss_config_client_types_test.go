@@ -7,9 +7,9 @@ import (
 )
 
 func TestAccDataSourceLSSClientTypes_Basic(t *testing.T) {
-	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: (testAccCheckDataSourceLSSClientTypesConfig_basic),
@@ -1,10 +1,11 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"html"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,8 +13,8 @@ import (
 
 func dataSourceLSSConfigController() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceLSSConfigControllerRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceLSSConfigControllerRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -275,8 +276,8 @@ func dataSourceLSSConfigController() *schema.Resource {
 	}
 }
 
-func dataSourceLSSConfigControllerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceLSSConfigControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *lssconfigcontroller.LSSResource
 	id, ok := d.Get("id").(string)
@@ -284,7 +285,7 @@ func dataSourceLSSConfigControllerRead(d *schema.ResourceData, m interface{}) er
 		log.Printf("[INFO] Getting data for lss config controller %s\n", id)
 		res, _, err := zClient.lssconfigcontroller.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -293,23 +294,23 @@ func dataSourceLSSConfigControllerRead(d *schema.ResourceData, m interface{}) er
 		log.Printf("[INFO] Getting data for lss config controller %s\n", name)
 		res, _, err := zClient.lssconfigcontroller.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
 	if resp != nil {
 		d.SetId(resp.ID)
 		if err := d.Set("config", flattenLSSConfig(resp.LSSConfig)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		_ = d.Set("connector_groups", flattenConnectorGroups(resp.ConnectorGroups))
 
 		if err := d.Set("policy_rule", flattenLSSPolicyRule(resp.PolicyRule)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any lss config controller with name '%s' or id", id)
+		return diag.Errorf("couldn't find any lss config controller with name '%s' or id", id)
 	}
 
 	return nil
@@ -19,10 +19,10 @@ func TestAccDataSourceLSSConfigController_Basic(t *testing.T) {
 	appConnectorGroupTypeAndName, _, appConnectorGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 	appConnectorGroupHCL := testAccCheckAppConnectorGroupConfigure(appConnectorGroupTypeAndName, appConnectorGroupGeneratedName, variable.AppConnectorDescription, variable.AppConnectorEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLSSConfigControllerDestroy,
+		CheckDestroy: testAccCheckLSSConfigControllerDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckLSSConfigControllerConfigure(resourceTypeAndName, generatedName, generatedName, generatedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, rIP, rPort, variable.LSSControllerEnabled, variable.LSSControllerTLSEnabled),
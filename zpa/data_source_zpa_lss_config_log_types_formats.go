@@ -1,17 +1,18 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceLSSLogTypeFormats() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceLSSLogTypeFormatsRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceLSSLogTypeFormatsRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"log_type": {
@@ -53,16 +54,16 @@ func getLogType(d *schema.ResourceData) (string, bool) {
 	value, ok := val.(string)
 	return value, ok
 }
-func dataSourceLSSLogTypeFormatsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceLSSLogTypeFormatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for LSS Log Types Format set\n")
 	logType, ok := getLogType(d)
 	if !ok {
-		return fmt.Errorf("[ERROR] log type is required")
+		return diag.Errorf("[ERROR] log type is required")
 	}
 	resp, _, err := zClient.lssconfigcontroller.GetFormats(logType)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting LSS Log Types Format:\n%+v\n", resp)
@@ -1,16 +1,18 @@
 package zpa
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceLSSStatusCodes() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceLSSStatusCodesRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceLSSStatusCodesRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"zpn_auth_log": {
@@ -58,13 +60,13 @@ func toMapString(v map[string]interface{}) map[string]string {
 
 	return result
 }
-func dataSourceLSSStatusCodesRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceLSSStatusCodesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for LSS Status Codes set\n")
 
 	resp, _, err := zClient.lssconfigcontroller.GetStatusCodes()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting LSS Status Codes:\n%+v\n", resp)
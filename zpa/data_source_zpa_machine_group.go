@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/machinegroup"
 )
 
 func dataSourceMachineGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceMachineGroupRead,
+		ReadContext: dataSourceMachineGroupRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -103,8 +104,8 @@ func dataSourceMachineGroup() *schema.Resource {
 	}
 }
 
-func dataSourceMachineGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceMachineGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *machinegroup.MachineGroup
 	id, ok := d.Get("id").(string)
@@ -112,7 +113,7 @@ func dataSourceMachineGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for machine group  %s\n", id)
 		res, _, err := zClient.machinegroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -121,7 +122,7 @@ func dataSourceMachineGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for machine group name %s\n", name)
 		res, _, err := zClient.machinegroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -136,7 +137,7 @@ func dataSourceMachineGroupRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("machines", flattenMachines(resp))
 
 	} else {
-		return fmt.Errorf("couldn't find any machine group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any machine group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -0,0 +1,460 @@
+package zpa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+const (
+	policyFindingDeletedReference = "DELETED_REFERENCE"
+	policyFindingUnsatisfiable    = "UNSATISFIABLE"
+	policyFindingDuplicate        = "DUPLICATE"
+	policyFindingShadowed         = "SHADOWED"
+)
+
+func dataSourcePolicyAnalysis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"policy_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The policy type of the policy set whose rules are analyzed.",
+				ValidateFunc: validation.StringInSlice(policyRuleOrderTypes, false),
+			},
+			"warn_on_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Report every finding as a warning when the data source is read.",
+			},
+			"policy_set_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"findings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The findings, by rule order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the finding: DELETED_REFERENCE, UNSATISFIABLE, DUPLICATE or SHADOWED.",
+						},
+						"rule_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_order": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"related_rule_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The earlier rule a DUPLICATE or SHADOWED rule is covered by.",
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyAnalysisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policyType := d.Get("policy_type").(string)
+
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := getOrderedRules(zClient, policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	findings, err := analyzePolicyRules(rules, newPolicyReferenceChecker(zClient).exists)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Analyzed %d rules of policy type %s, %d findings\n", len(rules), policyType, len(findings))
+
+	d.SetId(policySet.ID)
+	_ = d.Set("policy_set_id", policySet.ID)
+	items := make([]interface{}, len(findings))
+	var diags diag.Diagnostics
+	for i, finding := range findings {
+		items[i] = map[string]interface{}{
+			"type":            finding.kind,
+			"rule_id":         finding.rule.ID,
+			"rule_name":       finding.rule.Name,
+			"rule_order":      finding.rule.RuleOrder,
+			"related_rule_id": finding.relatedRuleID,
+			"message":         finding.message,
+		}
+		if d.Get("warn_on_plan").(bool) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s policy rule %s: %s", policyType, finding.rule.ID, finding.kind),
+				Detail:   finding.message,
+			})
+		}
+	}
+	if err := d.Set("findings", items); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+type policyAnalysisFinding struct {
+	kind          string
+	rule          policysetcontroller.PolicyRule
+	relatedRuleID string
+	message       string
+}
+
+// analyzePolicyRules returns the findings of the rules, which must be sorted by rule order.
+// A rule that can never match isn't reported as a duplicate or as shadowed, and doesn't shadow the rules after it.
+func analyzePolicyRules(rules []policysetcontroller.PolicyRule, exists func(objectReference) (bool, error)) ([]policyAnalysisFinding, error) {
+	var findings []policyAnalysisFinding
+	satisfiable := make([]bool, len(rules))
+	for i, rule := range rules {
+		for _, reference := range policyRuleReferences(rule) {
+			ok, err := exists(reference)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				findings = append(findings, policyAnalysisFinding{
+					kind:    policyFindingDeletedReference,
+					rule:    rule,
+					message: fmt.Sprintf("rule %q refers to the %s %s, which doesn't exist", rule.Name, reference.kind, reference.id),
+				})
+			}
+		}
+		if reason := policyRuleUnsatisfiable(rule); reason != "" {
+			findings = append(findings, policyAnalysisFinding{
+				kind:    policyFindingUnsatisfiable,
+				rule:    rule,
+				message: fmt.Sprintf("rule %q can never match: %s", rule.Name, reason),
+			})
+			continue
+		}
+		satisfiable[i] = true
+		for j := 0; j < i; j++ {
+			earlier := rules[j]
+			if !satisfiable[j] || !policyRuleImplies(rule, earlier) {
+				continue
+			}
+			if earlier.Action == rule.Action && policyRuleImplies(earlier, rule) {
+				findings = append(findings, policyAnalysisFinding{
+					kind:          policyFindingDuplicate,
+					rule:          rule,
+					relatedRuleID: earlier.ID,
+					message:       fmt.Sprintf("rule %q has the conditions and the action of the earlier rule %q", rule.Name, earlier.Name),
+				})
+			} else {
+				findings = append(findings, policyAnalysisFinding{
+					kind:          policyFindingShadowed,
+					rule:          rule,
+					relatedRuleID: earlier.ID,
+					message:       fmt.Sprintf("rule %q is unreachable, every access it matches is matched first by the rule %q", rule.Name, earlier.Name),
+				})
+			}
+			break
+		}
+	}
+	return findings, nil
+}
+
+// policyRuleImplies reports whether every access rule b matches is matched by rule a too, judging by the operands
+// of the rules alone. It may miss that b is covered by a, but never reports it wrongly.
+func policyRuleImplies(b, a policysetcontroller.PolicyRule) bool {
+	if len(b.Conditions) == 0 {
+		return len(a.Conditions) == 0
+	}
+	if b.Operator == "OR" {
+		for _, condition := range b.Conditions {
+			if !policyConditionsImplyRule([]policysetcontroller.Conditions{condition}, a) {
+				return false
+			}
+		}
+		return true
+	}
+	return policyConditionsImplyRule(b.Conditions, a)
+}
+
+// policyConditionsImplyRule reports whether the conditions, which must all match, imply the rule.
+func policyConditionsImplyRule(conditions []policysetcontroller.Conditions, rule policysetcontroller.PolicyRule) bool {
+	implied := func(target policysetcontroller.Conditions) bool {
+		for _, condition := range conditions {
+			if policyConditionImplies(condition, target) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(rule.Conditions) == 0 {
+		return true
+	}
+	for _, target := range rule.Conditions {
+		if rule.Operator == "OR" && implied(target) {
+			return true
+		}
+		if rule.Operator != "OR" && !implied(target) {
+			return false
+		}
+	}
+	return rule.Operator != "OR"
+}
+
+// policyConditionImplies reports whether condition b implies condition a.
+func policyConditionImplies(b, a policysetcontroller.Conditions) bool {
+	if b.Negated != a.Negated {
+		return false
+	}
+	if b.Negated {
+		// not b implies not a when a implies b
+		b, a = a, b
+	}
+	bOperands, aOperands := policyOperandKeys(b), policyOperandKeys(a)
+	if len(aOperands) == 0 {
+		return true
+	}
+	if len(bOperands) == 0 {
+		return false
+	}
+	bAll, aAll := policyConditionMatchesAll(b), policyConditionMatchesAll(a)
+	switch {
+	case !bAll && !aAll:
+		return policyOperandKeysSubset(bOperands, aOperands)
+	case bAll && aAll:
+		return policyOperandKeysSubset(aOperands, bOperands)
+	case bAll && !aAll:
+		for key := range bOperands {
+			if aOperands[key] {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// policyConditionMatchesAll reports whether all the operands of the condition must match, rather than any.
+func policyConditionMatchesAll(condition policysetcontroller.Conditions) bool {
+	return condition.Operator == "AND" && len(condition.Operands) > 1
+}
+
+func policyOperandKey(operand policysetcontroller.Operands) string {
+	return operand.ObjectType + "/" + operand.LHS + "/" + operand.RHS
+}
+
+func policyOperandKeys(condition policysetcontroller.Conditions) map[string]bool {
+	keys := map[string]bool{}
+	for _, operand := range condition.Operands {
+		keys[policyOperandKey(operand)] = true
+	}
+	return keys
+}
+
+func policyOperandKeysSubset(keys, of map[string]bool) bool {
+	for key := range keys {
+		if !of[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// policyRuleUnsatisfiable returns why the rule can never match, or an empty string when it may.
+func policyRuleUnsatisfiable(rule policysetcontroller.PolicyRule) string {
+	if len(rule.Conditions) == 0 {
+		return ""
+	}
+	if rule.Operator == "OR" {
+		var reasons []string
+		for i, condition := range rule.Conditions {
+			if condition.Negated || !policyConditionMatchesAll(condition) {
+				return ""
+			}
+			reason := policyOperandsConflict(condition.Operands)
+			if reason == "" {
+				return ""
+			}
+			reasons = append(reasons, fmt.Sprintf("condition %d: %s", i+1, reason))
+		}
+		return strings.Join(reasons, "; ")
+	}
+
+	// the operands every access the rule matches has
+	var required []policysetcontroller.Operands
+	for _, condition := range rule.Conditions {
+		if !condition.Negated && (policyConditionMatchesAll(condition) || len(condition.Operands) == 1) {
+			required = append(required, condition.Operands...)
+		}
+	}
+	if reason := policyOperandsConflict(required); reason != "" {
+		return reason
+	}
+	for i, condition := range rule.Conditions {
+		if !condition.Negated || policyConditionMatchesAll(condition) {
+			continue
+		}
+		for _, operand := range condition.Operands {
+			for _, r := range required {
+				if policyOperandKey(operand) == policyOperandKey(r) {
+					return fmt.Sprintf("negated condition %d excludes the %s operand %s the other conditions require", i+1, operand.ObjectType, policyOperandValue(operand))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// policyOperandsConflict returns why no access can match all the operands, or an empty string when one may.
+func policyOperandsConflict(operands []policysetcontroller.Operands) string {
+	for i, a := range operands {
+		for _, b := range operands[i+1:] {
+			if a.ObjectType != b.ObjectType || policyOperandKey(a) == policyOperandKey(b) {
+				continue
+			}
+			conflict := false
+			switch a.ObjectType {
+			case "APP", "APP_GROUP", "CLIENT_TYPE":
+				// an access is to a single application segment, with a single client type
+				conflict = a.RHS != b.RHS
+			case "PLATFORM", "COUNTRY_CODE":
+				conflict = a.RHS == "true" && b.RHS == "true" && a.LHS != b.LHS
+			case "TRUSTED_NETWORK":
+				// a device is on a single trusted network at a time
+				conflict = (a.LHS == b.LHS && a.RHS != b.RHS) || (a.LHS != b.LHS && a.RHS == "true" && b.RHS == "true")
+			case "POSTURE":
+				conflict = a.LHS == b.LHS && a.RHS != b.RHS
+			}
+			if conflict {
+				return fmt.Sprintf("the %s operands %s and %s can't both match", a.ObjectType, policyOperandValue(a), policyOperandValue(b))
+			}
+		}
+	}
+	return ""
+}
+
+// policyOperandValue describes the operand by the part that tells it apart from the other operands of its object type.
+func policyOperandValue(operand policysetcontroller.Operands) string {
+	switch operand.ObjectType {
+	case "PLATFORM", "COUNTRY_CODE", "TRUSTED_NETWORK", "POSTURE":
+		return fmt.Sprintf("%s=%s", operand.LHS, operand.RHS)
+	default:
+		return operand.RHS
+	}
+}
+
+// policyReferenceChecker looks up the objects policy rules refer to, every object is looked up once.
+type policyReferenceChecker struct {
+	zClient *Client
+	found   map[objectReference]bool
+	// the IDs of the kinds of objects that are looked up by listing them all
+	lists map[string]map[string]bool
+}
+
+func newPolicyReferenceChecker(zClient *Client) *policyReferenceChecker {
+	return &policyReferenceChecker{
+		zClient: zClient,
+		found:   map[objectReference]bool{},
+		lists:   map[string]map[string]bool{},
+	}
+}
+
+func (c *policyReferenceChecker) exists(reference objectReference) (bool, error) {
+	if found, ok := c.found[reference]; ok {
+		return found, nil
+	}
+	var err error
+	switch reference.kind {
+	case "posture profile", "trusted network", "location", "branch connector group":
+		return c.listed(reference)
+	case "application segment":
+		_, _, err = c.zClient.applicationsegment.Get(reference.id)
+	case "segment group":
+		_, _, err = c.zClient.segmentgroup.Get(reference.id)
+	case "IdP":
+		_, _, err = c.zClient.idpcontroller.Get(reference.id)
+	case "SCIM group":
+		_, _, err = c.zClient.scimgroup.Get(reference.id)
+	case "SAML attribute":
+		_, _, err = c.zClient.samlattribute.Get(reference.id)
+	case "machine group":
+		_, _, err = c.zClient.machinegroup.Get(reference.id)
+	case "cloud connector group":
+		_, _, err = c.zClient.cloudconnectorgroup.Get(reference.id)
+	case "server group":
+		_, _, err = c.zClient.servergroup.Get(reference.id)
+	case "app connector group":
+		_, _, err = c.zClient.appconnectorgroup.Get(reference.id)
+	}
+	if err != nil {
+		if !isNotFoundError(err) {
+			return false, fmt.Errorf("failed to get the %s %s: %w", reference.kind, reference.id, err)
+		}
+	}
+	c.found[reference] = err == nil
+	return err == nil, nil
+}
+
+func (c *policyReferenceChecker) listed(reference objectReference) (bool, error) {
+	ids, ok := c.lists[reference.kind]
+	if !ok {
+		ids = map[string]bool{}
+		switch reference.kind {
+		case "posture profile":
+			list, _, err := c.zClient.postureprofile.GetAll()
+			if err != nil {
+				return false, err
+			}
+			for _, profile := range list {
+				ids[profile.PostureudID] = true
+			}
+		case "trusted network":
+			list, _, err := c.zClient.trustednetwork.GetAll()
+			if err != nil {
+				return false, err
+			}
+			for _, network := range list {
+				ids[network.NetworkID] = true
+			}
+		case "location", "branch connector group":
+			service := c.zClient.locationcontroller
+			if reference.kind == "branch connector group" {
+				service = c.zClient.branchconnectorgroup
+			}
+			list, _, err := service.GetAll()
+			if err != nil {
+				return false, err
+			}
+			for _, summary := range list {
+				ids[summary.ID] = true
+			}
+		}
+		c.lists[reference.kind] = ids
+	}
+	return ids[reference.id], nil
+}
@@ -0,0 +1,230 @@
+package zpa
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func TestAccDataSourcePolicyAnalysis_Basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourcePolicyAnalysisConfigure(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zpa_policy_analysis.this", "findings.#", "3"),
+					resource.TestCheckResourceAttr("data.zpa_policy_analysis.this", "findings.0.type", "SHADOWED"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_analysis.this", "findings.0.rule_id", resourcetype.ZPAPolicyAccessRule+".finance", "id"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_analysis.this", "findings.0.related_rule_id", resourcetype.ZPAPolicyAccessRule+".segment_group", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_analysis.this", "findings.1.type", "DUPLICATE"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_analysis.this", "findings.1.rule_id", resourcetype.ZPAPolicyAccessRule+".duplicate", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_analysis.this", "findings.2.type", "UNSATISFIABLE"),
+					resource.TestCheckResourceAttr("data.zpa_policy_analysis.this", "findings.2.message", fmt.Sprintf("rule %q can never match: the CLIENT_TYPE operands zpn_client_type_zapp and zpn_client_type_exporter can't both match", rName+"-client-types")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourcePolicyAnalysisConfigure(rName string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "finance" {
+	name     = "Finance"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_policy_type" "access_policy" {
+	policy_type = "ACCESS_POLICY"
+}
+
+resource "%[1]s" "this" {
+	name    = "%[2]s"
+	enabled = true
+}
+
+resource "%[3]s" "segment_group" {
+	name          = "%[2]s-segment-group"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "ALLOW"
+	operator      = "AND"
+	rule_order    = 1
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %[1]s.this.id
+		}
+	}
+}
+
+resource "%[3]s" "finance" {
+	name          = "%[2]s-finance"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "DENY"
+	operator      = "AND"
+	insert_after  = %[3]s.segment_group.id
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %[1]s.this.id
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs         = data.zpa_idp_controller.users.id
+			rhs         = data.zpa_scim_groups.finance.id
+		}
+	}
+}
+
+resource "%[3]s" "duplicate" {
+	name          = "%[2]s-duplicate"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "ALLOW"
+	operator      = "AND"
+	insert_after  = %[3]s.finance.id
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %[1]s.this.id
+		}
+	}
+}
+
+resource "%[3]s" "client_types" {
+	name          = "%[2]s-client-types"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "ALLOW"
+	operator      = "AND"
+	insert_after  = %[3]s.duplicate.id
+	conditions {
+		operator = "AND"
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_zapp"
+		}
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_exporter"
+		}
+	}
+}
+
+data "zpa_policy_analysis" "this" {
+	policy_type  = "ACCESS_POLICY"
+	warn_on_plan = true
+	depends_on   = [%[3]s.segment_group, %[3]s.finance, %[3]s.duplicate, %[3]s.client_types]
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAPolicyAccessRule)
+}
+
+func TestAnalyzePolicyRules(t *testing.T) {
+	app := func(id string) policysetcontroller.Operands {
+		return policysetcontroller.Operands{ObjectType: "APP", LHS: "id", RHS: id}
+	}
+	network := func(id string) policysetcontroller.Operands {
+		return policysetcontroller.Operands{ObjectType: "TRUSTED_NETWORK", LHS: id, RHS: "true"}
+	}
+	rules := []policysetcontroller.PolicyRule{
+		{ID: "1", Name: "apps", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("a"), app("b")}},
+		}},
+		{ID: "2", Name: "networks", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operator: "AND", Operands: []policysetcontroller.Operands{network("n1"), network("n2")}},
+		}},
+		{ID: "3", Name: "app a on n1", Action: "DENY", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("a")}},
+			{Operator: "OR", Operands: []policysetcontroller.Operands{network("n1")}},
+		}},
+		{ID: "4", Name: "app c off n1", Action: "DENY", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("c")}},
+			{Negated: true, Operands: []policysetcontroller.Operands{network("n1")}},
+		}},
+		{ID: "5", Name: "app c off n1 or n2", Action: "DENY", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("c")}},
+			{Negated: true, Operator: "OR", Operands: []policysetcontroller.Operands{network("n1"), network("n2")}},
+		}},
+		{ID: "6", Name: "app c on n1 off n1", Action: "DENY", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("c")}},
+			{Operator: "OR", Operands: []policysetcontroller.Operands{network("n1")}},
+			{Negated: true, Operands: []policysetcontroller.Operands{network("n1")}},
+		}},
+		{ID: "7", Name: "apps again", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operator: "OR", Operands: []policysetcontroller.Operands{app("b"), app("a")}},
+		}},
+		{ID: "8", Name: "deleted app", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operands: []policysetcontroller.Operands{app("deleted")}},
+		}, AppServerGroups: []policysetcontroller.AppServerGroups{{ID: "deleted"}}},
+	}
+	exists := func(reference objectReference) (bool, error) {
+		return reference.id != "deleted", nil
+	}
+	findings, err := analyzePolicyRules(rules, exists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][3]string
+	for _, finding := range findings {
+		got = append(got, [3]string{finding.rule.ID, finding.kind, finding.relatedRuleID})
+	}
+	expected := [][3]string{
+		{"2", policyFindingUnsatisfiable, ""},
+		{"3", policyFindingShadowed, "1"},
+		{"5", policyFindingShadowed, "4"},
+		{"6", policyFindingUnsatisfiable, ""},
+		{"7", policyFindingDuplicate, "1"},
+		{"8", policyFindingDeletedReference, ""},
+		{"8", policyFindingDeletedReference, ""},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the findings %v, got %v", expected, got)
+	}
+	if expected := `rule "networks" can never match: the TRUSTED_NETWORK operands n1=true and n2=true can't both match`; findings[0].message != expected {
+		t.Errorf("expected the message %q, got %q", expected, findings[0].message)
+	}
+	if expected := `rule "deleted app" refers to the server group deleted, which doesn't exist`; findings[6].message != expected {
+		t.Errorf("expected the message %q, got %q", expected, findings[6].message)
+	}
+}
+
+func TestPolicyRuleImplies(t *testing.T) {
+	app := func(id string) policysetcontroller.Conditions {
+		return policysetcontroller.Conditions{Operands: []policysetcontroller.Operands{{ObjectType: "APP", LHS: "id", RHS: id}}}
+	}
+	apps := policysetcontroller.PolicyRule{Conditions: []policysetcontroller.Conditions{
+		{Operator: "OR", Operands: append(app("a").Operands, app("b").Operands...)},
+	}}
+	eitherApp := policysetcontroller.PolicyRule{Operator: "OR", Conditions: []policysetcontroller.Conditions{app("b"), app("a")}}
+	everything := policysetcontroller.PolicyRule{}
+	if !policyRuleImplies(eitherApp, apps) {
+		t.Error("expected a rule matching either application to imply a rule matching both")
+	}
+	if !policyRuleImplies(apps, everything) || policyRuleImplies(everything, apps) {
+		t.Error("expected only a rule without conditions to match every access")
+	}
+	if policyRuleImplies(apps, policysetcontroller.PolicyRule{Conditions: []policysetcontroller.Conditions{app("a")}}) {
+		t.Error("expected a rule matching two applications not to imply a rule matching one of them")
+	}
+}
@@ -0,0 +1,395 @@
+package zpa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func dataSourcePolicyEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyEvaluationRead,
+		Schema: map[string]*schema.Schema{
+			"app_segment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The application segment that is accessed, APP_GROUP operands match its segment group.",
+			},
+			"scim_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The SCIM groups of the user.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"saml_attributes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The SAML attributes of the user, a multi-valued attribute is repeated for every value.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the SAML attribute.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the SAML attribute.",
+						},
+					},
+				},
+			},
+			"client_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The client type of the access.",
+				ValidateFunc: validation.StringInSlice(policyClientTypes, false),
+			},
+			"platform": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The platform of the device.",
+				ValidateFunc: validation.StringInSlice(platformOperandLHS, false),
+			},
+			"posture_results": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The results of the posture profiles on the device, by posture UDID. POSTURE operands of the other profiles don't match.",
+				Elem: &schema.Schema{
+					Type: schema.TypeBool,
+				},
+			},
+			"trusted_networks": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The network IDs of the trusted networks the device is on.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"country_code": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The country code of the location of the device.",
+			},
+			"segment_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The segment group of the application segment.",
+			},
+			"matched": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a rule other than the default rule matches.",
+			},
+			"indeterminate": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the evaluation stopped at a rule whose match depends on operands that can't be evaluated, the last rule of skipped. No rule matches then.",
+			},
+			"rule_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the first rule that matches, the default rule when no other rule does.",
+			},
+			"rule_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The action of the rule that matches.",
+			},
+			"skipped": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules evaluated before the rule that matches, by rule order, with the reason they don't match or can't be evaluated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_order": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyEvaluationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+
+	appSegmentID := d.Get("app_segment_id").(string)
+	appSegment, _, err := zClient.applicationsegment.Get(appSegmentID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get application segment %s: %w", appSegmentID, err))
+	}
+	attributes := policyEvaluationAttributes{
+		appSegmentID:    appSegmentID,
+		segmentGroupID:  appSegment.SegmentGroupID,
+		scimGroupIDs:    map[string]bool{},
+		samlAttributes:  map[string][]string{},
+		clientType:      d.Get("client_type").(string),
+		platform:        d.Get("platform").(string),
+		postureResults:  map[string]bool{},
+		trustedNetworks: map[string]bool{},
+		countryCode:     d.Get("country_code").(string),
+	}
+	for _, id := range SetToStringList(d, "scim_group_ids") {
+		attributes.scimGroupIDs[id] = true
+	}
+	for _, attribute := range d.Get("saml_attributes").(*schema.Set).List() {
+		attribute := attribute.(map[string]interface{})
+		id := attribute["id"].(string)
+		attributes.samlAttributes[id] = append(attributes.samlAttributes[id], attribute["value"].(string))
+	}
+	for udid, result := range d.Get("posture_results").(map[string]interface{}) {
+		attributes.postureResults[udid] = result.(bool)
+	}
+	for _, id := range SetToStringList(d, "trusted_networks") {
+		attributes.trustedNetworks[id] = true
+	}
+
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, _, err := zClient.policysetcontroller.GetAllByType("ACCESS_POLICY")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	evaluation := evaluateAccessPolicy(rules, attributes)
+	log.Printf("[INFO] Evaluated %d access policy rules for application segment %s, %d skipped\n", len(rules), appSegmentID, len(evaluation.skipped))
+
+	d.SetId(policySet.ID)
+	_ = d.Set("segment_group_id", appSegment.SegmentGroupID)
+	_ = d.Set("matched", evaluation.rule != nil && !evaluation.rule.DefaultRule)
+	_ = d.Set("indeterminate", evaluation.indeterminate)
+	if evaluation.rule != nil {
+		_ = d.Set("rule_id", evaluation.rule.ID)
+		_ = d.Set("rule_name", evaluation.rule.Name)
+		_ = d.Set("action", evaluation.rule.Action)
+	} else {
+		_ = d.Set("rule_id", "")
+		_ = d.Set("rule_name", "")
+		_ = d.Set("action", "")
+	}
+	skipped := make([]interface{}, len(evaluation.skipped))
+	for i, skip := range evaluation.skipped {
+		skipped[i] = map[string]interface{}{
+			"id":         skip.rule.ID,
+			"name":       skip.rule.Name,
+			"rule_order": skip.rule.RuleOrder,
+			"action":     skip.rule.Action,
+			"reason":     skip.reason,
+		}
+	}
+	if err := d.Set("skipped", skipped); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// policyEvaluationAttributes are the attributes of an access the operands of the access policy rules are evaluated against.
+type policyEvaluationAttributes struct {
+	appSegmentID    string
+	segmentGroupID  string
+	scimGroupIDs    map[string]bool
+	samlAttributes  map[string][]string
+	clientType      string
+	platform        string
+	postureResults  map[string]bool
+	trustedNetworks map[string]bool
+	countryCode     string
+}
+
+// policyEvaluation is the first rule that matches an access, nil when no rule does, and the rules skipped before it.
+// When indeterminate, the evaluation stopped at the last skipped rule, it can't tell whether that rule matches.
+type policyEvaluation struct {
+	rule          *policysetcontroller.PolicyRule
+	skipped       []policyEvaluationSkip
+	indeterminate bool
+}
+
+// policyMatch is the result of evaluating a rule, a condition or an operand. It is indeterminate when it depends on
+// operands of object types that can't be evaluated.
+type policyMatch int
+
+const (
+	policyMatchNo policyMatch = iota
+	policyMatchYes
+	policyMatchIndeterminate
+)
+
+type policyEvaluationSkip struct {
+	rule   policysetcontroller.PolicyRule
+	reason string
+}
+
+// evaluateAccessPolicy evaluates the rules by rule order and returns the first one that matches the attributes.
+// The default rule matches when no other rule does. The evaluation stops at the first rule that is indeterminate,
+// the rules after it would only be reached when it doesn't match.
+func evaluateAccessPolicy(rules []policysetcontroller.PolicyRule, attributes policyEvaluationAttributes) policyEvaluation {
+	ordered := make([]policysetcontroller.PolicyRule, 0, len(rules))
+	var defaultRule *policysetcontroller.PolicyRule
+	for i := range rules {
+		if rules[i].DefaultRule {
+			defaultRule = &rules[i]
+			continue
+		}
+		ordered = append(ordered, rules[i])
+	}
+	sortPolicyRules(ordered)
+
+	var evaluation policyEvaluation
+	for i := range ordered {
+		matches, reason := attributes.ruleMatches(ordered[i])
+		if matches != policyMatchYes {
+			evaluation.skipped = append(evaluation.skipped, policyEvaluationSkip{rule: ordered[i], reason: reason})
+		}
+		switch matches {
+		case policyMatchNo:
+			continue
+		case policyMatchIndeterminate:
+			evaluation.indeterminate = true
+			return evaluation
+		}
+		evaluation.rule = &ordered[i]
+		return evaluation
+	}
+	evaluation.rule = defaultRule
+	return evaluation
+}
+
+// ruleMatches reports whether the rule matches, and the reason when it doesn't or is indeterminate.
+// The conditions of a rule are combined with its operator, AND unless it is OR, and a rule without conditions matches.
+// An indeterminate condition makes the rule indeterminate unless another condition decides the rule.
+func (a policyEvaluationAttributes) ruleMatches(rule policysetcontroller.PolicyRule) (policyMatch, string) {
+	if len(rule.Conditions) == 0 {
+		return policyMatchYes, ""
+	}
+	var reasons, indeterminate []string
+	for i, condition := range rule.Conditions {
+		matches, unsupported := a.conditionMatches(condition)
+		objectTypes := strings.Join(conditionObjectTypes(condition), ", ")
+		switch {
+		case matches == policyMatchIndeterminate:
+			indeterminate = append(indeterminate, fmt.Sprintf("condition %d (%s) can't be evaluated, %s operands aren't supported", i+1, objectTypes, strings.Join(unsupported, ", ")))
+			continue
+		case matches == policyMatchYes && rule.Operator == "OR":
+			return policyMatchYes, ""
+		case matches == policyMatchYes:
+			continue
+		}
+		reason := fmt.Sprintf("condition %d (%s) doesn't match", i+1, objectTypes)
+		if condition.Negated {
+			reason = fmt.Sprintf("negated condition %d (%s) matches", i+1, objectTypes)
+		}
+		if rule.Operator != "OR" {
+			return policyMatchNo, reason
+		}
+		reasons = append(reasons, reason)
+	}
+	if len(indeterminate) > 0 {
+		return policyMatchIndeterminate, strings.Join(indeterminate, "; ")
+	}
+	if rule.Operator == "OR" {
+		return policyMatchNo, "no condition matches: " + strings.Join(reasons, "; ")
+	}
+	return policyMatchYes, ""
+}
+
+// conditionMatches reports whether the condition matches, and the object types of its operands that can't be evaluated.
+// The operands of a condition are combined with its operator, OR unless it is AND. The condition is indeterminate, negated
+// or not, when the operands that can't be evaluated could change the result.
+func (a policyEvaluationAttributes) conditionMatches(condition policysetcontroller.Conditions) (policyMatch, []string) {
+	matchesAll := condition.Operator == "AND"
+	matches := len(condition.Operands) == 0 || matchesAll
+	var unsupported []string
+	for _, operand := range condition.Operands {
+		operandMatches, ok := a.operandMatches(operand)
+		if !ok {
+			if !contains(unsupported, operand.ObjectType) {
+				unsupported = append(unsupported, operand.ObjectType)
+			}
+			continue
+		}
+		if matchesAll {
+			matches = matches && operandMatches
+		} else {
+			matches = matches || operandMatches
+		}
+	}
+	switch {
+	case len(unsupported) > 0 && matches == matchesAll:
+		return policyMatchIndeterminate, unsupported
+	case matches != condition.Negated:
+		return policyMatchYes, unsupported
+	}
+	return policyMatchNo, unsupported
+}
+
+// operandMatches reports whether the operand matches, and false when its object type can't be evaluated.
+func (a policyEvaluationAttributes) operandMatches(operand policysetcontroller.Operands) (bool, bool) {
+	switch operand.ObjectType {
+	case "APP":
+		return operand.RHS == a.appSegmentID, true
+	case "APP_GROUP":
+		return operand.RHS == a.segmentGroupID, true
+	case "SCIM_GROUP":
+		return a.scimGroupIDs[operand.RHS], true
+	case "SAML":
+		return contains(a.samlAttributes[operand.LHS], operand.RHS), true
+	case "CLIENT_TYPE":
+		return operand.RHS == a.clientType, true
+	case "PLATFORM":
+		return operand.LHS == a.platform && operand.RHS == "true", true
+	case "POSTURE":
+		result, ok := a.postureResults[operand.LHS]
+		return ok && strconv.FormatBool(result) == operand.RHS, true
+	case "TRUSTED_NETWORK":
+		return strconv.FormatBool(a.trustedNetworks[operand.LHS]) == operand.RHS, true
+	case "COUNTRY_CODE":
+		return strconv.FormatBool(a.countryCode != "" && strings.EqualFold(operand.LHS, a.countryCode)) == operand.RHS, true
+	default:
+		return false, false
+	}
+}
+
+func conditionObjectTypes(condition policysetcontroller.Conditions) []string {
+	var objectTypes []string
+	for _, operand := range condition.Operands {
+		if !contains(objectTypes, operand.ObjectType) {
+			objectTypes = append(objectTypes, operand.ObjectType)
+		}
+	}
+	return objectTypes
+}
@@ -0,0 +1,236 @@
+package zpa
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func TestAccDataSourcePolicyEvaluation_Basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourcePolicyEvaluationConfigure(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zpa_policy_evaluation.finance", "segment_group_id", resourcetype.ZPASegmentGroup+".this", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.finance", "matched", "true"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_evaluation.finance", "rule_id", resourcetype.ZPAPolicyAccessRule+".deny_finance", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.finance", "action", "DENY"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.finance", "skipped.#", "0"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.engineering", "matched", "true"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_evaluation.engineering", "rule_id", resourcetype.ZPAPolicyAccessRule+".allow_zapp", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.engineering", "action", "ALLOW"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.engineering", "skipped.#", "1"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.engineering", "skipped.0.reason", "condition 2 (SCIM_GROUP) doesn't match"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.exporter", "matched", "false"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.exporter", "skipped.#", "2"),
+					resource.TestCheckResourceAttr("data.zpa_policy_evaluation.exporter", "skipped.1.reason", "condition 2 (CLIENT_TYPE) doesn't match"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourcePolicyEvaluationConfigure(rName string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "engineering" {
+	name     = "Engineering"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "finance" {
+	name     = "Finance"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_policy_type" "access_policy" {
+	policy_type = "ACCESS_POLICY"
+}
+
+resource "%[1]s" "this" {
+	name    = "%[2]s"
+	enabled = true
+}
+
+resource "%[3]s" "this" {
+	name             = "%[2]s"
+	enabled          = true
+	health_reporting = "ON_ACCESS"
+	bypass_type      = "NEVER"
+	tcp_port_ranges  = ["443", "443"]
+	domain_names     = ["%[2]s.example.com"]
+	segment_group_id = %[1]s.this.id
+	server_groups {
+		id = []
+	}
+}
+
+resource "%[4]s" "deny_finance" {
+	name          = "%[2]s-deny-finance"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "DENY"
+	operator      = "AND"
+	rule_order    = 1
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %[1]s.this.id
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs         = data.zpa_idp_controller.users.id
+			rhs         = data.zpa_scim_groups.finance.id
+		}
+	}
+}
+
+resource "%[4]s" "allow_zapp" {
+	name          = "%[2]s-allow-zapp"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "ALLOW"
+	operator      = "AND"
+	insert_after  = %[4]s.deny_finance.id
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP"
+			lhs         = "id"
+			rhs         = %[3]s.this.id
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_zapp"
+		}
+	}
+}
+
+data "zpa_policy_evaluation" "finance" {
+	app_segment_id = %[3]s.this.id
+	scim_group_ids = [data.zpa_scim_groups.finance.id]
+	client_type    = "zpn_client_type_zapp"
+	depends_on     = [%[4]s.deny_finance, %[4]s.allow_zapp]
+}
+
+data "zpa_policy_evaluation" "engineering" {
+	app_segment_id = %[3]s.this.id
+	scim_group_ids = [data.zpa_scim_groups.engineering.id]
+	client_type    = "zpn_client_type_zapp"
+	depends_on     = [%[4]s.deny_finance, %[4]s.allow_zapp]
+}
+
+data "zpa_policy_evaluation" "exporter" {
+	app_segment_id = %[3]s.this.id
+	scim_group_ids = [data.zpa_scim_groups.engineering.id]
+	client_type    = "zpn_client_type_exporter"
+	depends_on     = [%[4]s.deny_finance, %[4]s.allow_zapp]
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAApplicationSegment, resourcetype.ZPAPolicyAccessRule)
+}
+
+func TestEvaluateAccessPolicy(t *testing.T) {
+	rules := []policysetcontroller.PolicyRule{
+		{ID: "default", RuleOrder: "3", DefaultRule: true, Action: "DENY"},
+		{ID: "posture", RuleOrder: "2", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operator: "AND", Operands: []policysetcontroller.Operands{
+				{ObjectType: "POSTURE", LHS: "udid", RHS: "true"},
+				{ObjectType: "TRUSTED_NETWORK", LHS: "net", RHS: "true"},
+			}},
+			{Negated: true, Operands: []policysetcontroller.Operands{{ObjectType: "MACHINE_GRP", LHS: "id", RHS: "1"}}},
+		}},
+		{ID: "contractors", RuleOrder: "1", Action: "DENY", Operator: "OR", Conditions: []policysetcontroller.Conditions{
+			{Operands: []policysetcontroller.Operands{{ObjectType: "SAML", LHS: "role", RHS: "contractor"}}},
+			{Negated: true, Operands: []policysetcontroller.Operands{{ObjectType: "COUNTRY_CODE", LHS: "US", RHS: "true"}}},
+			{Operator: "AND", Operands: []policysetcontroller.Operands{{ObjectType: "MACHINE_GRP", LHS: "id", RHS: "1"}, {ObjectType: "CLIENT_TYPE", LHS: "id", RHS: "zpn_client_type_exporter"}}},
+		}},
+	}
+	contractorsReason := "no condition matches: condition 1 (SAML) doesn't match; negated condition 2 (COUNTRY_CODE) matches; condition 3 (MACHINE_GRP, CLIENT_TYPE) doesn't match"
+	tests := []struct {
+		name          string
+		attributes    policyEvaluationAttributes
+		rule          string
+		skipped       []string
+		indeterminate bool
+	}{
+		{
+			name:       "contractor",
+			attributes: policyEvaluationAttributes{samlAttributes: map[string][]string{"role": {"employee", "contractor"}}, countryCode: "us"},
+			rule:       "contractors",
+		},
+		{
+			name:       "outside the US",
+			attributes: policyEvaluationAttributes{countryCode: "CA"},
+			rule:       "contractors",
+		},
+		{
+			name:          "exporter",
+			attributes:    policyEvaluationAttributes{countryCode: "US", clientType: "zpn_client_type_exporter"},
+			skipped:       []string{"condition 3 (MACHINE_GRP, CLIENT_TYPE) can't be evaluated, MACHINE_GRP operands aren't supported"},
+			indeterminate: true,
+		},
+		{
+			name:          "posture and trusted network",
+			attributes:    policyEvaluationAttributes{countryCode: "US", clientType: "zpn_client_type_zapp", postureResults: map[string]bool{"udid": true}, trustedNetworks: map[string]bool{"net": true}},
+			skipped:       []string{contractorsReason, "condition 2 (MACHINE_GRP) can't be evaluated, MACHINE_GRP operands aren't supported"},
+			indeterminate: true,
+		},
+		{
+			name:       "failed posture",
+			attributes: policyEvaluationAttributes{countryCode: "US", clientType: "zpn_client_type_zapp", postureResults: map[string]bool{"udid": false}, trustedNetworks: map[string]bool{"net": true}},
+			rule:       "default",
+			skipped:    []string{contractorsReason, "condition 1 (POSTURE, TRUSTED_NETWORK) doesn't match"},
+		},
+	}
+	for _, test := range tests {
+		evaluation := evaluateAccessPolicy(rules, test.attributes)
+		if test.indeterminate {
+			if evaluation.rule != nil || !evaluation.indeterminate {
+				t.Errorf("%s: expected the evaluation to be indeterminate, got %+v", test.name, evaluation)
+			}
+		} else if evaluation.rule == nil || evaluation.rule.ID != test.rule || evaluation.indeterminate {
+			t.Errorf("%s: expected rule %s to match, got %+v", test.name, test.rule, evaluation.rule)
+		}
+		var skipped []string
+		for _, skip := range evaluation.skipped {
+			skipped = append(skipped, skip.reason)
+		}
+		if !reflect.DeepEqual(skipped, test.skipped) {
+			t.Errorf("%s: expected the skipped rules %q, got %q", test.name, test.skipped, skipped)
+		}
+	}
+}
+
+func TestEvaluateAccessPolicyWithoutDefaultRule(t *testing.T) {
+	rules := []policysetcontroller.PolicyRule{
+		{ID: "app", RuleOrder: "1", Action: "ALLOW", Conditions: []policysetcontroller.Conditions{
+			{Operands: []policysetcontroller.Operands{{ObjectType: "APP", LHS: "id", RHS: "1"}, {ObjectType: "APP_GROUP", LHS: "id", RHS: "2"}}},
+		}},
+	}
+	if evaluation := evaluateAccessPolicy(rules, policyEvaluationAttributes{appSegmentID: "3", segmentGroupID: "2"}); evaluation.rule == nil || evaluation.rule.ID != "app" {
+		t.Errorf("expected the segment group to match, got %+v", evaluation.rule)
+	}
+	if evaluation := evaluateAccessPolicy(rules, policyEvaluationAttributes{appSegmentID: "3"}); evaluation.rule != nil || len(evaluation.skipped) != 1 {
+		t.Errorf("expected no rule to match, got %+v", evaluation)
+	}
+}
@@ -0,0 +1,237 @@
+package zpa
+
+import (
+	"context"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func dataSourcePolicyRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyRulesRead,
+		Schema: map[string]*schema.Schema{
+			"policy_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The policy type of the policy set whose rules are listed.",
+				ValidateFunc: validation.StringInSlice(policyRuleOrderTypes, false),
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list the rules whose name matches the regular expression.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the rules with the action.",
+			},
+			"app_segment_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the rules with an APP operand for the application segment.",
+			},
+			"segment_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the rules with an APP_GROUP operand for the segment group.",
+			},
+			"scim_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the rules with a SCIM_GROUP operand for the SCIM group.",
+			},
+			"policy_set_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the listed rules, by rule order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The listed rules, by rule order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_order": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conditions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"negated": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"operator": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"operands": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"id": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"idp_id": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"lhs": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"object_type": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"rhs": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policyType := d.Get("policy_type").(string)
+	log.Printf("[INFO] Getting the rules of policy type %s\n", policyType)
+
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := getOrderedRules(zClient, policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := policyRulesFilter{
+		action: d.Get("action").(string),
+		operands: map[string]string{
+			"APP":        d.Get("app_segment_id").(string),
+			"APP_GROUP":  d.Get("segment_group_id").(string),
+			"SCIM_GROUP": d.Get("scim_group_id").(string),
+		},
+	}
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		filter.name = regexp.MustCompile(nameRegex.(string))
+	}
+
+	ids := []string{}
+	items := []interface{}{}
+	for _, rule := range rules {
+		if !filter.matches(rule) {
+			continue
+		}
+		ids = append(ids, rule.ID)
+		items = append(items, map[string]interface{}{
+			"id":          rule.ID,
+			"name":        rule.Name,
+			"description": rule.Description,
+			"rule_order":  rule.RuleOrder,
+			"action":      rule.Action,
+			"operator":    rule.Operator,
+			"conditions":  flattenPolicyConditions(rule.Conditions),
+		})
+	}
+	log.Printf("[INFO] %d of the %d rules of policy type %s match the filters\n", len(ids), len(rules), policyType)
+
+	d.SetId(policySet.ID)
+	_ = d.Set("policy_set_id", policySet.ID)
+	_ = d.Set("ids", ids)
+	if err := d.Set("rules", items); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// policyRulesFilter selects the rules listed by zpa_policy_rules, every filter that is set must match.
+type policyRulesFilter struct {
+	name   *regexp.Regexp
+	action string
+	// operands maps an object type to the RHS one of the operands of the rule must have, empty RHS are ignored
+	operands map[string]string
+}
+
+func (f policyRulesFilter) matches(rule policysetcontroller.PolicyRule) bool {
+	if f.name != nil && !f.name.MatchString(rule.Name) {
+		return false
+	}
+	if f.action != "" && f.action != rule.Action {
+		return false
+	}
+	for objectType, rhs := range f.operands {
+		if rhs != "" && !policyRuleHasOperand(rule, objectType, rhs) {
+			return false
+		}
+	}
+	return true
+}
+
+// policyRuleHasOperand reports whether a condition of the rule, negated or not, has the operand.
+func policyRuleHasOperand(rule policysetcontroller.PolicyRule, objectType, rhs string) bool {
+	for _, condition := range rule.Conditions {
+		for _, operand := range condition.Operands {
+			if operand.ObjectType == objectType && operand.RHS == rhs {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package zpa
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func TestAccDataSourcePolicyRules_Basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourcePolicyRulesConfigure(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.all", "rules.#", "2"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_rules.all", "ids.0", resourcetype.ZPAPolicyAccessRule+".segment_group", "id"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_rules.all", "ids.1", resourcetype.ZPAPolicyAccessRule+".scim_group", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.all", "rules.0.name", rName+"-segment-group"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.all", "rules.0.rule_order", "1"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.all", "rules.0.conditions.0.operands.0.object_type", "APP_GROUP"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.segment_group", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_rules.segment_group", "ids.0", resourcetype.ZPAPolicyAccessRule+".segment_group", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.scim_group", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zpa_policy_rules.scim_group", "ids.0", resourcetype.ZPAPolicyAccessRule+".scim_group", "id"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.deny", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.deny", "rules.0.action", "DENY"),
+					resource.TestCheckResourceAttr("data.zpa_policy_rules.none", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourcePolicyRulesConfigure(rName string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "engineering" {
+	name     = "Engineering"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_policy_type" "access_policy" {
+	policy_type = "ACCESS_POLICY"
+}
+
+resource "%[1]s" "this" {
+	name    = "%[2]s"
+	enabled = true
+}
+
+resource "%[3]s" "segment_group" {
+	name          = "%[2]s-segment-group"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "ALLOW"
+	operator      = "AND"
+	rule_order    = 1
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %[1]s.this.id
+		}
+	}
+}
+
+resource "%[3]s" "scim_group" {
+	name          = "%[2]s-scim-group"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	action        = "DENY"
+	operator      = "AND"
+	insert_after  = %[3]s.segment_group.id
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs         = data.zpa_idp_controller.users.id
+			rhs         = data.zpa_scim_groups.engineering.id
+		}
+	}
+}
+
+data "zpa_policy_rules" "all" {
+	policy_type = "ACCESS_POLICY"
+	name_regex  = "^%[2]s-"
+	depends_on  = [%[3]s.segment_group, %[3]s.scim_group]
+}
+
+data "zpa_policy_rules" "segment_group" {
+	policy_type      = "ACCESS_POLICY"
+	segment_group_id = %[1]s.this.id
+	depends_on       = [%[3]s.segment_group, %[3]s.scim_group]
+}
+
+data "zpa_policy_rules" "scim_group" {
+	policy_type   = "ACCESS_POLICY"
+	scim_group_id = data.zpa_scim_groups.engineering.id
+	depends_on    = [%[3]s.segment_group, %[3]s.scim_group]
+}
+
+data "zpa_policy_rules" "deny" {
+	policy_type = "ACCESS_POLICY"
+	name_regex  = "^%[2]s-"
+	action      = "DENY"
+	depends_on  = [%[3]s.segment_group, %[3]s.scim_group]
+}
+
+data "zpa_policy_rules" "none" {
+	policy_type      = "ACCESS_POLICY"
+	segment_group_id = %[1]s.this.id
+	action           = "DENY"
+	depends_on       = [%[3]s.segment_group, %[3]s.scim_group]
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAPolicyAccessRule)
+}
+
+func TestPolicyRulesFilter(t *testing.T) {
+	rule := policysetcontroller.PolicyRule{
+		Name:   "Engineering",
+		Action: "ALLOW",
+		Conditions: []policysetcontroller.Conditions{
+			{Operands: []policysetcontroller.Operands{{ObjectType: "APP", RHS: "1"}}},
+			{Negated: true, Operands: []policysetcontroller.Operands{{ObjectType: "SCIM_GROUP", RHS: "2"}}},
+		},
+	}
+	tests := []struct {
+		filter  policyRulesFilter
+		matches bool
+	}{
+		{policyRulesFilter{}, true},
+		{policyRulesFilter{name: regexp.MustCompile("^Eng")}, true},
+		{policyRulesFilter{name: regexp.MustCompile("^Fin")}, false},
+		{policyRulesFilter{action: "DENY"}, false},
+		{policyRulesFilter{operands: map[string]string{"APP": "1", "SCIM_GROUP": "2", "APP_GROUP": ""}}, true},
+		{policyRulesFilter{operands: map[string]string{"APP": "2"}}, false},
+		{policyRulesFilter{operands: map[string]string{"APP_GROUP": "1"}}, false},
+	}
+	for i, test := range tests {
+		if matches := test.filter.matches(rule); matches != test.matches {
+			t.Errorf("filter %d: expected %t, got %t", i, test.matches, matches)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package zpa
 
 import (
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
@@ -10,7 +12,7 @@ import (
 
 func dataSourcePolicyType() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourcePolicyTypeRead,
+		ReadContext: dataSourcePolicyTypeRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -141,10 +143,18 @@ func dataSourcePolicyType() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"zpn_isolation_profile_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"zpn_inspection_profile_id": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"zpn_inspection_profile_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"conditions": {
 							Type:     schema.TypeList,
 							Computed: true,
@@ -232,8 +242,8 @@ func dataSourcePolicyType() *schema.Resource {
 	}
 }
 
-func dataSourcePolicyTypeRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourcePolicyTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	log.Printf("[INFO] Getting data for policy type\n")
 	var resp *policysetcontroller.PolicySet
 	var err error
@@ -244,7 +254,7 @@ func dataSourcePolicyTypeRead(d *schema.ResourceData, m interface{}) error {
 		resp, _, err = zClient.policysetcontroller.GetByPolicyType("GLOBAL_POLICY")
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting data for Policy Type:\n%+v\n", resp)
@@ -259,7 +269,7 @@ func dataSourcePolicyTypeRead(d *schema.ResourceData, m interface{}) error {
 	_ = d.Set("policy_type", resp.PolicyType)
 
 	if err := d.Set("rules", flattenPolicySetRules(resp)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
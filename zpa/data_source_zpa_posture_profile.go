@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/postureprofile"
 )
 
 func dataSourcePostureProfile() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourcePostureProfileRead,
+		ReadContext: dataSourcePostureProfileRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -56,8 +57,8 @@ func dataSourcePostureProfile() *schema.Resource {
 	}
 }
 
-func dataSourcePostureProfileRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourcePostureProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *postureprofile.PostureProfile
 	id, ok := d.Get("id").(string)
@@ -65,7 +66,7 @@ func dataSourcePostureProfileRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for posture profile %s\n", id)
 		res, _, err := zClient.postureprofile.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -74,7 +75,7 @@ func dataSourcePostureProfileRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for posture profile name %s\n", name)
 		res, _, err := zClient.postureprofile.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -91,7 +92,7 @@ func dataSourcePostureProfileRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("zscaler_customer_id", resp.ZscalerCustomerID)
 
 	} else {
-		return fmt.Errorf("couldn't find any posture profile with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any posture profile with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
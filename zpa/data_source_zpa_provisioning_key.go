@@ -1,9 +1,10 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/provisioningkey"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,8 +13,8 @@ import (
 
 func dataSourceProvisioningKey() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceProvisioningKeyRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceProvisioningKeyRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -106,11 +107,11 @@ func dataSourceProvisioningKey() *schema.Resource {
 	}
 }
 
-func dataSourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceProvisioningKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	associationType, ok := getAssociationType(d)
 	if !ok {
-		return fmt.Errorf("associationType is required")
+		return diag.Errorf("associationType is required")
 	}
 	var resp *provisioningkey.ProvisioningKey
 	id, ok := d.Get("id").(string)
@@ -118,7 +119,7 @@ func dataSourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error
 		log.Printf("[INFO] Getting data provisioning key %s\n", id)
 		res, _, err := zClient.provisioningkey.Get(associationType, id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -127,7 +128,7 @@ func dataSourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error
 		log.Printf("[INFO] Getting data for provisioning key name %s\n", name)
 		res, _, err := zClient.provisioningkey.GetByName(associationType, name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -151,7 +152,7 @@ func dataSourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error
 		_ = d.Set("zcomponent_id", resp.ZcomponentID)
 		_ = d.Set("zcomponent_name", resp.ZcomponentName)
 	} else {
-		return fmt.Errorf("couldn't find any provisioning key with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any provisioning key with name '%s' or id '%s'", name, id)
 	}
 	return nil
 }
@@ -1,9 +1,10 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
@@ -11,7 +12,7 @@ import (
 
 func dataSourceSamlAttribute() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceSamlAttributeRead,
+		ReadContext: dataSourceSamlAttributeRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -55,15 +56,15 @@ func dataSourceSamlAttribute() *schema.Resource {
 	}
 }
 
-func dataSourceSamlAttributeRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceSamlAttributeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *samlattribute.SamlAttribute
 	idpId, okidpId := d.Get("idp_id").(string)
 	idpName, okIdpName := d.Get("idp_name").(string)
 	if !okIdpName && !okidpId || idpId == "" && idpName == "" {
 		log.Printf("[INFO] idp name or id is required\n")
-		return fmt.Errorf("idp name or id is required")
+		return diag.Errorf("idp name or id is required")
 	}
 	var idpResp *idpcontroller.IdpController
 	// getting Idp controller by id or name
@@ -71,14 +72,14 @@ func dataSourceSamlAttributeRead(d *schema.ResourceData, m interface{}) error {
 		resp, _, err := zClient.idpcontroller.Get(idpId)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by id: %s\n", idpId)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	} else {
 		resp, _, err := zClient.idpcontroller.GetByName(idpName)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by name: %s\n", idpName)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	}
@@ -87,7 +88,7 @@ func dataSourceSamlAttributeRead(d *schema.ResourceData, m interface{}) error {
 	if ok && id != "" {
 		res, _, err := zClient.samlattribute.Get(idpResp.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -95,7 +96,7 @@ func dataSourceSamlAttributeRead(d *schema.ResourceData, m interface{}) error {
 	if id == "" && ok && name != "" {
 		res, _, err := zClient.samlattribute.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -110,7 +111,7 @@ func dataSourceSamlAttributeRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("saml_name", resp.SamlName)
 		_ = d.Set("user_attribute", resp.UserAttribute)
 	} else {
-		return fmt.Errorf("couldn't find any saml attribute with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any saml attribute with name '%s' or id '%s'", name, id)
 	}
 	return nil
 }
@@ -1,9 +1,10 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
@@ -11,7 +12,7 @@ import (
 
 func dataSourceScimAttributeHeader() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceScimAttributeHeaderRead,
+		ReadContext: dataSourceScimAttributeHeaderRead,
 		Schema: map[string]*schema.Schema{
 			"canonical_values": {
 				Type:     schema.TypeList,
@@ -91,15 +92,15 @@ func dataSourceScimAttributeHeader() *schema.Resource {
 	}
 }
 
-func dataSourceScimAttributeHeaderRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceScimAttributeHeaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *scimattributeheader.ScimAttributeHeader
 	idpId, okidpId := d.Get("idp_id").(string)
 	idpName, okIdpName := d.Get("idp_name").(string)
 	if !okIdpName && !okidpId || idpId == "" && idpName == "" {
 		log.Printf("[INFO] idp name or id is required\n")
-		return fmt.Errorf("idp name or id is required")
+		return diag.Errorf("idp name or id is required")
 	}
 	var idpResp *idpcontroller.IdpController
 	// getting Idp controller by id or name
@@ -107,14 +108,14 @@ func dataSourceScimAttributeHeaderRead(d *schema.ResourceData, m interface{}) er
 		resp, _, err := zClient.idpcontroller.Get(idpId)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by id: %s\n", idpId)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	} else {
 		resp, _, err := zClient.idpcontroller.GetByName(idpName)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by name: %s\n", idpName)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	}
@@ -123,7 +124,7 @@ func dataSourceScimAttributeHeaderRead(d *schema.ResourceData, m interface{}) er
 	if ok && id != "" {
 		res, _, err := zClient.scimattributeheader.Get(idpResp.ID, id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -131,7 +132,7 @@ func dataSourceScimAttributeHeaderRead(d *schema.ResourceData, m interface{}) er
 	if id == "" && ok && name != "" {
 		res, _, err := zClient.scimattributeheader.GetByName(name, idpResp.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -156,7 +157,7 @@ func dataSourceScimAttributeHeaderRead(d *schema.ResourceData, m interface{}) er
 		_ = d.Set("values", values)
 
 	} else {
-		return fmt.Errorf("no scim attribute name '%s' & idp name '%s' OR id '%s' was found", name, idpName, id)
+		return diag.Errorf("no scim attribute name '%s' & idp name '%s' OR id '%s' was found", name, idpName, id)
 	}
 	return nil
 }
@@ -1,10 +1,11 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimgroup"
@@ -12,7 +13,7 @@ import (
 
 func dataSourceScimGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceScimGroupRead,
+		ReadContext: dataSourceScimGroupRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeInt,
@@ -46,14 +47,14 @@ func dataSourceScimGroup() *schema.Resource {
 	}
 }
 
-func dataSourceScimGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceScimGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	var resp *scimgroup.ScimGroup
 	idpId, okidpId := d.Get("idp_id").(string)
 	idpName, okIdpName := d.Get("idp_name").(string)
 	if !okIdpName && !okidpId || idpId == "" && idpName == "" {
 		log.Printf("[INFO] idp name or id is required\n")
-		return fmt.Errorf("idp name or id is required")
+		return diag.Errorf("idp name or id is required")
 	}
 	var idpResp *idpcontroller.IdpController
 	// getting Idp controller by id or name
@@ -61,14 +62,14 @@ func dataSourceScimGroupRead(d *schema.ResourceData, m interface{}) error {
 		resp, _, err := zClient.idpcontroller.Get(idpId)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by id: %s\n", idpId)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	} else {
 		resp, _, err := zClient.idpcontroller.GetByName(idpName)
 		if err != nil || resp == nil {
 			log.Printf("[INFO] couldn't find idp by name: %s\n", idpName)
-			return err
+			return diag.FromErr(err)
 		}
 		idpResp = resp
 	}
@@ -77,7 +78,7 @@ func dataSourceScimGroupRead(d *schema.ResourceData, m interface{}) error {
 	if ok && id != "" {
 		res, _, err := zClient.scimgroup.Get(idpResp.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -85,7 +86,7 @@ func dataSourceScimGroupRead(d *schema.ResourceData, m interface{}) error {
 	if id == "" && ok && name != "" {
 		res, _, err := zClient.scimgroup.GetByName(name, idpResp.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -98,7 +99,7 @@ func dataSourceScimGroupRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("modified_time", resp.ModifiedTime)
 		_ = d.Set("name", resp.Name)
 	} else {
-		return fmt.Errorf("no scim name '%s' & idp name '%s' OR id '%s' was found", name, idpName, id)
+		return diag.Errorf("no scim name '%s' & idp name '%s' OR id '%s' was found", name, idpName, id)
 	}
 	return nil
 }
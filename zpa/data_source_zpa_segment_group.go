@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
 )
 
 func dataSourceSegmentGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceSegmentGroupRead,
+		ReadContext: dataSourceSegmentGroupRead,
 		Schema: map[string]*schema.Schema{
 			"applications": {
 				Type:     schema.TypeList,
@@ -203,8 +204,8 @@ func dataSourceSegmentGroup() *schema.Resource {
 	}
 }
 
-func dataSourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceSegmentGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *segmentgroup.SegmentGroup
 	id, ok := d.Get("id").(string)
@@ -212,7 +213,7 @@ func dataSourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for server group %s\n", id)
 		res, _, err := zClient.segmentgroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -221,7 +222,7 @@ func dataSourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for server group name %s\n", name)
 		res, _, err := zClient.segmentgroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -238,10 +239,10 @@ func dataSourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("tcp_keep_alive_enabled", resp.TcpKeepAliveEnabled)
 
 		if err := d.Set("applications", flattenSegmentGroupApplications(resp)); err != nil {
-			return fmt.Errorf("failed to read applications %s", err)
+			return diag.Errorf("failed to read applications %s", err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any segment group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any segment group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
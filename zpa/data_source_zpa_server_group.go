@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
 )
 
 func dataSourceServerGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceServerGroupRead,
+		ReadContext: dataSourceServerGroupRead,
 		Schema: map[string]*schema.Schema{
 			"applications": {
 				Type:     schema.TypeList,
@@ -293,8 +294,8 @@ func dataSourceServerGroup() *schema.Resource {
 	}
 }
 
-func dataSourceServerGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *servergroup.ServerGroup
 	id, ok := d.Get("id").(string)
@@ -302,7 +303,7 @@ func dataSourceServerGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for server group  %s\n", id)
 		res, _, err := zClient.servergroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -311,7 +312,7 @@ func dataSourceServerGroupRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for server group name %s\n", name)
 		res, _, err := zClient.servergroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -328,18 +329,18 @@ func dataSourceServerGroupRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("name", resp.Name)
 
 		if err := d.Set("applications", flattenServerGroupApplications(resp.Applications)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("app_connector_groups", flattenAppConnectorGroups(resp.AppConnectorGroups)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("servers", flattenServers(resp.Servers)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return fmt.Errorf("couldn't find any server group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any server group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -16,10 +16,10 @@ func TestAccDataSourceServerGroup_Basic(t *testing.T) {
 	appConnectorGroupTypeAndName, _, appConnectorGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 	appConnectorGroupHCL := testAccCheckAppConnectorGroupConfigure(appConnectorGroupTypeAndName, appConnectorGroupGeneratedName, variable.AppConnectorDescription, variable.AppConnectorEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckServerGroupDestroy,
+		CheckDestroy: testAccCheckServerGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckServerGroupConfigure(resourceTypeAndName, generatedName, generatedName, generatedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, variable.ServerGroupEnabled, variable.ServerGroupDynamicDiscovery),
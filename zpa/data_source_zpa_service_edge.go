@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgecontroller"
 )
 
 func dataSourceServiceEdgeController() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceServiceEdgeControllerRead,
+		ReadContext: dataSourceServiceEdgeControllerRead,
 		Schema: map[string]*schema.Schema{
 			"application_start_time": {
 				Type:     schema.TypeString,
@@ -169,8 +170,8 @@ func dataSourceServiceEdgeController() *schema.Resource {
 	}
 }
 
-func dataSourceServiceEdgeControllerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceServiceEdgeControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *serviceedgecontroller.ServiceEdgeController
 	id, ok := d.Get("id").(string)
@@ -178,7 +179,7 @@ func dataSourceServiceEdgeControllerRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for service edge controller %s\n", id)
 		res, _, err := zClient.serviceedgecontroller.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -187,7 +188,7 @@ func dataSourceServiceEdgeControllerRead(d *schema.ResourceData, m interface{})
 		log.Printf("[INFO] Getting data for service edge controller name %s\n", name)
 		res, _, err := zClient.serviceedgecontroller.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -232,7 +233,7 @@ func dataSourceServiceEdgeControllerRead(d *schema.ResourceData, m interface{})
 		_ = d.Set("upgrade_status", resp.UpgradeStatus)
 
 	} else {
-		return fmt.Errorf("couldn't find any app connector with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any app connector with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
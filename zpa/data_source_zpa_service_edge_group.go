@@ -1,17 +1,18 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgegroup"
 )
 
 func dataSourceServiceEdgeGroup() *schema.Resource {
 	return &schema.Resource{
-		Read:     dataSourceServiceEdgeGroupRead,
-		Importer: &schema.ResourceImporter{},
+		ReadContext: dataSourceServiceEdgeGroupRead,
+		Importer:    &schema.ResourceImporter{},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -317,8 +318,8 @@ func dataSourceServiceEdgeGroup() *schema.Resource {
 	}
 }
 
-func dataSourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceServiceEdgeGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *serviceedgegroup.ServiceEdgeGroup
 	id, ok := d.Get("id").(string)
@@ -326,7 +327,7 @@ func dataSourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error
 		log.Printf("[INFO] Getting data for service edge group %s\n", id)
 		res, _, err := zClient.serviceedgegroup.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -335,7 +336,7 @@ func dataSourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error
 		log.Printf("[INFO] Getting data for service edge group name %s\n", name)
 		res, _, err := zClient.serviceedgegroup.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -364,7 +365,7 @@ func dataSourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error
 		_ = d.Set("service_edges", flattenServiceEdges(resp))
 
 	} else {
-		return fmt.Errorf("couldn't find any service edge group with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any service edge group with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
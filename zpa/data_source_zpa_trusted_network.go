@@ -1,16 +1,17 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/trustednetwork"
 )
 
 func dataSourceTrustedNetwork() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTrustedNetworkRead,
+		ReadContext: dataSourceTrustedNetworkRead,
 		Schema: map[string]*schema.Schema{
 			"creation_time": {
 				Type:     schema.TypeString,
@@ -48,8 +49,8 @@ func dataSourceTrustedNetwork() *schema.Resource {
 	}
 }
 
-func dataSourceTrustedNetworkRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func dataSourceTrustedNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	var resp *trustednetwork.TrustedNetwork
 	id, ok := d.Get("id").(string)
@@ -57,7 +58,7 @@ func dataSourceTrustedNetworkRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for trusted network %s\n", id)
 		res, _, err := zClient.trustednetwork.Get(id)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 
@@ -67,7 +68,7 @@ func dataSourceTrustedNetworkRead(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[INFO] Getting data for trusted network name %s\n", name)
 		res, _, err := zClient.trustednetwork.GetByName(name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		resp = res
 	}
@@ -82,7 +83,7 @@ func dataSourceTrustedNetworkRead(d *schema.ResourceData, m interface{}) error {
 		_ = d.Set("zscaler_cloud", resp.ZscalerCloud)
 
 	} else {
-		return fmt.Errorf("couldn't find any trusted network with name '%s' or id '%s'", name, id)
+		return diag.Errorf("couldn't find any trusted network with name '%s' or id '%s'", name, id)
 	}
 
 	return nil
@@ -0,0 +1,132 @@
+package zpa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/zscaler/zscaler-sdk-go/zpa"
+)
+
+// errorClass is the kind of failure of an API call, it decides whether the call is retried, the object is
+// removed from the state or the error is reported as is.
+type errorClass int
+
+const (
+	errorClassOther errorClass = iota
+	// the object doesn't exist, the API answers 404, or 400 with the resource.not.found error ID
+	errorClassNotFound
+	// the object conflicts with another one, the API answers 409, or 400 with the resource.already.exist error ID
+	errorClassConflict
+	// the API rate limits the tenant, 429
+	errorClassRateLimited
+	// the credentials are invalid or lack permissions, 401 and 403
+	errorClassAuth
+	// the API or the network failed and the same call may succeed later, 5xx and connection errors
+	errorClassTransient
+)
+
+var errorClassNames = map[errorClass]string{
+	errorClassOther:       "other",
+	errorClassNotFound:    "not found",
+	errorClassConflict:    "conflict",
+	errorClassRateLimited: "rate limited",
+	errorClassAuth:        "auth",
+	errorClassTransient:   "transient",
+}
+
+func (c errorClass) String() string {
+	return errorClassNames[c]
+}
+
+// classifyError returns the class of an error returned by the SDK services or by the HTTP transport.
+func classifyError(err error) errorClass {
+	if err == nil {
+		return errorClassOther
+	}
+	var respErr *client.ErrorResponse
+	if errors.As(err, &respErr) {
+		if respErr.Response == nil {
+			return errorClassOther
+		}
+		status := respErr.Response.StatusCode
+		switch {
+		case status == http.StatusNotFound || status == http.StatusBadRequest && apiErrorID(respErr) == "resource.not.found":
+			return errorClassNotFound
+		case status == http.StatusConflict || status == http.StatusBadRequest && apiErrorID(respErr) == "resource.already.exist":
+			return errorClassConflict
+		case status == http.StatusTooManyRequests:
+			return errorClassRateLimited
+		case status == http.StatusUnauthorized || status == http.StatusForbidden:
+			return errorClassAuth
+		case status >= http.StatusInternalServerError:
+			return errorClassTransient
+		}
+		return errorClassOther
+	}
+	// the SDK signs in before the first call and reports a sign in the API refused with a plain error
+	if match := signInStatus.FindStringSubmatch(err.Error()); match != nil {
+		if status, _ := strconv.Atoi(match[1]); status < http.StatusInternalServerError {
+			return errorClassAuth
+		}
+		return errorClassTransient
+	}
+	if strings.Contains(err.Error(), "no client credentials were provided") {
+		return errorClassAuth
+	}
+	// the call was cancelled or timed out by its caller, and a certificate that doesn't validate won't validate
+	// on the next attempt either, every other error of the transport is a connection error
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isCertificateError(err) {
+		return errorClassOther
+	}
+	return errorClassTransient
+}
+
+var signInStatus = regexp.MustCompile(`Failed to signin the user .*got http status:(\d+)`)
+
+// describeAPIError adds to err what its class tells the user: the credentials to check when the API refuses them,
+// the object that already exists on a conflict and the retry settings when the tenant stays rate limited. kind and
+// name describe the object the call creates or updates, such as "segment group" and the name of the group.
+func describeAPIError(err error, kind, name string) error {
+	switch classifyError(err) {
+	case errorClassAuth:
+		return fmt.Errorf("ZPA refused the credentials of the provider or they lack the permission to change the %s %q, check zpa_client_id, zpa_client_secret and zpa_customer_id, or the profile or the environment variables they come from: %w", kind, name, err)
+	case errorClassConflict:
+		return fmt.Errorf("the %s %q conflicts with an object that already exists in ZPA, such as a %s with the same name, import that object or choose another name: %w", kind, name, kind, err)
+	case errorClassRateLimited:
+		return fmt.Errorf("ZPA kept rate limiting the changes of the %s %q after the retries, raise max_retries or lower the parallelism of Terraform: %w", kind, name, err)
+	}
+	return err
+}
+
+// apiErrorID returns the ID of the error in the body of an API error response, such as resource.not.found.
+func apiErrorID(respErr *client.ErrorResponse) string {
+	var body struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(respErr.Message), &body); err != nil {
+		return ""
+	}
+	return body.ID
+}
+
+func isNotFoundError(err error) bool {
+	return classifyError(err) == errorClassNotFound
+}
+
+// notFoundOnUpdate reports an object deleted outside of Terraform that an Update was about to change. The object
+// is removed from the state, so that the next plan creates it again.
+func notFoundOnUpdate(d *schema.ResourceData, kind string) diag.Diagnostics {
+	id := d.Id()
+	log.Printf("[WARN] Removing %s %s from state because it no longer exists in ZPA\n", kind, id)
+	d.SetId("")
+	return diag.Errorf("the %s %s was deleted outside of Terraform, it was removed from the state and the next apply creates it again", kind, id)
+}
@@ -0,0 +1,140 @@
+package zpa
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	client "github.com/zscaler/zscaler-sdk-go/zpa"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+)
+
+func testAPIError(statusCode int, id string) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://config.private.zscaler.com/mgmtconfig/v1/admin/customers/123/application/1", nil)
+	message, _ := json.Marshal(map[string]string{"id": id})
+	return &client.ErrorResponse{
+		Response: &http.Response{StatusCode: statusCode, Status: http.StatusText(statusCode), Request: req},
+		Message:  string(message),
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	connectionError := &url.Error{Op: "Get", URL: "https://config.private.zscaler.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	for _, test := range []struct {
+		name string
+		err  error
+		want errorClass
+	}{
+		{"nil", nil, errorClassOther},
+		{"404", testAPIError(http.StatusNotFound, ""), errorClassNotFound},
+		{"400 resource.not.found", testAPIError(http.StatusBadRequest, "resource.not.found"), errorClassNotFound},
+		{"wrapped not found", fmt.Errorf("failed to get the application segment: %w", testAPIError(http.StatusNotFound, "")), errorClassNotFound},
+		{"409", testAPIError(http.StatusConflict, ""), errorClassConflict},
+		{"400 resource.already.exist", testAPIError(http.StatusBadRequest, "resource.already.exist"), errorClassConflict},
+		{"429", testAPIError(http.StatusTooManyRequests, ""), errorClassRateLimited},
+		{"401", testAPIError(http.StatusUnauthorized, ""), errorClassAuth},
+		{"403", testAPIError(http.StatusForbidden, ""), errorClassAuth},
+		{"500", testAPIError(http.StatusInternalServerError, ""), errorClassTransient},
+		{"503", testAPIError(http.StatusServiceUnavailable, ""), errorClassTransient},
+		{"connection error", connectionError, errorClassTransient},
+		{"unexpected EOF", io.ErrUnexpectedEOF, errorClassTransient},
+		{"400 other", testAPIError(http.StatusBadRequest, "invalid.rule.order"), errorClassOther},
+		{"error response without response", &client.ErrorResponse{}, errorClassOther},
+		{"cancelled", &url.Error{Op: "Get", URL: "https://config.private.zscaler.com", Err: context.Canceled}, errorClassOther},
+		{"timed out", context.DeadlineExceeded, errorClassOther},
+		{"certificate", &url.Error{Op: "Get", URL: "https://config.private.zscaler.com", Err: x509.UnknownAuthorityError{}}, errorClassOther},
+		{"sign in refused", errors.New("[ERROR] Failed to signin the user ZPA_CLIENT_ID=id, got http status:401, response body:{}"), errorClassAuth},
+		{"sign in failed", errors.New("[ERROR] Failed to signin the user ZPA_CLIENT_ID=id, got http status:503, response body:{}"), errorClassTransient},
+		{"no credentials", errors.New("no client credentials were provided"), errorClassAuth},
+	} {
+		if got := classifyError(test.err); got != test.want {
+			t.Errorf("%s: got class %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDescribeAPIError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"auth", testAPIError(http.StatusForbidden, ""), `lack the permission to change the segment group "finance", check zpa_client_id`},
+		{"conflict", testAPIError(http.StatusBadRequest, "resource.already.exist"), `the segment group "finance" conflicts with an object that already exists in ZPA`},
+		{"rate limited", testAPIError(http.StatusTooManyRequests, ""), `rate limiting the changes of the segment group "finance" after the retries`},
+	} {
+		err := describeAPIError(test.err, "segment group", "finance")
+		if !strings.Contains(err.Error(), test.want) || !errors.Is(err, test.err) {
+			t.Errorf("%s: got %v, want it to contain %q and wrap the error", test.name, err, test.want)
+		}
+	}
+	other := testAPIError(http.StatusBadRequest, "invalid.rule.order")
+	if err := describeAPIError(other, "segment group", "finance"); err != other {
+		t.Errorf("got %v, want the other errors unchanged", err)
+	}
+}
+
+func TestRetryTransportRetriesTransientErrors(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{RetryOnStatus: defaultRetryOnStatus})
+	req, _ := http.NewRequest(http.MethodGet, "https://config.private.zscaler.com", nil)
+	if !transport.shouldRetry(req, nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}) {
+		t.Error("expected a connection error to be retried")
+	}
+	if transport.shouldRetry(req, nil, x509.UnknownAuthorityError{}) {
+		t.Error("expected a certificate error not to be retried")
+	}
+}
+
+// failingApplicationSegments fails every read of an application segment with err.
+type failingApplicationSegments struct {
+	applicationSegmentService
+	err error
+}
+
+func (f *failingApplicationSegments) Get(id string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	return nil, nil, f.err
+}
+
+func TestResourceReadNotFoundClearsState(t *testing.T) {
+	zClient := newFakeClient()
+	d := schema.TestResourceDataRaw(t, resourceApplicationSegment().Schema, map[string]interface{}{})
+	d.SetId("404")
+	if diags := resourceApplicationSegmentRead(context.Background(), d, zClient); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the application segment to be removed from the state, got ID %q", d.Id())
+	}
+
+	// an error that isn't an API error response is reported, it used to panic the provider
+	zClient.applicationsegment = &failingApplicationSegments{applicationSegmentService: zClient.applicationsegment, err: io.ErrUnexpectedEOF}
+	d.SetId("1")
+	if diags := resourceApplicationSegmentRead(context.Background(), d, zClient); !diags.HasError() {
+		t.Error("expected the error of the read to be reported")
+	}
+	if d.Id() != "1" {
+		t.Errorf("expected the application segment to stay in the state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceUpdateNotFoundIsDrift(t *testing.T) {
+	zClient := newFakeClient()
+	d := schema.TestResourceDataRaw(t, resourceSegmentGroup().Schema, map[string]interface{}{"name": "segment group"})
+	d.SetId("404")
+	diags := resourceSegmentGroupUpdate(context.Background(), d, zClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "the segment group 404 was deleted outside of Terraform") {
+		t.Errorf("expected the update to report the segment group as deleted outside of Terraform, got %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the segment group to be removed from the state, got ID %q", d.Id())
+	}
+}
@@ -0,0 +1,909 @@
+package zpa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	client "github.com/zscaler/zscaler-sdk-go/zpa"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
+)
+
+// fakeBackend is an in-memory stand-in for the ZPA API, the fake services of fake_services_test.go share it.
+// It mimics the semantics the provider relies on: numeric IDs assigned by the server, unique names per object
+// type, not found errors shaped like the API ones, rule order shifting in policy sets and the applications
+// lists of the segment groups and of the server groups.
+type fakeBackend struct {
+	sync.Mutex
+	lastID int
+
+	// policy sets by ID, policySetTypes maps every policy type to the ID of its set
+	policySets     map[string]*policysetcontroller.PolicySet
+	policySetTypes map[string]string
+	// ruleExtensions holds the fields of the rules written through the extended policy rule service by rule ID
+	ruleExtensions map[string]policyRuleExtensions
+
+	segmentGroups *fakeStore[segmentgroup.SegmentGroup]
+	serverGroups  *fakeStore[servergroup.ServerGroup]
+	applications  *fakeStore[fakeApplication]
+}
+
+func newFakeBackend() *fakeBackend {
+	b := &fakeBackend{
+		policySets:     map[string]*policysetcontroller.PolicySet{},
+		policySetTypes: map[string]string{},
+		ruleExtensions: map[string]policyRuleExtensions{},
+	}
+	b.segmentGroups = newFakeStore[segmentgroup.SegmentGroup](b, "segmentGroup")
+	b.serverGroups = newFakeStore[servergroup.ServerGroup](b, "serverGroup")
+	b.applications = newFakeStore[fakeApplication](b, "application")
+	// the API exposes some policy sets under several policy types
+	for _, policyTypes := range [][]string{
+		{"ACCESS_POLICY", "GLOBAL_POLICY"},
+		{"TIMEOUT_POLICY", "REAUTH_POLICY"},
+		{"CLIENT_FORWARDING_POLICY", "BYPASS_POLICY"},
+		{"INSPECTION_POLICY"},
+		{"ISOLATION_POLICY"},
+		{"SIEM_POLICY"},
+		{"CREDENTIAL_POLICY"},
+		{"CAPABILITIES_POLICY"},
+		{"REDIRECTION_POLICY"},
+		{"CLIENTLESS_SESSION_PROTECTION_POLICY"},
+	} {
+		id := b.nextID()
+		b.policySets[id] = &policysetcontroller.PolicySet{ID: id, Name: policyTypes[0], Enabled: true, Sorted: true, PolicyType: policyTypes[0]}
+		for _, policyType := range policyTypes {
+			b.policySetTypes[policyType] = id
+		}
+	}
+	return b
+}
+
+// nextID must be called with the lock held.
+func (b *fakeBackend) nextID() string {
+	b.lastID++
+	return strconv.Itoa(216196257331280000 + b.lastID)
+}
+
+// fakeAPIError builds the error the SDK returns for a non 2XX response.
+func fakeAPIError(statusCode int, method, path, id, reason string) error {
+	req, _ := http.NewRequest(method, "https://config.private.zscaler.com/mgmtconfig/v1/admin/customers/fake/"+path, nil)
+	message, _ := json.Marshal(map[string]string{"id": id, "reason": reason})
+	return &client.ErrorResponse{
+		Response: &http.Response{StatusCode: statusCode, Status: http.StatusText(statusCode), Request: req},
+		Message:  string(message),
+	}
+}
+
+func fakeNotFound(method, path string) error {
+	return fakeAPIError(http.StatusNotFound, method, path, "resource.not.found", "Resource not found")
+}
+
+func fakeOK() *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Status: http.StatusText(http.StatusOK)}
+}
+
+func fakeNoContent() *http.Response {
+	return &http.Response{StatusCode: http.StatusNoContent, Status: http.StatusText(http.StatusNoContent)}
+}
+
+// fakeClone deep copies an object the way a round trip through the API does, so that callers never share
+// memory with the backend.
+func fakeClone[T any](v T) T {
+	var out T
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// fakeMerge overlays the fields set in the JSON document of update on current.
+func fakeMerge[T any](current, update T) T {
+	var merged T
+	data, err := json.Marshal(fakeMergeDocuments(fakeDocument(current), fakeDocument(update)))
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		panic(err)
+	}
+	return merged
+}
+
+func fakeDocument(v interface{}) map[string]interface{} {
+	document := map[string]interface{}{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		panic(err)
+	}
+	return document
+}
+
+func fakeMergeDocuments(current, update map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range update {
+		merged[key] = value
+	}
+	return merged
+}
+
+func fakeField(v interface{}, name string) reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(v)).FieldByName(name)
+}
+
+// fakeFieldString reads the ID or Name field of an SDK object, some IDs are int64.
+func fakeFieldString(v interface{}, name string) string {
+	field := fakeField(v, name)
+	if !field.IsValid() {
+		return ""
+	}
+	if field.Kind() == reflect.String {
+		return field.String()
+	}
+	if field.Kind() == reflect.Int64 {
+		if field.Int() == 0 {
+			return ""
+		}
+		return strconv.FormatInt(field.Int(), 10)
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+func fakeSetFieldString(v interface{}, name, value string) {
+	field := fakeField(v, name)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int64:
+		i, _ := strconv.ParseInt(value, 10, 64)
+		field.SetInt(i)
+	}
+}
+
+// fakeStore holds the objects of one API endpoint in creation order.
+type fakeStore[T any] struct {
+	backend *fakeBackend
+	path    string
+	items   []*T
+}
+
+func newFakeStore[T any](backend *fakeBackend, path string) *fakeStore[T] {
+	return &fakeStore[T]{backend: backend, path: path}
+}
+
+// seed adds objects as if they were configured out of band, objects without an ID get one.
+func (s *fakeStore[T]) seed(items ...T) *fakeStore[T] {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	for _, item := range items {
+		item := fakeClone(item)
+		if fakeFieldString(&item, "ID") == "" {
+			fakeSetFieldString(&item, "ID", s.backend.nextID())
+		}
+		s.items = append(s.items, &item)
+	}
+	return s
+}
+
+func (s *fakeStore[T]) index(id string) int {
+	for i, item := range s.items {
+		if id != "" && fakeFieldString(item, "ID") == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *fakeStore[T]) checkName(method, id string, v *T) error {
+	name := fakeFieldString(v, "Name")
+	if name == "" {
+		return nil
+	}
+	for _, item := range s.items {
+		if fakeFieldString(item, "ID") != id && strings.EqualFold(fakeFieldString(item, "Name"), name) {
+			return fakeAPIError(http.StatusBadRequest, method, s.path, "resource.already.exist", fmt.Sprintf("%s with name %s already exists", s.path, name))
+		}
+	}
+	return nil
+}
+
+// The unlocked variants are used by operations that have to update several stores atomically.
+
+func (s *fakeStore[T]) createLocked(v T) (*T, error) {
+	item := fakeClone(v)
+	if err := s.checkName(http.MethodPost, "", &item); err != nil {
+		return nil, err
+	}
+	fakeSetFieldString(&item, "ID", s.backend.nextID())
+	s.items = append(s.items, &item)
+	created := fakeClone(item)
+	return &created, nil
+}
+
+func (s *fakeStore[T]) getLocked(id string) (*T, error) {
+	i := s.index(id)
+	if i < 0 {
+		return nil, fakeNotFound(http.MethodGet, s.path+"/"+id)
+	}
+	item := fakeClone(*s.items[i])
+	return &item, nil
+}
+
+// updateLocked keeps the current value of the fields the payload omits, like the API does.
+func (s *fakeStore[T]) updateLocked(id string, v T) error {
+	i := s.index(id)
+	if i < 0 {
+		return fakeNotFound(http.MethodPut, s.path+"/"+id)
+	}
+	item := fakeMerge(*s.items[i], v)
+	if err := s.checkName(http.MethodPut, id, &item); err != nil {
+		return err
+	}
+	fakeSetFieldString(&item, "ID", id)
+	s.items[i] = &item
+	return nil
+}
+
+func (s *fakeStore[T]) deleteLocked(id string) error {
+	i := s.index(id)
+	if i < 0 {
+		return fakeNotFound(http.MethodDelete, s.path+"/"+id)
+	}
+	s.items = append(s.items[:i], s.items[i+1:]...)
+	return nil
+}
+
+func (s *fakeStore[T]) create(v T) (*T, error) {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	return s.createLocked(v)
+}
+
+func (s *fakeStore[T]) get(id string) (*T, error) {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	return s.getLocked(id)
+}
+
+// getByName matches names case insensitively and returns a plain error when nothing matches, like the SDK.
+func (s *fakeStore[T]) getByName(name string) (*T, error) {
+	return s.find(func(item *T) bool { return strings.EqualFold(fakeFieldString(item, "Name"), name) }, name)
+}
+
+func (s *fakeStore[T]) find(match func(*T) bool, name string) (*T, error) {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	for _, item := range s.items {
+		if match(item) {
+			found := fakeClone(*item)
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s named '%s' was found", s.path, name)
+}
+
+func (s *fakeStore[T]) update(id string, v T) error {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	return s.updateLocked(id, v)
+}
+
+func (s *fakeStore[T]) delete(id string) error {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	return s.deleteLocked(id)
+}
+
+func (s *fakeStore[T]) list() []T {
+	s.backend.Lock()
+	defer s.backend.Unlock()
+	list := make([]T, 0, len(s.items))
+	for _, item := range s.items {
+		list = append(list, fakeClone(*item))
+	}
+	return list
+}
+
+// Segment group membership: the API maintains the applications list of a segment group from the
+// segmentGroupId of the application segments, and refuses to delete a group that still has applications.
+
+func (b *fakeBackend) checkSegmentGroupLocked(method, path, groupID string) error {
+	if groupID == "" {
+		return nil
+	}
+	if b.segmentGroups.index(groupID) < 0 {
+		return fakeAPIError(http.StatusBadRequest, method, path, "resource.not.found", fmt.Sprintf("segment group %s not found", groupID))
+	}
+	return nil
+}
+
+func (b *fakeBackend) moveApplicationLocked(appID, appName, fromGroupID, toGroupID string) {
+	if fromGroupID != "" {
+		if i := b.segmentGroups.index(fromGroupID); i >= 0 {
+			group := b.segmentGroups.items[i]
+			apps := group.Applications[:0]
+			for _, app := range group.Applications {
+				if app.ID != appID {
+					apps = append(apps, app)
+				}
+			}
+			group.Applications = apps
+		}
+	}
+	if toGroupID != "" {
+		if i := b.segmentGroups.index(toGroupID); i >= 0 {
+			group := b.segmentGroups.items[i]
+			for _, app := range group.Applications {
+				if app.ID == appID {
+					return
+				}
+			}
+			group.Applications = append(group.Applications, segmentgroup.Application{ID: appID, Name: appName})
+		}
+	}
+}
+
+// Server group applications: the API lists in the applications of a server group the application segments
+// whose serverGroups contain it.
+
+func (b *fakeBackend) linkServerGroupsLocked(appID, appName string, fromGroupIDs, toGroupIDs []string) {
+	for _, groupID := range fromGroupIDs {
+		if i := b.serverGroups.index(groupID); i >= 0 && !contains(toGroupIDs, groupID) {
+			group := b.serverGroups.items[i]
+			apps := []servergroup.Applications{}
+			for _, app := range group.Applications {
+				if app.ID != appID {
+					apps = append(apps, app)
+				}
+			}
+			group.Applications = apps
+		}
+	}
+	for _, groupID := range toGroupIDs {
+		if i := b.serverGroups.index(groupID); i >= 0 {
+			group := b.serverGroups.items[i]
+			linked := false
+			for _, app := range group.Applications {
+				linked = linked || app.ID == appID
+			}
+			if !linked {
+				group.Applications = append(group.Applications, servergroup.Applications{ID: appID, Name: appName})
+			}
+		}
+	}
+}
+
+// fakeApplication is the single object behind the application segment, PRA, inspection and browser access
+// endpoints. It is kept as a JSON document so that every endpoint decodes the fields it knows about.
+type fakeApplication struct {
+	ID             string
+	Name           string
+	SegmentGroupID string
+	ServerGroupIDs []string
+	Document       map[string]interface{}
+}
+
+func fakeApplicationServerGroupIDs(document map[string]interface{}) []string {
+	var ids []string
+	groups, _ := document["serverGroups"].([]interface{})
+	for _, group := range groups {
+		group, _ := group.(map[string]interface{})
+		if id, _ := group["id"].(string); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// fakeNormalizePorts fills both representations of the port ranges of an application, the API accepts
+// either of them and returns both.
+func fakeNormalizePorts(document map[string]interface{}) {
+	for _, protocol := range []string{"tcp", "udp"} {
+		if ports, ok := document[protocol+"PortRange"].([]interface{}); ok && len(ports) > 0 {
+			ranges := []interface{}{}
+			for _, port := range ports {
+				port, _ := port.(map[string]interface{})
+				ranges = append(ranges, port["from"], port["to"])
+			}
+			document[protocol+"PortRanges"] = ranges
+			continue
+		}
+		ranges, ok := document[protocol+"PortRanges"].([]interface{})
+		if !ok {
+			continue
+		}
+		ports := []interface{}{}
+		for i := 0; i+1 < len(ranges); i += 2 {
+			ports = append(ports, map[string]interface{}{"from": ranges[i], "to": ranges[i+1]})
+		}
+		document[protocol+"PortRange"] = ports
+		document[protocol+"PortRanges"] = ranges
+	}
+}
+
+func fakeEncodeApplication(v interface{}) fakeApplication {
+	document := fakeDocument(v)
+	fakeNormalizePorts(document)
+	app := fakeApplication{Document: document}
+	app.Name, _ = document["name"].(string)
+	app.SegmentGroupID, _ = document["segmentGroupId"].(string)
+	app.ServerGroupIDs = fakeApplicationServerGroupIDs(document)
+	return app
+}
+
+func fakeDecodeApplication[T any](app *fakeApplication) *T {
+	document := map[string]interface{}{}
+	for key, value := range app.Document {
+		document[key] = value
+	}
+	document["id"] = app.ID
+	v := new(T)
+	data, err := json.Marshal(document)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// fakeApplicationStore is the view of one endpoint on the applications of the backend, it maintains the
+// segment group membership.
+type fakeApplicationStore[T any] struct {
+	backend *fakeBackend
+}
+
+func (s fakeApplicationStore[T]) get(id string) (*T, error) {
+	app, err := s.backend.applications.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return fakeDecodeApplication[T](app), nil
+}
+
+func (s fakeApplicationStore[T]) getByName(name string) (*T, error) {
+	app, err := s.backend.applications.getByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return fakeDecodeApplication[T](app), nil
+}
+
+func (s fakeApplicationStore[T]) list() []T {
+	list := []T{}
+	for _, app := range s.backend.applications.list() {
+		list = append(list, *fakeDecodeApplication[T](&app))
+	}
+	return list
+}
+
+func (s fakeApplicationStore[T]) create(v T) (*T, error) {
+	b := s.backend
+	b.Lock()
+	defer b.Unlock()
+	app := fakeEncodeApplication(v)
+	if err := b.checkSegmentGroupLocked(http.MethodPost, b.applications.path, app.SegmentGroupID); err != nil {
+		return nil, err
+	}
+	created, err := b.applications.createLocked(app)
+	if err != nil {
+		return nil, err
+	}
+	b.moveApplicationLocked(created.ID, created.Name, "", created.SegmentGroupID)
+	b.linkServerGroupsLocked(created.ID, created.Name, nil, created.ServerGroupIDs)
+	return fakeDecodeApplication[T](created), nil
+}
+
+func (s fakeApplicationStore[T]) update(id string, v T) error {
+	b := s.backend
+	b.Lock()
+	defer b.Unlock()
+	current, err := b.applications.getLocked(id)
+	if err != nil {
+		return err
+	}
+	app := fakeEncodeApplication(v)
+	app.Document = fakeMergeDocuments(current.Document, app.Document)
+	app.Name, _ = app.Document["name"].(string)
+	app.SegmentGroupID, _ = app.Document["segmentGroupId"].(string)
+	app.ServerGroupIDs = fakeApplicationServerGroupIDs(app.Document)
+	if err := b.checkSegmentGroupLocked(http.MethodPut, b.applications.path+"/"+id, app.SegmentGroupID); err != nil {
+		return err
+	}
+	if err := b.applications.updateLocked(id, app); err != nil {
+		return err
+	}
+	b.moveApplicationLocked(id, app.Name, current.SegmentGroupID, app.SegmentGroupID)
+	b.linkServerGroupsLocked(id, app.Name, current.ServerGroupIDs, app.ServerGroupIDs)
+	return nil
+}
+
+func (s fakeApplicationStore[T]) delete(id string) error {
+	b := s.backend
+	b.Lock()
+	defer b.Unlock()
+	current, err := b.applications.getLocked(id)
+	if err != nil {
+		return err
+	}
+	if err := b.applications.deleteLocked(id); err != nil {
+		return err
+	}
+	b.moveApplicationLocked(id, "", current.SegmentGroupID, "")
+	b.linkServerGroupsLocked(id, "", current.ServerGroupIDs, nil)
+	return nil
+}
+
+func (b *fakeBackend) deleteSegmentGroup(id string) error {
+	b.Lock()
+	defer b.Unlock()
+	if i := b.segmentGroups.index(id); i >= 0 && len(b.segmentGroups.items[i].Applications) > 0 {
+		return fakeAPIError(http.StatusBadRequest, http.MethodDelete, "segmentGroup/"+id, "segment.group.has.applications", "Segment group has applications associated with it")
+	}
+	return b.segmentGroups.deleteLocked(id)
+}
+
+// Policy sets: rules are kept sorted by rule order, creating a rule appends it, deleting a rule and moving a
+// rule with reorder shift the order of the rules after it.
+
+func (b *fakeBackend) policySetLocked(method, policySetID string) (*policysetcontroller.PolicySet, error) {
+	set, ok := b.policySets[policySetID]
+	if !ok {
+		return nil, fakeNotFound(method, "policySet/"+policySetID)
+	}
+	return set, nil
+}
+
+func (b *fakeBackend) renumberLocked(set *policysetcontroller.PolicySet) {
+	for i := range set.Rules {
+		set.Rules[i].RuleOrder = strconv.Itoa(i + 1)
+	}
+}
+
+func (b *fakeBackend) ruleIndexLocked(set *policysetcontroller.PolicySet, ruleID string) int {
+	for i := range set.Rules {
+		if set.Rules[i].ID == ruleID {
+			return i
+		}
+	}
+	return -1
+}
+
+// prepareRuleLocked checks the name is unique in the policy set and assigns IDs to the conditions and
+// operands, the API does the same on every write.
+func (b *fakeBackend) prepareRuleLocked(method string, set *policysetcontroller.PolicySet, ruleID string, rule *policysetcontroller.PolicyRule) error {
+	for _, existing := range set.Rules {
+		if existing.ID != ruleID && strings.EqualFold(existing.Name, rule.Name) {
+			return fakeAPIError(http.StatusBadRequest, method, "policySet/"+set.ID+"/rule", "resource.already.exist", fmt.Sprintf("rule with name %s already exists", rule.Name))
+		}
+	}
+	for i := range rule.Conditions {
+		if rule.Conditions[i].ID == "" {
+			rule.Conditions[i].ID = b.nextID()
+		}
+		for j := range rule.Conditions[i].Operands {
+			if rule.Conditions[i].Operands[j].ID == "" {
+				rule.Conditions[i].Operands[j].ID = b.nextID()
+			}
+		}
+	}
+	rule.PolicySetID = set.ID
+	rule.PolicyType = set.PolicyType
+	return nil
+}
+
+func (b *fakeBackend) createRule(rule policysetcontroller.PolicyRule) (*policysetcontroller.PolicyRule, error) {
+	b.Lock()
+	defer b.Unlock()
+	set, err := b.policySetLocked(http.MethodPost, rule.PolicySetID)
+	if err != nil {
+		return nil, err
+	}
+	rule = fakeClone(rule)
+	if err := b.prepareRuleLocked(http.MethodPost, set, "", &rule); err != nil {
+		return nil, err
+	}
+	rule.ID = b.nextID()
+	set.Rules = append(set.Rules, rule)
+	b.renumberLocked(set)
+	created := fakeClone(set.Rules[len(set.Rules)-1])
+	return &created, nil
+}
+
+func (b *fakeBackend) getRule(policySetID, ruleID string) (*policysetcontroller.PolicyRule, error) {
+	b.Lock()
+	defer b.Unlock()
+	set, err := b.policySetLocked(http.MethodGet, policySetID)
+	if err != nil {
+		return nil, err
+	}
+	i := b.ruleIndexLocked(set, ruleID)
+	if i < 0 {
+		return nil, fakeNotFound(http.MethodGet, "policySet/"+policySetID+"/rule/"+ruleID)
+	}
+	rule := fakeClone(set.Rules[i])
+	return &rule, nil
+}
+
+// updateRule keeps the order of the rule, the rule order of the payload is ignored like the API does.
+func (b *fakeBackend) updateRule(policySetID, ruleID string, rule policysetcontroller.PolicyRule) error {
+	b.Lock()
+	defer b.Unlock()
+	set, err := b.policySetLocked(http.MethodPut, policySetID)
+	if err != nil {
+		return err
+	}
+	i := b.ruleIndexLocked(set, ruleID)
+	if i < 0 {
+		return fakeNotFound(http.MethodPut, "policySet/"+policySetID+"/rule/"+ruleID)
+	}
+	rule = fakeClone(rule)
+	if err := b.prepareRuleLocked(http.MethodPut, set, ruleID, &rule); err != nil {
+		return err
+	}
+	rule.ID = ruleID
+	rule.RuleOrder = set.Rules[i].RuleOrder
+	set.Rules[i] = rule
+	return nil
+}
+
+func (b *fakeBackend) deleteRule(policySetID, ruleID string) error {
+	b.Lock()
+	defer b.Unlock()
+	set, err := b.policySetLocked(http.MethodDelete, policySetID)
+	if err != nil {
+		return err
+	}
+	i := b.ruleIndexLocked(set, ruleID)
+	if i < 0 {
+		return fakeNotFound(http.MethodDelete, "policySet/"+policySetID+"/rule/"+ruleID)
+	}
+	set.Rules = append(set.Rules[:i], set.Rules[i+1:]...)
+	delete(b.ruleExtensions, ruleID)
+	b.renumberLocked(set)
+	return nil
+}
+
+func (b *fakeBackend) setRuleExtensions(ruleID string, extensions policyRuleExtensions) {
+	b.Lock()
+	defer b.Unlock()
+	b.ruleExtensions[ruleID] = fakeClone(extensions)
+}
+
+func (b *fakeBackend) getRuleExtensions(ruleID string) policyRuleExtensions {
+	b.Lock()
+	defer b.Unlock()
+	return fakeClone(b.ruleExtensions[ruleID])
+}
+
+func (b *fakeBackend) reorderRule(policySetID, ruleID string, order int) error {
+	b.Lock()
+	defer b.Unlock()
+	path := fmt.Sprintf("policySet/%s/rule/%s/reorder/%d", policySetID, ruleID, order)
+	set, err := b.policySetLocked(http.MethodPut, policySetID)
+	if err != nil {
+		return err
+	}
+	i := b.ruleIndexLocked(set, ruleID)
+	if i < 0 {
+		return fakeNotFound(http.MethodPut, path)
+	}
+	if order < 1 || order > len(set.Rules) {
+		return fakeAPIError(http.StatusBadRequest, http.MethodPut, path, "invalid.rule.order", fmt.Sprintf("rule order %d is out of range [1, %d]", order, len(set.Rules)))
+	}
+	rule := set.Rules[i]
+	rules := append(set.Rules[:i:i], set.Rules[i+1:]...)
+	rules = append(rules[:order-1], append([]policysetcontroller.PolicyRule{rule}, rules[order-1:]...)...)
+	set.Rules = rules
+	b.renumberLocked(set)
+	return nil
+}
+
+// bulkReorderRules expects the IDs of all rules of the policy set except the default rule, which stays last.
+func (b *fakeBackend) bulkReorderRules(policySetID string, ruleIDs []string) error {
+	b.Lock()
+	defer b.Unlock()
+	path := fmt.Sprintf("policySet/%s/reorder", policySetID)
+	set, err := b.policySetLocked(http.MethodPut, policySetID)
+	if err != nil {
+		return err
+	}
+	var rules, defaultRules []policysetcontroller.PolicyRule
+	seen := map[string]bool{}
+	for _, id := range ruleIDs {
+		i := b.ruleIndexLocked(set, id)
+		if i < 0 || seen[id] || set.Rules[i].DefaultRule {
+			return fakeAPIError(http.StatusBadRequest, http.MethodPut, path, "invalid.rule.order", fmt.Sprintf("rule %s can't be reordered", id))
+		}
+		seen[id] = true
+		rules = append(rules, set.Rules[i])
+	}
+	for _, rule := range set.Rules {
+		if rule.DefaultRule {
+			defaultRules = append(defaultRules, rule)
+		} else if !seen[rule.ID] {
+			return fakeAPIError(http.StatusBadRequest, http.MethodPut, path, "invalid.rule.order", fmt.Sprintf("rule %s is missing from the order", rule.ID))
+		}
+	}
+	set.Rules = append(rules, defaultRules...)
+	b.renumberLocked(set)
+	return nil
+}
+
+func (b *fakeBackend) policySetByType(policyType string) (*policysetcontroller.PolicySet, error) {
+	b.Lock()
+	defer b.Unlock()
+	id, ok := b.policySetTypes[policyType]
+	if !ok {
+		return nil, fakeNotFound(http.MethodGet, "policySet/policyType/"+policyType)
+	}
+	set := fakeClone(*b.policySets[id])
+	return &set, nil
+}
+
+func (b *fakeBackend) rulesByType(policyType string) ([]policysetcontroller.PolicyRule, error) {
+	set, err := b.policySetByType(policyType)
+	if err != nil {
+		return nil, err
+	}
+	rules := set.Rules
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, _ := strconv.Atoi(rules[i].RuleOrder)
+		b, _ := strconv.Atoi(rules[j].RuleOrder)
+		return a < b
+	})
+	return rules, nil
+}
+
+func TestFakeBackendNameUniquenessAndNotFound(t *testing.T) {
+	zClient := newFakeClient()
+	group, _, err := zClient.segmentgroup.Create(&segmentgroup.SegmentGroup{Name: "group"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := zClient.segmentgroup.Create(&segmentgroup.SegmentGroup{Name: "GROUP"}); err == nil {
+		t.Error("expected a duplicate name to be rejected")
+	}
+	if _, err := zClient.segmentgroup.Delete(group.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _, err = zClient.segmentgroup.Get(group.ID)
+	if respErr, ok := err.(*client.ErrorResponse); !ok || !respErr.IsObjectNotFound() {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestFakeBackendSegmentGroupMembership(t *testing.T) {
+	zClient := newFakeClient()
+	first, _, _ := zClient.segmentgroup.Create(&segmentgroup.SegmentGroup{Name: "first"})
+	second, _, _ := zClient.segmentgroup.Create(&segmentgroup.SegmentGroup{Name: "second"})
+	app, _, err := zClient.applicationsegment.Create(applicationsegment.ApplicationSegmentResource{Name: "app", SegmentGroupID: first.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group, _, _ := zClient.segmentgroup.Get(first.ID); len(group.Applications) != 1 || group.Applications[0].ID != app.ID {
+		t.Errorf("expected the application in the first group, got %+v", group.Applications)
+	}
+	if _, err := zClient.segmentgroup.Delete(first.ID); err == nil {
+		t.Error("expected a segment group with applications to be kept")
+	}
+
+	app.SegmentGroupID = second.ID
+	if _, err := zClient.applicationsegment.Update(app.ID, *app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group, _, _ := zClient.segmentgroup.Get(first.ID); len(group.Applications) != 0 {
+		t.Errorf("expected the application to leave the first group, got %+v", group.Applications)
+	}
+	// every kind of application segment is a view on the same object
+	if pra, _, err := zClient.applicationsegmentpra.Get(app.ID); err != nil || pra.SegmentGroupID != second.ID {
+		t.Errorf("expected the PRA view of the application, got %+v, %v", pra, err)
+	}
+
+	if _, err := zClient.applicationsegment.Delete(app.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := zClient.segmentgroup.Delete(second.ID); err != nil {
+		t.Errorf("expected the empty segment group to be deleted, got %v", err)
+	}
+}
+
+func TestFakeBackendServerGroupApplications(t *testing.T) {
+	zClient := newFakeClient()
+	first, _, _ := zClient.servergroup.Create(&servergroup.ServerGroup{Name: "first"})
+	second, _, _ := zClient.servergroup.Create(&servergroup.ServerGroup{Name: "second"})
+	app, _, err := zClient.applicationsegment.Create(applicationsegment.ApplicationSegmentResource{
+		Name:         "app",
+		ServerGroups: []applicationsegment.AppServerGroups{{ID: first.ID}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group, _, _ := zClient.servergroup.Get(first.ID); len(group.Applications) != 1 || group.Applications[0].ID != app.ID {
+		t.Errorf("expected the application in the first server group, got %+v", group.Applications)
+	}
+
+	app.ServerGroups = []applicationsegment.AppServerGroups{{ID: second.ID}}
+	if _, err := zClient.applicationsegment.Update(app.ID, *app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group, _, _ := zClient.servergroup.Get(first.ID); len(group.Applications) != 0 {
+		t.Errorf("expected the application to leave the first server group, got %+v", group.Applications)
+	}
+	if group, _, _ := zClient.servergroup.Get(second.ID); len(group.Applications) != 1 {
+		t.Errorf("expected the application in the second server group, got %+v", group.Applications)
+	}
+
+	if _, err := zClient.applicationsegment.Delete(app.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group, _, _ := zClient.servergroup.Get(second.ID); len(group.Applications) != 0 {
+		t.Errorf("expected the deleted application to leave the second server group, got %+v", group.Applications)
+	}
+}
+
+func TestFakeBackendPolicyReorder(t *testing.T) {
+	zClient := newFakeClient()
+	set, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		rule, _, err := zClient.policysetcontroller.Create(&policysetcontroller.PolicyRule{Name: name, PolicySetID: set.ID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, rule.ID)
+	}
+	order := func() string {
+		rules, _, _ := zClient.policysetcontroller.GetAllByType("GLOBAL_POLICY")
+		var names []string
+		for _, rule := range rules {
+			names = append(names, rule.Name+rule.RuleOrder)
+		}
+		return strings.Join(names, ",")
+	}
+
+	if _, err := zClient.policysetcontroller.Reorder(set.ID, ids[3], 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := order(); got != "d1,a2,b3,c4" {
+		t.Errorf("unexpected order after moving d first: %s", got)
+	}
+	if _, err := zClient.policysetcontroller.Reorder(set.ID, ids[3], 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := order(); got != "a1,b2,d3,c4" {
+		t.Errorf("unexpected order after moving d third: %s", got)
+	}
+	if _, err := zClient.policysetcontroller.Reorder(set.ID, ids[0], 5); err == nil {
+		t.Error("expected an out of range rule order to be rejected")
+	}
+	if _, err := zClient.policysetcontroller.Delete(set.ID, ids[1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := order(); got != "a1,d2,c3" {
+		t.Errorf("unexpected order after deleting b: %s", got)
+	}
+}
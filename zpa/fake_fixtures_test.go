@@ -0,0 +1,148 @@
+package zpa
+
+import (
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/bacertificate"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/cloudconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/customerversionprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/enrollmentcert"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_predefined_controls"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/isolationprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/machinegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/postureprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/trustednetwork"
+)
+
+// IDs of the identity providers of the test tenant, SCIM groups reference them as numbers.
+const (
+	fakeAdminIdpID = "72058304855015424"
+	fakeUserIdpID  = "72058304855015425"
+	fakeOWASPCRS   = "OWASP_CRS/3.3.0"
+)
+
+// fakeFixtures holds the read only objects the acceptance tests expect to find in the test tenant.
+type fakeFixtures struct {
+	baCertificates          *fakeStore[bacertificate.BaCertificate]
+	branchConnectorGroups   *fakeStore[objectSummary]
+	cloudConnectorGroups    *fakeStore[cloudconnectorgroup.CloudConnectorGroup]
+	customerVersionProfiles *fakeStore[customerversionprofile.CustomerVersionProfile]
+	enrollmentCerts         *fakeStore[enrollmentcert.EnrollmentCert]
+	idps                    *fakeStore[idpcontroller.IdpController]
+	isolationProfiles       *fakeStore[isolationprofile.IsolationProfile]
+	locations               *fakeStore[objectSummary]
+	machineGroups           *fakeStore[machinegroup.MachineGroup]
+	postureProfiles         *fakeStore[postureprofile.PostureProfile]
+	praConsoles             *fakeStore[objectSummary]
+	predefinedControls      *fakeStore[inspection_predefined_controls.PredefinedControls]
+	samlAttributes          *fakeStore[samlattribute.SamlAttribute]
+	scimAttributeHeaders    *fakeStore[scimattributeheader.ScimAttributeHeader]
+	scimGroups              *fakeStore[scimgroup.ScimGroup]
+	trustedNetworks         *fakeStore[trustednetwork.TrustedNetwork]
+}
+
+func newFakeFixtures(b *fakeBackend) *fakeFixtures {
+	f := &fakeFixtures{
+		baCertificates: newFakeStore[bacertificate.BaCertificate](b, "clientlessCertificate").seed(
+			bacertificate.BaCertificate{Name: "bd-hashicorp.com", CName: "bd-hashicorp.com", Status: "ACTIVE"},
+			bacertificate.BaCertificate{Name: "jenkins.bd-hashicorp.com", CName: "jenkins.bd-hashicorp.com", Status: "ACTIVE"},
+			bacertificate.BaCertificate{Name: "sales.bd-hashicorp.com", CName: "sales.bd-hashicorp.com", Status: "ACTIVE"},
+		),
+		branchConnectorGroups: newFakeStore[objectSummary](b, "branchConnectorGroup").seed(
+			objectSummary{Name: "BD-BC-Group01"},
+		),
+		cloudConnectorGroups: newFakeStore[cloudconnectorgroup.CloudConnectorGroup](b, "cloudConnectorGroup").seed(
+			cloudconnectorgroup.CloudConnectorGroup{Name: "zs-cc-vpc-096108eb5d9e68d71-ca-central-1a", Enabled: true},
+		),
+		customerVersionProfiles: newFakeStore[customerversionprofile.CustomerVersionProfile](b, "visible/versionProfiles").seed(
+			customerversionprofile.CustomerVersionProfile{ID: "0", Name: "Default", VisibilityScope: "ALL"},
+			customerversionprofile.CustomerVersionProfile{ID: "1", Name: "Previous Default", VisibilityScope: "ALL"},
+			customerversionprofile.CustomerVersionProfile{ID: "2", Name: "New Release", VisibilityScope: "ALL"},
+		),
+		enrollmentCerts: newFakeStore[enrollmentcert.EnrollmentCert](b, "enrollmentCert").seed(
+			enrollmentcert.EnrollmentCert{Name: "Root", AllowSigning: true},
+			enrollmentcert.EnrollmentCert{Name: "Client", AllowSigning: true},
+			enrollmentcert.EnrollmentCert{Name: "Connector", AllowSigning: true},
+			enrollmentcert.EnrollmentCert{Name: "Service Edge", AllowSigning: true},
+		),
+		idps: newFakeStore[idpcontroller.IdpController](b, "idp").seed(
+			idpcontroller.IdpController{ID: fakeAdminIdpID, Name: "BD_Okta_Admin", Enabled: true, SsoType: []string{"ADMIN"}},
+			idpcontroller.IdpController{ID: fakeUserIdpID, Name: "BD_Okta_Users", Enabled: true, ScimEnabled: true, SsoType: []string{"USER"}},
+		),
+		isolationProfiles: newFakeStore[isolationprofile.IsolationProfile](b, "isolation/profiles").seed(
+			isolationprofile.IsolationProfile{Name: "BD_SA_Profile1", Enabled: true},
+			isolationprofile.IsolationProfile{Name: "BD_SA_Profile2", Enabled: true},
+		),
+		locations: newFakeStore[objectSummary](b, "location").seed(
+			objectSummary{Name: "BD-Location01"},
+			objectSummary{Name: "BD-Location02"},
+		),
+		machineGroups: newFakeStore[machinegroup.MachineGroup](b, "machineGroup").seed(
+			machinegroup.MachineGroup{Name: "BD-MGR01", Enabled: true},
+			machinegroup.MachineGroup{Name: "BD-MGR02", Enabled: true},
+		),
+		postureProfiles: newFakeStore[postureprofile.PostureProfile](b, "posture").seed(
+			postureprofile.PostureProfile{Name: "CrowdStrike_ZPA_Pre-ZTA (zscalertwo.net)", PostureudID: "e7a27ea0-5b3c-4a9c-a2a0-d0d2a05bb4d1"},
+			postureprofile.PostureProfile{Name: "CrowdStrike_ZPA_ZTA_40 (zscalertwo.net)", PostureudID: "13ba3d97-aefb-4acc-9e54-6cc230dee4a5"},
+			postureprofile.PostureProfile{Name: "CrowdStrike_ZPA_ZTA_80 (zscalertwo.net)", PostureudID: "f66a4f1d-6e3f-4c1b-8d5f-f0b61b09e4cb"},
+		),
+		praConsoles: newFakeStore[objectSummary](b, "praConsole").seed(
+			objectSummary{ID: "216196257331281100", Name: "BD-PRA-Console01"},
+		),
+		trustedNetworks: newFakeStore[trustednetwork.TrustedNetwork](b, "network").seed(
+			trustednetwork.TrustedNetwork{Name: "BD-TrustedNetwork03 (zscalertwo.net)", NetworkID: "869ee72e-8ec3-4d2d-9f40-8e9d7c0f6e28"},
+		),
+	}
+
+	samlAttributes := []samlattribute.SamlAttribute{}
+	for _, name := range []string{"Email", "DepartmentName", "FirstName", "LastName", "GroupName"} {
+		samlAttributes = append(samlAttributes, samlattribute.SamlAttribute{Name: name + "_BD_Okta_Users", SamlName: name, IdpID: fakeUserIdpID, IdpName: "BD_Okta_Users", UserAttribute: true})
+	}
+	f.samlAttributes = newFakeStore[samlattribute.SamlAttribute](b, "samlAttribute").seed(samlAttributes...)
+
+	scimAttributeHeaders := []scimattributeheader.ScimAttributeHeader{}
+	for _, name := range []string{"name.givenName", "name.familyName", "userName", "emails.value", "costCenter", "department"} {
+		scimAttributeHeaders = append(scimAttributeHeaders, scimattributeheader.ScimAttributeHeader{Name: name, IdpID: fakeUserIdpID, DataType: "String"})
+	}
+	f.scimAttributeHeaders = newFakeStore[scimattributeheader.ScimAttributeHeader](b, "scimattribute").seed(scimAttributeHeaders...)
+
+	scimGroups := []scimgroup.ScimGroup{}
+	for _, name := range []string{"Engineering", "Contractors", "Marketing", "Finance", "Executives"} {
+		scimGroups = append(scimGroups, scimgroup.ScimGroup{Name: name, IdpName: "BD_Okta_Users"})
+		fakeSetFieldString(&scimGroups[len(scimGroups)-1], "IdpID", fakeUserIdpID)
+	}
+	f.scimGroups = newFakeStore[scimgroup.ScimGroup](b, "scimgroup").seed(scimGroups...)
+
+	predefinedControls := []inspection_predefined_controls.PredefinedControls{}
+	for _, group := range []struct {
+		name     string
+		controls []string
+	}{
+		{"Preprocessors", []string{
+			"Failed to parse request body",
+			"Multipart request body failed strict validation",
+			"Multipart parser detected a possible unmatched boundary",
+			"Attempted multipart/form-data bypass",
+			"GET or HEAD Request with Body Content",
+			"Request content type is not allowed by policy",
+		}},
+		{"Protocol Issues", []string{"Invalid HTTP Request Line"}},
+		{"PHP Injection", []string{"PHP Injection Attack: PHP Open Tag Found"}},
+	} {
+		for _, name := range group.controls {
+			predefinedControls = append(predefinedControls, inspection_predefined_controls.PredefinedControls{
+				Name:          name,
+				ControlGroup:  group.name,
+				Version:       fakeOWASPCRS,
+				Action:        "BLOCK",
+				DefaultAction: "BLOCK",
+				ParanoiaLevel: "1",
+				Severity:      "CRITICAL",
+			})
+		}
+	}
+	f.predefinedControls = newFakeStore[inspection_predefined_controls.PredefinedControls](b, "inspectionControls/predefined").seed(predefinedControls...)
+	return f
+}
@@ -0,0 +1,1084 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentinspection"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentpra"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appservercontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/bacertificate"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/browseraccess"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/clienttypes"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/cloudconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/customerversionprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/enrollmentcert"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_custom_controls"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_predefined_controls"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_profile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/isolationprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/machinegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/platforms"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/postureprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/provisioningkey"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgecontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/trustednetwork"
+)
+
+// newFakeClient returns a Client whose services are backed by the in-memory fake backend, seeded with the
+// objects the test configurations look up with data sources.
+func newFakeClient() *Client {
+	b := newFakeBackend()
+	fixtures := newFakeFixtures(b)
+	return &Client{
+		appconnectorgroup:              &fakeAppConnectorGroups{store: newFakeStore[appconnectorgroup.AppConnectorGroup](b, "appConnectorGroup")},
+		appconnectorcontroller:         &fakeAppConnectors{store: newFakeStore[appconnectorcontroller.AppConnector](b, "connector").seed(appconnectorcontroller.AppConnector{Name: "SGIO-APP-CONNECTOR-GRP-1649283086343", Enabled: true})},
+		applicationsegment:             &fakeApplicationSegments{store: fakeApplicationStore[applicationsegment.ApplicationSegmentResource]{backend: b}},
+		applicationsegmentpra:          &fakeApplicationSegmentsPRA{store: fakeApplicationStore[applicationsegmentpra.AppSegmentPRA]{backend: b}},
+		applicationsegmentinspection:   &fakeApplicationSegmentsInspection{store: fakeApplicationStore[applicationsegmentinspection.AppSegmentInspection]{backend: b}},
+		appservercontroller:            &fakeAppServers{store: newFakeStore[appservercontroller.ApplicationServer](b, "server")},
+		bacertificate:                  &fakeBaCertificates{store: fixtures.baCertificates},
+		cloudconnectorgroup:            &fakeCloudConnectorGroups{store: fixtures.cloudConnectorGroups},
+		customerversionprofile:         &fakeCustomerVersionProfiles{store: fixtures.customerVersionProfiles},
+		enrollmentcert:                 &fakeEnrollmentCerts{store: fixtures.enrollmentCerts},
+		idpcontroller:                  &fakeIdpControllers{store: fixtures.idps},
+		lssconfigcontroller:            &fakeLSSConfigs{store: newFakeStore[lssconfigcontroller.LSSResource](b, "lssConfig")},
+		machinegroup:                   &fakeMachineGroups{store: fixtures.machineGroups},
+		postureprofile:                 &fakePostureProfiles{store: fixtures.postureProfiles},
+		isolationprofile:               &fakeIsolationProfiles{store: fixtures.isolationProfiles},
+		policysetcontroller:            &fakePolicySets{backend: b},
+		provisioningkey:                &fakeProvisioningKeys{store: newFakeStore[provisioningkey.ProvisioningKey](b, "associationType/provisioningKey")},
+		samlattribute:                  &fakeSamlAttributes{store: fixtures.samlAttributes},
+		scimgroup:                      &fakeScimGroups{store: fixtures.scimGroups},
+		scimattributeheader:            &fakeScimAttributeHeaders{store: fixtures.scimAttributeHeaders},
+		segmentgroup:                   &fakeSegmentGroups{backend: b},
+		servergroup:                    &fakeServerGroups{store: b.serverGroups},
+		serviceedgegroup:               &fakeServiceEdgeGroups{store: newFakeStore[serviceedgegroup.ServiceEdgeGroup](b, "serviceEdgeGroup")},
+		serviceedgecontroller:          &fakeServiceEdges{store: newFakeStore[serviceedgecontroller.ServiceEdgeController](b, "serviceEdge")},
+		trustednetwork:                 &fakeTrustedNetworks{store: fixtures.trustedNetworks},
+		platforms:                      &fakePlatforms{},
+		clienttypes:                    &fakeClientTypes{},
+		browseraccess:                  &fakeBrowserAccess{store: fakeApplicationStore[browseraccess.BrowserAccess]{backend: b}},
+		inspection_custom_controls:     &fakeInspectionCustomControls{store: newFakeStore[inspection_custom_controls.InspectionCustomControl](b, "inspectionControls/custom")},
+		inspection_predefined_controls: &fakeInspectionPredefinedControls{store: fixtures.predefinedControls},
+		inspection_profile:             &fakeInspectionProfiles{store: newFakeStore[inspection_profile.InspectionProfile](b, "inspectionProfile")},
+		locationcontroller:             &fakeSummaries{store: fixtures.locations},
+		branchconnectorgroup:           &fakeSummaries{store: fixtures.branchConnectorGroups},
+		praconsole:                     &fakeSummaries{store: fixtures.praConsoles},
+		policyrulereorder:              &fakePolicySets{backend: b},
+		extendedpolicyrule:             &fakeExtendedPolicyRules{backend: b},
+	}
+}
+
+type fakeAppConnectorGroups struct {
+	store *fakeStore[appconnectorgroup.AppConnectorGroup]
+}
+
+func (f *fakeAppConnectorGroups) Get(id string) (*appconnectorgroup.AppConnectorGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppConnectorGroups) GetByName(name string) (*appconnectorgroup.AppConnectorGroup, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppConnectorGroups) Create(v appconnectorgroup.AppConnectorGroup) (*appconnectorgroup.AppConnectorGroup, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeAppConnectorGroups) Update(id string, v *appconnectorgroup.AppConnectorGroup) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeAppConnectorGroups) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeAppConnectorGroups) GetAll() ([]appconnectorgroup.AppConnectorGroup, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeAppConnectors struct {
+	store *fakeStore[appconnectorcontroller.AppConnector]
+}
+
+func (f *fakeAppConnectors) Get(id string) (*appconnectorcontroller.AppConnector, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppConnectors) GetByName(name string) (*appconnectorcontroller.AppConnector, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppConnectors) GetAll() ([]appconnectorcontroller.AppConnector, error) {
+	return f.store.list(), nil
+}
+
+func (f *fakeAppConnectors) Update(id string, v appconnectorcontroller.AppConnector) (*appconnectorcontroller.AppConnector, *http.Response, error) {
+	if err := f.store.update(id, v); err != nil {
+		return nil, nil, err
+	}
+	return f.Get(id)
+}
+
+func (f *fakeAppConnectors) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeAppConnectors) BulkDelete(ids []string) (*http.Response, error) {
+	for _, id := range ids {
+		if err := f.store.delete(id); err != nil {
+			return nil, err
+		}
+	}
+	return fakeNoContent(), nil
+}
+
+type fakeApplicationSegments struct {
+	store fakeApplicationStore[applicationsegment.ApplicationSegmentResource]
+}
+
+func (f *fakeApplicationSegments) Get(id string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegments) GetByName(name string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegments) Create(v applicationsegment.ApplicationSegmentResource) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeApplicationSegments) Update(id string, v applicationsegment.ApplicationSegmentResource) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, v)
+}
+
+func (f *fakeApplicationSegments) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeApplicationSegments) GetAll() ([]applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeApplicationSegmentsPRA struct {
+	store fakeApplicationStore[applicationsegmentpra.AppSegmentPRA]
+}
+
+func (f *fakeApplicationSegmentsPRA) Get(id string) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsPRA) GetByName(name string) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsPRA) Create(v applicationsegmentpra.AppSegmentPRA) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsPRA) Update(id string, v *applicationsegmentpra.AppSegmentPRA) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeApplicationSegmentsPRA) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeApplicationSegmentsPRA) GetAll() ([]applicationsegmentpra.AppSegmentPRA, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeApplicationSegmentsInspection struct {
+	store fakeApplicationStore[applicationsegmentinspection.AppSegmentInspection]
+}
+
+func (f *fakeApplicationSegmentsInspection) Get(id string) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsInspection) GetByName(name string) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsInspection) Create(v applicationsegmentinspection.AppSegmentInspection) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeApplicationSegmentsInspection) Update(id string, v *applicationsegmentinspection.AppSegmentInspection) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeApplicationSegmentsInspection) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeApplicationSegmentsInspection) GetAll() ([]applicationsegmentinspection.AppSegmentInspection, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeBrowserAccess struct {
+	store fakeApplicationStore[browseraccess.BrowserAccess]
+}
+
+func (f *fakeBrowserAccess) Get(id string) (*browseraccess.BrowserAccess, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeBrowserAccess) GetByName(name string) (*browseraccess.BrowserAccess, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeBrowserAccess) Create(v browseraccess.BrowserAccess) (*browseraccess.BrowserAccess, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeBrowserAccess) Update(id string, v *browseraccess.BrowserAccess) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeBrowserAccess) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeBrowserAccess) GetAll() ([]browseraccess.BrowserAccess, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeAppServers struct {
+	store *fakeStore[appservercontroller.ApplicationServer]
+}
+
+func (f *fakeAppServers) Get(id string) (*appservercontroller.ApplicationServer, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppServers) GetByName(name string) (*appservercontroller.ApplicationServer, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeAppServers) Create(v appservercontroller.ApplicationServer) (*appservercontroller.ApplicationServer, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeAppServers) Update(id string, v appservercontroller.ApplicationServer) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, v)
+}
+
+func (f *fakeAppServers) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeAppServers) GetAll() ([]appservercontroller.ApplicationServer, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeBaCertificates struct {
+	store *fakeStore[bacertificate.BaCertificate]
+}
+
+func (f *fakeBaCertificates) Get(id string) (*bacertificate.BaCertificate, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeBaCertificates) GetIssuedByName(name string) (*bacertificate.BaCertificate, *http.Response, error) {
+	v, err := f.store.find(func(v *bacertificate.BaCertificate) bool { return v.Name == name }, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeBaCertificates) GetAll() ([]bacertificate.BaCertificate, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+func (f *fakeBaCertificates) Create(v bacertificate.BaCertificate) (*bacertificate.BaCertificate, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeBaCertificates) Update(id string, v *bacertificate.BaCertificate) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeBaCertificates) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+type fakeCloudConnectorGroups struct {
+	store *fakeStore[cloudconnectorgroup.CloudConnectorGroup]
+}
+
+func (f *fakeCloudConnectorGroups) Get(id string) (*cloudconnectorgroup.CloudConnectorGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeCloudConnectorGroups) GetByName(name string) (*cloudconnectorgroup.CloudConnectorGroup, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeCloudConnectorGroups) GetAll() ([]cloudconnectorgroup.CloudConnectorGroup, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeCustomerVersionProfiles struct {
+	store *fakeStore[customerversionprofile.CustomerVersionProfile]
+}
+
+func (f *fakeCustomerVersionProfiles) Get(id string) (*customerversionprofile.CustomerVersionProfile, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeCustomerVersionProfiles) GetByName(name string) (*customerversionprofile.CustomerVersionProfile, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeCustomerVersionProfiles) GetAll() ([]customerversionprofile.CustomerVersionProfile, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeEnrollmentCerts struct {
+	store *fakeStore[enrollmentcert.EnrollmentCert]
+}
+
+func (f *fakeEnrollmentCerts) Get(id string) (*enrollmentcert.EnrollmentCert, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeEnrollmentCerts) GetByName(name string) (*enrollmentcert.EnrollmentCert, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeEnrollmentCerts) GetAll() ([]enrollmentcert.EnrollmentCert, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeIdpControllers struct {
+	store *fakeStore[idpcontroller.IdpController]
+}
+
+func (f *fakeIdpControllers) Get(id string) (*idpcontroller.IdpController, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeIdpControllers) GetByName(name string) (*idpcontroller.IdpController, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeIdpControllers) GetAll() ([]idpcontroller.IdpController, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeLSSConfigs struct {
+	store *fakeStore[lssconfigcontroller.LSSResource]
+}
+
+func (f *fakeLSSConfigs) GetClientTypes() (*lssconfigcontroller.LSSClientTypes, *http.Response, error) {
+	return &lssconfigcontroller.LSSClientTypes{
+		ZPNClientTypeExporter:      "Web Browser",
+		ZPNClientTypeMachineTunnel: "Machine Tunnel",
+		ZPNClientTypeIPAnchoring:   "ZIA Service Edge",
+		ZPNClientTypeEdgeConnector: "Cloud Connector",
+		ZPNClientTypeZAPP:          "Client Connector",
+		ZPNClientTypeSlogger:       "ZPA LSS",
+	}, fakeOK(), nil
+}
+
+func (f *fakeLSSConfigs) Get(id string) (*lssconfigcontroller.LSSResource, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+// LSS configurations are named by their inner config object.
+func (f *fakeLSSConfigs) GetByName(name string) (*lssconfigcontroller.LSSResource, *http.Response, error) {
+	v, err := f.store.find(func(v *lssconfigcontroller.LSSResource) bool {
+		return v.LSSConfig != nil && strings.EqualFold(v.LSSConfig.Name, name)
+	}, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeLSSConfigs) Create(v *lssconfigcontroller.LSSResource) (*lssconfigcontroller.LSSResource, *http.Response, error) {
+	created, err := f.store.create(*v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeLSSConfigs) Update(id string, v *lssconfigcontroller.LSSResource) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeLSSConfigs) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeLSSConfigs) GetAll() ([]lssconfigcontroller.LSSResource, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+func (f *fakeLSSConfigs) GetFormats(logType string) (*lssconfigcontroller.LSSFormats, *http.Response, error) {
+	return &lssconfigcontroller.LSSFormats{
+		Csv:  fmt.Sprintf("%%s{%s:csv}", logType),
+		Tsv:  fmt.Sprintf("%%s{%s:tsv}", logType),
+		Json: fmt.Sprintf(`{"LogTimestamp": %%j{%s:time}}`, logType),
+	}, fakeOK(), nil
+}
+
+func (f *fakeLSSConfigs) GetStatusCodes() (*lssconfigcontroller.LSSStatusCodes, *http.Response, error) {
+	return &lssconfigcontroller.LSSStatusCodes{
+		ZPNAuthLog:    map[string]interface{}{"ZPN_STATUS_AUTHENTICATED": map[string]interface{}{"error_type": "Success"}},
+		ZPNAstAuthLog: map[string]interface{}{"ZPN_STATUS_AUTHENTICATED": map[string]interface{}{"error_type": "Success"}},
+		ZPNTransLog:   map[string]interface{}{"MT_CLOSED_TERMINATED": map[string]interface{}{"error_type": "Success"}},
+		ZPNSysAuthLog: map[string]interface{}{"ZPN_STATUS_AUTHENTICATED": map[string]interface{}{"error_type": "Success"}},
+	}, fakeOK(), nil
+}
+
+type fakeMachineGroups struct {
+	store *fakeStore[machinegroup.MachineGroup]
+}
+
+func (f *fakeMachineGroups) Get(id string) (*machinegroup.MachineGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeMachineGroups) GetByName(name string) (*machinegroup.MachineGroup, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeMachineGroups) GetAll() ([]machinegroup.MachineGroup, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakePostureProfiles struct {
+	store *fakeStore[postureprofile.PostureProfile]
+}
+
+func (f *fakePostureProfiles) Get(id string) (*postureprofile.PostureProfile, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakePostureProfiles) GetByPostureUDID(udid string) (*postureprofile.PostureProfile, *http.Response, error) {
+	v, err := f.store.find(func(v *postureprofile.PostureProfile) bool { return v.PostureudID == udid }, udid)
+	return v, fakeOK(), err
+}
+
+func (f *fakePostureProfiles) GetByName(name string) (*postureprofile.PostureProfile, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakePostureProfiles) GetAll() ([]postureprofile.PostureProfile, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeIsolationProfiles struct {
+	store *fakeStore[isolationprofile.IsolationProfile]
+}
+
+func (f *fakeIsolationProfiles) Get(id string) (*isolationprofile.IsolationProfile, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeIsolationProfiles) GetByName(name string) (*isolationprofile.IsolationProfile, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeIsolationProfiles) GetAll() ([]isolationprofile.IsolationProfile, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakePolicySets struct {
+	backend *fakeBackend
+}
+
+func (f *fakePolicySets) GetByPolicyType(policyType string) (*policysetcontroller.PolicySet, *http.Response, error) {
+	v, err := f.backend.policySetByType(policyType)
+	return v, fakeOK(), err
+}
+
+func (f *fakePolicySets) GetPolicyRule(policySetID, ruleID string) (*policysetcontroller.PolicyRule, *http.Response, error) {
+	v, err := f.backend.getRule(policySetID, ruleID)
+	return v, fakeOK(), err
+}
+
+func (f *fakePolicySets) Create(rule *policysetcontroller.PolicyRule) (*policysetcontroller.PolicyRule, *http.Response, error) {
+	created, err := f.backend.createRule(*rule)
+	return created, fakeOK(), err
+}
+
+func (f *fakePolicySets) Update(policySetID, ruleID string, rule *policysetcontroller.PolicyRule) (*http.Response, error) {
+	return fakeNoContent(), f.backend.updateRule(policySetID, ruleID, *rule)
+}
+
+func (f *fakePolicySets) Delete(policySetID, ruleID string) (*http.Response, error) {
+	return fakeNoContent(), f.backend.deleteRule(policySetID, ruleID)
+}
+
+func (f *fakePolicySets) GetByNameAndType(policyType, ruleName string) (*policysetcontroller.PolicyRule, *http.Response, error) {
+	rules, err := f.backend.rulesByType(policyType)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rule := range rules {
+		if strings.EqualFold(rule.Name, ruleName) {
+			return &rule, fakeOK(), nil
+		}
+	}
+	return nil, fakeOK(), fmt.Errorf("no policy rule named :%s found", ruleName)
+}
+
+func (f *fakePolicySets) GetByNameAndTypes(policyTypes []string, ruleName string) (p *policysetcontroller.PolicyRule, resp *http.Response, err error) {
+	for _, policyType := range policyTypes {
+		p, resp, err = f.GetByNameAndType(policyType, ruleName)
+		if err == nil {
+			return
+		}
+	}
+	return
+}
+
+func (f *fakePolicySets) Reorder(policySetID, ruleID string, order int) (*http.Response, error) {
+	return fakeNoContent(), f.backend.reorderRule(policySetID, ruleID, order)
+}
+
+func (f *fakePolicySets) BulkReorder(policySetID string, ruleIDs []string) (*http.Response, error) {
+	return fakeNoContent(), f.backend.bulkReorderRules(policySetID, ruleIDs)
+}
+
+func (f *fakePolicySets) RulesCount() (int, *http.Response, error) {
+	rules, err := f.backend.rulesByType("GLOBAL_POLICY")
+	return len(rules), fakeOK(), err
+}
+
+func (f *fakePolicySets) GetAllByType(policyType string) ([]policysetcontroller.PolicyRule, *http.Response, error) {
+	rules, err := f.backend.rulesByType(policyType)
+	return rules, fakeOK(), err
+}
+
+// fakeProvisioningKeys keeps the keys of both association types in one store, a key is only visible
+// through its own association type.
+// fakeExtendedPolicyRules keeps the rule in the policy set of the backend and its extensions next to it.
+type fakeExtendedPolicyRules struct {
+	backend *fakeBackend
+}
+
+func (f *fakeExtendedPolicyRules) GetPolicyRule(policySetID, ruleID string) (*extendedPolicyRule, *http.Response, error) {
+	rule, err := f.backend.getRule(policySetID, ruleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &extendedPolicyRule{PolicyRule: *rule, policyRuleExtensions: f.backend.getRuleExtensions(ruleID)}, fakeOK(), nil
+}
+
+func (f *fakeExtendedPolicyRules) Create(rule *extendedPolicyRule) (*extendedPolicyRule, *http.Response, error) {
+	created, err := f.backend.createRule(rule.PolicyRule)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.backend.setRuleExtensions(created.ID, rule.policyRuleExtensions)
+	return &extendedPolicyRule{PolicyRule: *created, policyRuleExtensions: f.backend.getRuleExtensions(created.ID)}, fakeOK(), nil
+}
+
+func (f *fakeExtendedPolicyRules) Update(policySetID, ruleID string, rule *extendedPolicyRule) (*http.Response, error) {
+	if err := f.backend.updateRule(policySetID, ruleID, rule.PolicyRule); err != nil {
+		return nil, err
+	}
+	f.backend.setRuleExtensions(ruleID, rule.policyRuleExtensions)
+	return fakeNoContent(), nil
+}
+
+type fakeProvisioningKeys struct {
+	store *fakeStore[provisioningkey.ProvisioningKey]
+}
+
+func (f *fakeProvisioningKeys) Get(associationType, id string) (*provisioningkey.ProvisioningKey, *http.Response, error) {
+	v, err := f.store.get(id)
+	if err == nil && v.AssociationType != associationType {
+		return nil, nil, fakeNotFound(http.MethodGet, associationType+"/provisioningKey/"+id)
+	}
+	return v, fakeOK(), err
+}
+
+func (f *fakeProvisioningKeys) GetByName(associationType, name string) (*provisioningkey.ProvisioningKey, *http.Response, error) {
+	v, err := f.store.find(func(v *provisioningkey.ProvisioningKey) bool {
+		return v.AssociationType == associationType && strings.EqualFold(v.Name, name)
+	}, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeProvisioningKeys) Create(associationType string, v *provisioningkey.ProvisioningKey) (*provisioningkey.ProvisioningKey, *http.Response, error) {
+	key := *v
+	key.AssociationType = associationType
+	created, err := f.store.create(key)
+	return created, fakeOK(), err
+}
+
+func (f *fakeProvisioningKeys) Update(associationType, id string, v *provisioningkey.ProvisioningKey) (*http.Response, error) {
+	if _, _, err := f.Get(associationType, id); err != nil {
+		return nil, err
+	}
+	key := *v
+	key.AssociationType = associationType
+	return fakeNoContent(), f.store.update(id, key)
+}
+
+func (f *fakeProvisioningKeys) Delete(associationType, id string) (*http.Response, error) {
+	if _, _, err := f.Get(associationType, id); err != nil {
+		return nil, err
+	}
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeProvisioningKeys) GetByNameAllAssociations(name string) (*provisioningkey.ProvisioningKey, string, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return v, v.AssociationType, fakeOK(), nil
+}
+
+func (f *fakeProvisioningKeys) GetByIDAllAssociations(id string) (*provisioningkey.ProvisioningKey, string, *http.Response, error) {
+	v, err := f.store.get(id)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return v, v.AssociationType, fakeOK(), nil
+}
+
+func (f *fakeProvisioningKeys) GetAllByAssociationType(associationType string) ([]provisioningkey.ProvisioningKey, error) {
+	var list []provisioningkey.ProvisioningKey
+	for _, key := range f.store.list() {
+		if key.AssociationType == associationType {
+			list = append(list, key)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeProvisioningKeys) GetAll() ([]provisioningkey.ProvisioningKey, error) {
+	return f.store.list(), nil
+}
+
+type fakeSamlAttributes struct {
+	store *fakeStore[samlattribute.SamlAttribute]
+}
+
+func (f *fakeSamlAttributes) Get(id string) (*samlattribute.SamlAttribute, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeSamlAttributes) GetByName(name string) (*samlattribute.SamlAttribute, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeSamlAttributes) GetAll() ([]samlattribute.SamlAttribute, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeScimGroups struct {
+	store *fakeStore[scimgroup.ScimGroup]
+}
+
+func (f *fakeScimGroups) Get(id string) (*scimgroup.ScimGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeScimGroups) GetByName(name, idpID string) (*scimgroup.ScimGroup, *http.Response, error) {
+	v, err := f.store.find(func(v *scimgroup.ScimGroup) bool {
+		return fakeFieldString(v, "IdpID") == idpID && strings.EqualFold(v.Name, name)
+	}, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeScimGroups) GetAllByIdpId(idpID string) ([]scimgroup.ScimGroup, *http.Response, error) {
+	var list []scimgroup.ScimGroup
+	for _, group := range f.store.list() {
+		if fakeFieldString(&group, "IdpID") == idpID {
+			list = append(list, group)
+		}
+	}
+	return list, fakeOK(), nil
+}
+
+type fakeScimAttributeHeaders struct {
+	store *fakeStore[scimattributeheader.ScimAttributeHeader]
+}
+
+func (f *fakeScimAttributeHeaders) Get(idpID, id string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	v, err := f.store.get(id)
+	if err == nil && v.IdpID != idpID {
+		return nil, nil, fakeNotFound(http.MethodGet, "idp/"+idpID+"/scimattribute/"+id)
+	}
+	return v, fakeOK(), err
+}
+
+func (f *fakeScimAttributeHeaders) GetValues(idpID, id string) ([]string, error) {
+	if _, _, err := f.Get(idpID, id); err != nil {
+		return nil, err
+	}
+	return []string{}, nil
+}
+
+func (f *fakeScimAttributeHeaders) GetByName(name, idpID string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	v, err := f.store.find(func(v *scimattributeheader.ScimAttributeHeader) bool {
+		return v.IdpID == idpID && strings.EqualFold(v.Name, name)
+	}, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeScimAttributeHeaders) GetAllByIdpId(idpID string) ([]scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	var list []scimattributeheader.ScimAttributeHeader
+	for _, header := range f.store.list() {
+		if header.IdpID == idpID {
+			list = append(list, header)
+		}
+	}
+	return list, fakeOK(), nil
+}
+
+type fakeSegmentGroups struct {
+	backend *fakeBackend
+}
+
+func (f *fakeSegmentGroups) Get(id string) (*segmentgroup.SegmentGroup, *http.Response, error) {
+	v, err := f.backend.segmentGroups.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeSegmentGroups) GetByName(name string) (*segmentgroup.SegmentGroup, *http.Response, error) {
+	v, err := f.backend.segmentGroups.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeSegmentGroups) Create(v *segmentgroup.SegmentGroup) (*segmentgroup.SegmentGroup, *http.Response, error) {
+	created, err := f.backend.segmentGroups.create(*v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeSegmentGroups) Update(id string, v *segmentgroup.SegmentGroup) (*http.Response, error) {
+	return fakeNoContent(), f.backend.segmentGroups.update(id, *v)
+}
+
+func (f *fakeSegmentGroups) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.backend.deleteSegmentGroup(id)
+}
+
+func (f *fakeSegmentGroups) GetAll() ([]segmentgroup.SegmentGroup, *http.Response, error) {
+	return f.backend.segmentGroups.list(), fakeOK(), nil
+}
+
+type fakeServerGroups struct {
+	store *fakeStore[servergroup.ServerGroup]
+}
+
+func (f *fakeServerGroups) Get(id string) (*servergroup.ServerGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServerGroups) GetByName(name string) (*servergroup.ServerGroup, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServerGroups) Create(v *servergroup.ServerGroup) (*servergroup.ServerGroup, *http.Response, error) {
+	created, err := f.store.create(*v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeServerGroups) Update(id string, v *servergroup.ServerGroup) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeServerGroups) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeServerGroups) GetAll() ([]servergroup.ServerGroup, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeServiceEdges struct {
+	store *fakeStore[serviceedgecontroller.ServiceEdgeController]
+}
+
+func (f *fakeServiceEdges) Get(id string) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServiceEdges) GetByName(name string) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServiceEdges) GetAll() ([]serviceedgecontroller.ServiceEdgeController, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+func (f *fakeServiceEdges) Update(id string, v serviceedgecontroller.ServiceEdgeController) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error) {
+	if err := f.store.update(id, v); err != nil {
+		return nil, nil, err
+	}
+	return f.Get(id)
+}
+
+func (f *fakeServiceEdges) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeServiceEdges) BulkDelete(ids []string) (*http.Response, error) {
+	for _, id := range ids {
+		if err := f.store.delete(id); err != nil {
+			return nil, err
+		}
+	}
+	return fakeNoContent(), nil
+}
+
+type fakeServiceEdgeGroups struct {
+	store *fakeStore[serviceedgegroup.ServiceEdgeGroup]
+}
+
+func (f *fakeServiceEdgeGroups) Get(id string) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServiceEdgeGroups) GetByName(name string) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeServiceEdgeGroups) Create(v serviceedgegroup.ServiceEdgeGroup) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeServiceEdgeGroups) Update(id string, v *serviceedgegroup.ServiceEdgeGroup) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeServiceEdgeGroups) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeServiceEdgeGroups) GetAll() ([]serviceedgegroup.ServiceEdgeGroup, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeTrustedNetworks struct {
+	store *fakeStore[trustednetwork.TrustedNetwork]
+}
+
+func (f *fakeTrustedNetworks) Get(id string) (*trustednetwork.TrustedNetwork, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeTrustedNetworks) GetByNetID(netID string) (*trustednetwork.TrustedNetwork, *http.Response, error) {
+	v, err := f.store.find(func(v *trustednetwork.TrustedNetwork) bool { return v.NetworkID == netID }, netID)
+	return v, fakeOK(), err
+}
+
+func (f *fakeTrustedNetworks) GetByName(name string) (*trustednetwork.TrustedNetwork, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeTrustedNetworks) GetAll() ([]trustednetwork.TrustedNetwork, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakePlatforms struct{}
+
+func (f *fakePlatforms) GetAllPlatforms() (*platforms.Platforms, *http.Response, error) {
+	return &platforms.Platforms{Linux: "Linux", Android: "Android", Windows: "Windows", IOS: "iOS", MacOS: "Mac"}, fakeOK(), nil
+}
+
+type fakeClientTypes struct{}
+
+func (f *fakeClientTypes) GetAllClientTypes() (*clienttypes.ClientTypes, *http.Response, error) {
+	return &clienttypes.ClientTypes{
+		ZPNClientTypeExplorer:         "Web Browser",
+		ZPNClientTypeNoAuth:           "Web Browser Unauthenticated",
+		ZPNClientTypeBrowserIsolation: "Cloud Browser",
+		ZPNClientTypeMachineTunnel:    "Machine Tunnel",
+		ZPNClientTypeIPAnchoring:      "ZIA Service Edge",
+		ZPNClientTypeEdgeConnector:    "Cloud Connector",
+		ZPNClientTypeZAPP:             "Client Connector",
+		ZPNClientTypeSlogger:          "ZPA LSS",
+		ZPNClientTypeBranchConnector:  "Branch Connector",
+	}, fakeOK(), nil
+}
+
+type fakeInspectionCustomControls struct {
+	store *fakeStore[inspection_custom_controls.InspectionCustomControl]
+}
+
+func (f *fakeInspectionCustomControls) Get(id string) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionCustomControls) GetByName(name string) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionCustomControls) Create(v inspection_custom_controls.InspectionCustomControl) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error) {
+	if v.ProtocolType == "" {
+		v.ProtocolType = "HTTP"
+	}
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeInspectionCustomControls) Update(id string, v *inspection_custom_controls.InspectionCustomControl) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeInspectionCustomControls) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeInspectionCustomControls) GetAll() ([]inspection_custom_controls.InspectionCustomControl, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeInspectionPredefinedControls struct {
+	store *fakeStore[inspection_predefined_controls.PredefinedControls]
+}
+
+func (f *fakeInspectionPredefinedControls) Get(id string) (*inspection_predefined_controls.PredefinedControls, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionPredefinedControls) GetAll(version string) ([]inspection_predefined_controls.PredefinedControls, error) {
+	var list []inspection_predefined_controls.PredefinedControls
+	for _, control := range f.store.list() {
+		if control.Version == version {
+			list = append(list, control)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeInspectionPredefinedControls) GetByName(name, version string) (*inspection_predefined_controls.PredefinedControls, *http.Response, error) {
+	v, err := f.store.find(func(v *inspection_predefined_controls.PredefinedControls) bool {
+		return v.Version == version && strings.EqualFold(v.Name, name)
+	}, name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionPredefinedControls) GetAllByGroup(version, groupName string) ([]inspection_predefined_controls.PredefinedControls, error) {
+	var list []inspection_predefined_controls.PredefinedControls
+	for _, control := range f.store.list() {
+		if control.Version == version && strings.EqualFold(control.ControlGroup, groupName) {
+			list = append(list, control)
+		}
+	}
+	return list, nil
+}
+
+type fakeInspectionProfiles struct {
+	store *fakeStore[inspection_profile.InspectionProfile]
+}
+
+func (f *fakeInspectionProfiles) Get(id string) (*inspection_profile.InspectionProfile, *http.Response, error) {
+	v, err := f.store.get(id)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionProfiles) GetByName(name string) (*inspection_profile.InspectionProfile, *http.Response, error) {
+	v, err := f.store.getByName(name)
+	return v, fakeOK(), err
+}
+
+func (f *fakeInspectionProfiles) Create(v inspection_profile.InspectionProfile) (*inspection_profile.InspectionProfile, *http.Response, error) {
+	created, err := f.store.create(v)
+	return created, fakeOK(), err
+}
+
+func (f *fakeInspectionProfiles) Update(id string, v *inspection_profile.InspectionProfile) (*http.Response, error) {
+	return fakeNoContent(), f.store.update(id, *v)
+}
+
+func (f *fakeInspectionProfiles) PutAssociate(id string, v *inspection_profile.InspectionProfile) (*http.Response, error) {
+	return f.Update(id, v)
+}
+
+func (f *fakeInspectionProfiles) PutDeassociate(id string, v *inspection_profile.InspectionProfile) (*http.Response, error) {
+	return f.Update(id, v)
+}
+
+func (f *fakeInspectionProfiles) Patch(id string, v *inspection_profile.InspectionProfile) (*http.Response, error) {
+	return f.Update(id, v)
+}
+
+func (f *fakeInspectionProfiles) Delete(id string) (*http.Response, error) {
+	return fakeNoContent(), f.store.delete(id)
+}
+
+func (f *fakeInspectionProfiles) GetAll() ([]inspection_profile.InspectionProfile, *http.Response, error) {
+	return f.store.list(), fakeOK(), nil
+}
+
+type fakeSummaries struct {
+	store *fakeStore[objectSummary]
+	// calls counts the listings, every copy of the fake client shares it
+	calls int
+}
+
+func (f *fakeSummaries) GetAll() ([]objectSummary, *http.Response, error) {
+	f.calls++
+	return f.store.list(), fakeOK(), nil
+}
@@ -0,0 +1,28 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+
+	gozscaler "github.com/zscaler/zscaler-sdk-go/zpa"
+)
+
+// policyRuleBulkReorderService sets the order of every rule of a policy set in one request, the SDK only
+// moves one rule at a time.
+type policyRuleBulkReorderService interface {
+	BulkReorder(policySetID string, ruleIDs []string) (*http.Response, error)
+}
+
+type policyRuleBulkReorder struct {
+	client *gozscaler.Client
+}
+
+func newPolicyRuleBulkReorder(client *gozscaler.Client) *policyRuleBulkReorder {
+	return &policyRuleBulkReorder{client: client}
+}
+
+// PUT --> /mgmtconfig/v1/admin/customers/{customerId}/policySet/{policySetId}/reorder
+func (s *policyRuleBulkReorder) BulkReorder(policySetID string, ruleIDs []string) (*http.Response, error) {
+	path := fmt.Sprintf(mgmtConfig+s.client.Config.CustomerID+"/policySet/%s/reorder", policySetID)
+	return s.client.NewRequestDo("PUT", path, nil, ruleIDs, nil)
+}
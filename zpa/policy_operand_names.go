@@ -0,0 +1,180 @@
+package zpa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/cloudconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/machinegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/postureprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/trustednetwork"
+)
+
+// resolvePolicyConditionNames replaces the lhs and rhs of every operand that sets lhs_name or rhs_name with the ID
+// the name resolves to. It returns nil when no operand uses names, and one error per name that is missing or
+// ambiguous. Only new and changed names are looked up, an operand of an existing rule whose name didn't change
+// keeps the ID in the state.
+func resolvePolicyConditionNames(d *schema.ResourceDiff, zClient *Client) ([]interface{}, policyOperandErrors) {
+	var errs policyOperandErrors
+	usesNames := false
+	conditions, _ := d.Get("conditions").([]interface{})
+	var previous []interface{}
+	if d.Id() != "" {
+		old, _ := d.GetChange("conditions")
+		previous, _ = old.([]interface{})
+	}
+	for i, condition := range conditions {
+		conditionSet, _ := condition.(map[string]interface{})
+		if conditionSet == nil {
+			continue
+		}
+		operands, _ := conditionSet["operands"].([]interface{})
+		for j, operand := range operands {
+			key := fmt.Sprintf("conditions.%d.operands.%d", i, j)
+			operandSet, _ := operand.(map[string]interface{})
+			if operandSet == nil || !policyOperandKnown(d, i, j) {
+				continue
+			}
+			objectType, _ := operandSet["object_type"].(string)
+			idpID, _ := operandSet["idp_id"].(string)
+			if lhs, _ := operandSet["lhs"].(string); lhs == "" && operandSet["lhs_name"] == "" {
+				errs = append(errs, &policyOperandError{key: key, attribute: "lhs", objectType: objectType, expected: "set when lhs_name isn't"})
+				continue
+			}
+			prev := previousPolicyOperand(previous, i, j, objectType)
+			if name, _ := operandSet["lhs_name"].(string); name != "" {
+				usesNames = true
+				id, _ := prev["lhs"].(string)
+				if id == "" || prev["lhs_name"] != name || prev["idp_id"] != idpID {
+					var err error
+					if id, err = resolveOperandLHSName(zClient, objectType, idpID, name); err != nil {
+						errs = append(errs, &policyOperandError{key: key, attribute: "lhs_name", objectType: objectType, expected: "the name of exactly one object", value: name, err: err})
+						continue
+					}
+				}
+				operandSet["lhs"] = id
+			}
+			if name, _ := operandSet["rhs_name"].(string); name != "" {
+				usesNames = true
+				lhs, _ := operandSet["lhs"].(string)
+				id, _ := prev["rhs"].(string)
+				if id == "" || prev["rhs_name"] != name || prev["lhs"] != lhs {
+					var err error
+					if id, err = resolveOperandRHSName(zClient, objectType, lhs, name); err != nil {
+						errs = append(errs, &policyOperandError{key: key, attribute: "rhs_name", objectType: objectType, expected: "the name of exactly one object", value: name, err: err})
+						continue
+					}
+				}
+				operandSet["rhs"] = id
+			}
+		}
+	}
+	if !usesNames {
+		return nil, errs
+	}
+	return conditions, errs
+}
+
+// previousPolicyOperand returns the operand at the same position in the state when it has the same object type,
+// or nil.
+func previousPolicyOperand(previous []interface{}, condition, operand int, objectType string) map[string]interface{} {
+	if condition >= len(previous) {
+		return nil
+	}
+	conditionSet, _ := previous[condition].(map[string]interface{})
+	operands, _ := conditionSet["operands"].([]interface{})
+	if operand >= len(operands) {
+		return nil
+	}
+	operandSet, _ := operands[operand].(map[string]interface{})
+	if operandSet["object_type"] != objectType {
+		return nil
+	}
+	return operandSet
+}
+
+func resolveOperandLHSName(zClient *Client, objectType, idpID, name string) (string, error) {
+	switch objectType {
+	case "SAML":
+		list, _, err := zClient.samlattribute.GetAll()
+		return resolveName(name, list, err, func(v samlattribute.SamlAttribute) (string, string) { return v.Name, v.ID })
+	case "SCIM":
+		if idpID == "" {
+			return "", fmt.Errorf("idp_id must be set to look up SCIM attributes by name")
+		}
+		list, _, err := zClient.scimattributeheader.GetAllByIdpId(idpID)
+		return resolveName(name, list, err, func(v scimattributeheader.ScimAttributeHeader) (string, string) { return v.Name, v.ID })
+	case "SCIM_GROUP":
+		list, _, err := zClient.idpcontroller.GetAll()
+		return resolveName(name, list, err, func(v idpcontroller.IdpController) (string, string) { return v.Name, v.ID })
+	case "POSTURE":
+		list, _, err := zClient.postureprofile.GetAll()
+		return resolveName(name, list, err, func(v postureprofile.PostureProfile) (string, string) { return v.Name, v.PostureudID })
+	case "TRUSTED_NETWORK":
+		list, _, err := zClient.trustednetwork.GetAll()
+		return resolveName(name, list, err, func(v trustednetwork.TrustedNetwork) (string, string) { return v.Name, v.NetworkID })
+	}
+	return "", fmt.Errorf("lhs_name isn't supported for object type %s", objectType)
+}
+
+func resolveOperandRHSName(zClient *Client, objectType, lhs, name string) (string, error) {
+	switch objectType {
+	case "APP":
+		list, _, err := zClient.applicationsegment.GetAll()
+		return resolveName(name, list, err, func(v applicationsegment.ApplicationSegmentResource) (string, string) { return v.Name, v.ID })
+	case "APP_GROUP":
+		list, _, err := zClient.segmentgroup.GetAll()
+		return resolveName(name, list, err, func(v segmentgroup.SegmentGroup) (string, string) { return v.Name, v.ID })
+	case "IDP":
+		list, _, err := zClient.idpcontroller.GetAll()
+		return resolveName(name, list, err, func(v idpcontroller.IdpController) (string, string) { return v.Name, v.ID })
+	case "EDGE_CONNECTOR_GROUP", "CLOUD_CONNECTOR_GROUP":
+		list, _, err := zClient.cloudconnectorgroup.GetAll()
+		return resolveName(name, list, err, func(v cloudconnectorgroup.CloudConnectorGroup) (string, string) { return v.Name, v.ID })
+	case "MACHINE_GRP":
+		list, _, err := zClient.machinegroup.GetAll()
+		return resolveName(name, list, err, func(v machinegroup.MachineGroup) (string, string) { return v.Name, v.ID })
+	case "SCIM_GROUP":
+		if lhs == "" {
+			return "", fmt.Errorf("lhs or lhs_name must name the IdP to look up SCIM groups by name")
+		}
+		list, _, err := zClient.scimgroup.GetAllByIdpId(lhs)
+		return resolveName(name, list, err, func(v scimgroup.ScimGroup) (string, string) { return v.Name, strconv.FormatInt(v.ID, 10) })
+	case "LOCATION":
+		list, _, err := zClient.locationcontroller.GetAll()
+		return resolveName(name, list, err, func(v objectSummary) (string, string) { return v.Name, v.ID })
+	case "BRANCH_CONNECTOR_GROUP":
+		list, _, err := zClient.branchconnectorgroup.GetAll()
+		return resolveName(name, list, err, func(v objectSummary) (string, string) { return v.Name, v.ID })
+	}
+	return "", fmt.Errorf("rhs_name isn't supported for object type %s", objectType)
+}
+
+// resolveName returns the ID of the only object of the list with the given name, names are compared without case
+// like the API does.
+func resolveName[T any](name string, list []T, err error, nameAndID func(T) (string, string)) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, v := range list {
+		if n, id := nameAndID(v); strings.EqualFold(n, name) {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no object named %q was found", name)
+	case 1:
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("the name %q is ambiguous, it matches the IDs %s", name, strings.Join(ids, ", "))
+}
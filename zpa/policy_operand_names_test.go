@@ -0,0 +1,27 @@
+package zpa
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolveName(t *testing.T) {
+	type object struct{ name, id string }
+	nameAndID := func(o object) (string, string) { return o.name, o.id }
+	list := []object{{"Engineering", "1"}, {"Finance", "2"}, {"finance", "3"}}
+
+	if id, err := resolveName("engineering", list, nil, nameAndID); err != nil || id != "1" {
+		t.Errorf("expected engineering to resolve to 1, got %q, %v", id, err)
+	}
+	if _, err := resolveName("Finance", list, nil, nameAndID); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected Finance to be ambiguous, got %v", err)
+	}
+	if _, err := resolveName("Marketing", list, nil, nameAndID); err == nil || !strings.Contains(err.Error(), "no object named") {
+		t.Errorf("expected Marketing to be missing, got %v", err)
+	}
+	listErr := errors.New("boom")
+	if _, err := resolveName("Engineering", list, listErr, nameAndID); err != listErr {
+		t.Errorf("expected the list error to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package zpa
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+// policyRuleCRUD creates, reads, updates and deletes the rules of one policy type through the extendedpolicyrule
+// service. It handles the attributes every rule resource has, the expand and flatten hooks of the policy type in
+// policyRuleTypes handle the attributes only the type has. zpa_policy_rule uses it with the type of its
+// policy_type attribute.
+type policyRuleCRUD struct {
+	policyType string
+}
+
+func (c policyRuleCRUD) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(c.policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := c.expand(d, policySet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	log.Printf("[INFO] Creating zpa %s rule with request\n%+v\n", c.policyType, req)
+	if c.policyType == "ACCESS_POLICY" {
+		if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	rule, _, err := zClient.extendedpolicyrule.Create(req)
+	if err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	d.SetId(rule.ID)
+	order, ok := d.GetOk("rule_order")
+	if ok {
+		if err := reorder(order, policySet.ID, c.policyType, rule.ID, zClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := placeRule(d, policySet.ID, c.policyType, rule.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return c.read(ctx, d, m)
+}
+
+func (c policyRuleCRUD) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(c.policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Getting %s rule: policySet:%s id: %s\n", c.policyType, policySet.ID, d.Id())
+	resp, _, err := zClient.extendedpolicyrule.GetPolicyRule(policySet.ID, d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Got %s rule:\n%+v\n", c.policyType, resp)
+	d.SetId(resp.ID)
+	_ = d.Set("name", resp.Name)
+	_ = d.Set("description", resp.Description)
+	_ = d.Set("action", resp.Action)
+	_ = d.Set("action_id", resp.ActionID)
+	_ = d.Set("custom_msg", resp.CustomMsg)
+	_ = d.Set("bypass_default_rule", resp.BypassDefaultRule)
+	_ = d.Set("default_rule", resp.DefaultRule)
+	_ = d.Set("operator", resp.Operator)
+	_ = d.Set("policy_set_id", resp.PolicySetID)
+	// the API may return an alias of the policy type
+	_ = d.Set("policy_type", c.policyType)
+	_ = d.Set("priority", resp.Priority)
+	_ = d.Set("rule_order", resp.RuleOrder)
+	_ = d.Set("lss_default_rule", resp.LSSDefaultRule)
+	if err := readRulePlacement(d, c.policyType, resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("conditions", flattenPolicyConditionsLike(d, resp.Conditions))
+
+	if flatten := policyRuleTypes[c.policyType].flatten; flatten != nil {
+		return flatten(d, resp)
+	}
+	return nil
+}
+
+func (c policyRuleCRUD) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(c.policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleID := d.Id()
+	log.Printf("[INFO] Updating %s rule ID: %v\n", c.policyType, ruleID)
+	req, err := c.expand(d, policySet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	if c.policyType == "ACCESS_POLICY" {
+		if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if _, _, err := zClient.extendedpolicyrule.GetPolicyRule(policySet.ID, ruleID); err != nil {
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
+		}
+		return diag.FromErr(err)
+	}
+
+	if _, err := zClient.extendedpolicyrule.Update(policySet.ID, ruleID, req); err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	if d.HasChange("rule_order") {
+		order, ok := d.GetOk("rule_order")
+		if ok {
+			if err := reorder(order, policySet.ID, c.policyType, ruleID, zClient); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if err := placeRule(d, policySet.ID, c.policyType, ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return c.read(ctx, d, m)
+}
+
+func (c policyRuleCRUD) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(c.policyType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting %s rule with id %v\n", c.policyType, d.Id())
+
+	if _, err := zClient.policysetcontroller.Delete(policySet.ID, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// expand returns the rule of d, the expand hook of the policy type sets the fields of the attributes of the type.
+func (c policyRuleCRUD) expand(d *schema.ResourceData, policySetID string) (*extendedPolicyRule, error) {
+	conditions, err := ExpandPolicyConditions(d)
+	if err != nil {
+		return nil, err
+	}
+	rule := &extendedPolicyRule{
+		PolicyRule: policysetcontroller.PolicyRule{
+			ID:                d.Id(),
+			Name:              d.Get("name").(string),
+			Description:       d.Get("description").(string),
+			Action:            d.Get("action").(string),
+			ActionID:          d.Get("action_id").(string),
+			CustomMsg:         d.Get("custom_msg").(string),
+			BypassDefaultRule: d.Get("bypass_default_rule").(bool),
+			DefaultRule:       d.Get("default_rule").(bool),
+			Operator:          d.Get("operator").(string),
+			PolicySetID:       policySetID,
+			PolicyType:        c.policyType,
+			Priority:          d.Get("priority").(string),
+			RuleOrder:         d.Get("rule_order").(string),
+			LSSDefaultRule:    d.Get("lss_default_rule").(bool),
+			Conditions:        conditions,
+		},
+	}
+	if expand := policyRuleTypes[c.policyType].expand; expand != nil {
+		expand(d, rule)
+	}
+	return rule, nil
+}
+
+// customizeDiffPolicyRuleType validates the attributes of the policy type with its customizeDiff hook, then
+// validates the conditions like the other rule resources.
+func customizeDiffPolicyRuleType(policyType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if customizeDiff := policyRuleTypes[policyType].customizeDiff; customizeDiff != nil {
+			if err := customizeDiff(d, m.(*Client).withContext(ctx)); err != nil {
+				return err
+			}
+		}
+		return customizeDiffPolicyConditions(ctx, d, m)
+	}
+}
@@ -0,0 +1,88 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+
+	gozscaler "github.com/zscaler/zscaler-sdk-go/zpa"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+// policyRuleExtensions holds the rule fields of the newer policy types that the PolicyRule of the SDK doesn't
+// have yet. A field left nil isn't sent, so that writing a rule of another policy type doesn't set it.
+type policyRuleExtensions struct {
+	Credential *policyRuleCredential `json:"credential,omitempty"`
+	// an empty list removes the service edge groups of a REDIRECTION_POLICY rule
+	ServiceEdgeGroups      *[]policyRuleServiceEdgeGroup     `json:"serviceEdgeGroups,omitempty"`
+	PrivilegedCapabilities *policyRulePrivilegedCapabilities `json:"privilegedCapabilities,omitempty"`
+}
+
+// policyRuleCredential is the privileged credential a CREDENTIAL_POLICY rule injects.
+type policyRuleCredential struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// policyRuleServiceEdgeGroup is a service edge group a REDIRECTION_POLICY rule redirects clients to.
+type policyRuleServiceEdgeGroup struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// policyRulePrivilegedCapabilities lists the capabilities a CAPABILITIES_POLICY rule enables, the capabilities
+// that aren't listed are disabled.
+type policyRulePrivilegedCapabilities struct {
+	ID           string   `json:"id,omitempty"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// extendedPolicyRule is a policy rule with the fields of policyRuleExtensions, both are marshalled into one
+// JSON document.
+type extendedPolicyRule struct {
+	policysetcontroller.PolicyRule
+	policyRuleExtensions
+}
+
+// extendedPolicyRuleService reads and writes the rules of the policy types that need policyRuleExtensions,
+// listing, reordering and deleting them goes through the policysetcontroller service.
+type extendedPolicyRuleService interface {
+	GetPolicyRule(policySetID, ruleID string) (*extendedPolicyRule, *http.Response, error)
+	Create(rule *extendedPolicyRule) (*extendedPolicyRule, *http.Response, error)
+	Update(policySetID, ruleID string, rule *extendedPolicyRule) (*http.Response, error)
+}
+
+type extendedPolicyRules struct {
+	client *gozscaler.Client
+}
+
+func newExtendedPolicyRules(client *gozscaler.Client) *extendedPolicyRules {
+	return &extendedPolicyRules{client: client}
+}
+
+// GET --> /mgmtconfig/v1/admin/customers/{customerId}/policySet/{policySetId}/rule/{ruleId}
+func (s *extendedPolicyRules) GetPolicyRule(policySetID, ruleID string) (*extendedPolicyRule, *http.Response, error) {
+	v := new(extendedPolicyRule)
+	path := fmt.Sprintf(mgmtConfig+s.client.Config.CustomerID+"/policySet/%s/rule/%s", policySetID, ruleID)
+	resp, err := s.client.NewRequestDo("GET", path, nil, nil, v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, resp, nil
+}
+
+// POST --> /mgmtconfig/v1/admin/customers/{customerId}/policySet/{policySetId}/rule
+func (s *extendedPolicyRules) Create(rule *extendedPolicyRule) (*extendedPolicyRule, *http.Response, error) {
+	v := new(extendedPolicyRule)
+	path := fmt.Sprintf(mgmtConfig+s.client.Config.CustomerID+"/policySet/%s/rule", rule.PolicySetID)
+	resp, err := s.client.NewRequestDo("POST", path, nil, rule, v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, resp, nil
+}
+
+// PUT --> /mgmtconfig/v1/admin/customers/{customerId}/policySet/{policySetId}/rule/{ruleId}
+func (s *extendedPolicyRules) Update(policySetID, ruleID string, rule *extendedPolicyRule) (*http.Response, error) {
+	path := fmt.Sprintf(mgmtConfig+s.client.Config.CustomerID+"/policySet/%s/rule/%s", policySetID, ruleID)
+	return s.client.NewRequestDo("PUT", path, nil, rule, nil)
+}
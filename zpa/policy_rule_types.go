@@ -0,0 +1,200 @@
+package zpa
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// policyRuleType describes the rules of one policy type. It is the single place the rule resources take their
+// actions and operand object types from, a new policy type only needs an entry in policyRuleTypes.
+type policyRuleType struct {
+	// aliases are the other policy types the API exposes the same policy set under.
+	aliases     []string
+	actions     []string
+	objectTypes []string
+	// attributes are the zpa_policy_rule attributes only the rules of this policy type take.
+	attributes []string
+	// attributeSchema returns the schema of the attributes CommonPolicySchema doesn't have, zpa_policy_rule takes
+	// them as optional attributes.
+	attributeSchema func() map[string]*schema.Schema
+	// expand and flatten map the attributes only the rules of this policy type take to and from the rule, see
+	// policyRuleCRUD.
+	expand  func(d *schema.ResourceData, rule *extendedPolicyRule)
+	flatten func(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics
+	// customizeDiff validates those attributes at plan time.
+	customizeDiff func(d *schema.ResourceDiff, zClient *Client) error
+}
+
+var policyRuleTypes = map[string]policyRuleType{
+	"ACCESS_POLICY": {
+		aliases: []string{"GLOBAL_POLICY"},
+		actions: []string{"ALLOW", "DENY", "REQUIRE_APPROVAL"},
+		objectTypes: []string{
+			"USER",
+			"USER_GROUP",
+			"APP",
+			"APP_GROUP",
+			"LOCATION",
+			"IDP",
+			"SAML",
+			"SCIM",
+			"SCIM_GROUP",
+			"CLIENT_TYPE",
+			"POSTURE",
+			"TRUSTED_NETWORK",
+			"BRANCH_CONNECTOR_GROUP",
+			"EDGE_CONNECTOR_GROUP",
+			"MACHINE_GRP",
+			"COUNTRY_CODE",
+			"PLATFORM",
+		},
+		attributes: []string{"app_server_groups", "app_connector_groups"},
+		expand:     expandPolicyRuleAccessAttributes,
+		flatten:    flattenPolicyRuleAccessAttributes,
+	},
+	"TIMEOUT_POLICY": {
+		aliases: []string{"REAUTH_POLICY"},
+		actions: []string{"RE_AUTH"},
+		objectTypes: []string{
+			"APP",
+			"APP_GROUP",
+			"CLIENT_TYPE",
+			"CLOUD_CONNECTOR_GROUP",
+			"IDP",
+			"POSTURE",
+			"SAML",
+			"SCIM",
+			"SCIM_GROUP",
+			"TRUSTED_NETWORK",
+		},
+		attributes: []string{"reauth_default_rule", "reauth_idle_timeout", "reauth_timeout"},
+		expand:     expandPolicyRuleTimeoutAttributes,
+		flatten:    flattenPolicyRuleTimeoutAttributes,
+	},
+	"CLIENT_FORWARDING_POLICY": {
+		aliases: []string{"BYPASS_POLICY"},
+		actions: []string{"BYPASS", "INTERCEPT", "INTERCEPT_ACCESSIBLE"},
+		objectTypes: []string{
+			"APP",
+			"APP_GROUP",
+			"CLIENT_TYPE",
+			"EDGE_CONNECTOR_GROUP",
+			"POSTURE",
+			"MACHINE_GRP",
+			"TRUSTED_NETWORK",
+			"IDP",
+			"SAML",
+			"SCIM",
+			"SCIM_GROUP",
+		},
+	},
+	"INSPECTION_POLICY": {
+		actions: []string{"INSPECT", "BYPASS_INSPECT"},
+		objectTypes: []string{
+			"APP",
+			"APP_GROUP",
+			"CLIENT_TYPE",
+			"CLOUD_CONNECTOR_GROUP",
+			"IDP",
+			"POSTURE",
+			"SAML",
+			"SCIM",
+			"SCIM_GROUP",
+			"TRUSTED_NETWORK",
+		},
+		attributes: []string{"zpn_inspection_profile_id"},
+		expand:     expandPolicyRuleInspectionAttributes,
+		flatten:    flattenPolicyRuleInspectionAttributes,
+	},
+	"ISOLATION_POLICY": {
+		actions: []string{"ISOLATE", "BYPASS_ISOLATE"},
+		objectTypes: []string{
+			"APP",
+			"CLIENT_TYPE",
+			"EDGE_CONNECTOR_GROUP",
+			"POSTURE",
+			"MACHINE_GRP",
+			"TRUSTED_NETWORK",
+			"PLATFORM",
+			"IDP",
+			"SAML",
+			"SCIM",
+			"SCIM_GROUP",
+		},
+		attributes: []string{"zpn_isolation_profile_id", "zpn_cbi_profile_id"},
+		expand:     expandPolicyRuleIsolationAttributes,
+		flatten:    flattenPolicyRuleIsolationAttributes,
+	},
+	"CREDENTIAL_POLICY": {
+		actions:         []string{"INJECT_CREDENTIALS"},
+		objectTypes:     []string{"CONSOLE", "IDP", "SAML", "SCIM_GROUP"},
+		attributes:      []string{"credential"},
+		attributeSchema: policyCredentialRuleSchema,
+		expand:          expandPolicyCredentialRule,
+		flatten:         flattenPolicyCredentialRule,
+	},
+	"CAPABILITIES_POLICY": {
+		actions:         []string{"CHECK_CAPABILITIES"},
+		objectTypes:     []string{"APP", "APP_GROUP", "SAML", "SCIM", "SCIM_GROUP"},
+		attributes:      []string{"privileged_capabilities"},
+		attributeSchema: policyCapabilitiesRuleSchema,
+		expand:          expandPolicyCapabilitiesRule,
+		flatten:         flattenPolicyCapabilitiesRule,
+	},
+	"REDIRECTION_POLICY": {
+		actions:         []string{"REDIRECT_DEFAULT", "REDIRECT_PREFERRED", "REDIRECT_ALWAYS"},
+		objectTypes:     []string{"CLIENT_TYPE", "COUNTRY_CODE"},
+		attributes:      []string{"service_edge_groups"},
+		attributeSchema: policyRedirectionRuleSchema,
+		expand:          expandPolicyRedirectionRule,
+		flatten:         flattenPolicyRedirectionRule,
+		customizeDiff:   validatePolicyRedirectionServiceEdgeGroups,
+	},
+	"CLIENTLESS_SESSION_PROTECTION_POLICY": {
+		actions:     []string{"MONITOR", "DO_NOT_MONITOR"},
+		objectTypes: []string{"APP", "APP_GROUP", "CLIENT_TYPE", "SAML", "SCIM", "SCIM_GROUP"},
+	},
+}
+
+// policyRuleImportTypes returns the policy type and its aliases, the types rules of the policy set are imported by.
+func policyRuleImportTypes(policyType string) []string {
+	return append([]string{policyType}, policyRuleTypes[policyType].aliases...)
+}
+
+// policyRuleTypeNames returns the policy types of policyRuleTypes, sorted.
+func policyRuleTypeNames() []string {
+	names := make([]string, 0, len(policyRuleTypes))
+	for name := range policyRuleTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allPolicyRuleObjectTypes returns every object type a policy rule operand can match, sorted.
+func allPolicyRuleObjectTypes() []string {
+	seen := map[string]bool{}
+	var objectTypes []string
+	for _, t := range policyRuleTypes {
+		for _, objectType := range t.objectTypes {
+			if !seen[objectType] {
+				seen[objectType] = true
+				objectTypes = append(objectTypes, objectType)
+			}
+		}
+	}
+	sort.Strings(objectTypes)
+	return objectTypes
+}
+
+// policyRuleTypeAttributes returns every attribute only some policy types take, sorted.
+func policyRuleTypeAttributes() []string {
+	var attributes []string
+	for _, t := range policyRuleTypes {
+		attributes = append(attributes, t.attributes...)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -23,26 +24,123 @@ func Provider() *schema.Provider {
 			"zpa_client_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_CLIENT_ID", nil),
 				Description: "zpa client id",
 			},
 			"zpa_client_secret": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_CLIENT_SECRET", nil),
 				Description: "zpa client secret",
 			},
 			"zpa_customer_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_CUSTOMER_ID", nil),
 				Description: "zpa customer id",
 			},
 			"zpa_cloud": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Cloud to use PRODUCTION, BETA, GOV, PREVIEW or DEV",
-				ValidateFunc: validation.StringInSlice([]string{"PRODUCTION", "BETA", "GOV", "PREVIEW, DEV"}, true),
-				Default:      "PRODUCTION",
+				DefaultFunc:  schema.EnvDefaultFunc("ZPA_CLOUD", nil),
+				Description:  "Cloud to use PRODUCTION, BETA, GOV, PREVIEW or DEV. Defaults to PRODUCTION",
+				ValidateFunc: validation.StringInSlice([]string{"PRODUCTION", "BETA", "GOV", "PREVIEW", "DEV"}, true),
+			},
+			"base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ZPA_BASE_URL", nil),
+				Description:  "Overrides the API endpoint of zpa_cloud, for example to target a ZPA cloud not known by the provider yet or a local mock API",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_PROFILE", defaultProfile),
+				Description: "Named profile of the credentials file to read the credentials from",
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_SHARED_CREDENTIALS_FILE", defaultCredentialsFile),
+				Description: "Path to the credentials file, defaults to ~/.zpa/credentials",
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_CREDENTIAL_PROCESS", nil),
+				Description: "External command printing a json object with the zpa_client_id, zpa_client_secret, zpa_customer_id and zpa_cloud keys",
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ZPA_HTTP_PROXY", nil),
+				Description:  "URL of the proxy used for API calls, the HTTPS_PROXY and HTTP_PROXY environment variables are honored when not set",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_CA_BUNDLE_FILE", nil),
+				Description: "Path to a PEM file of CA certificates trusted in addition to the system trust store, e.g. the CA of an inspecting proxy",
+			},
+			"min_tls_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1.2",
+				Description:  "Minimum TLS version for API calls, 1.2 or 1.3",
+				ValidateFunc: validation.StringInSlice([]string{"1.2", "1.3"}, false),
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disables the TLS certificate verification of the API, for lab environments only",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				Description:  "Maximum number of retries for an API call that failed with a retryable status code or error",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMinWaitSeconds,
+				Description:  "Minimum time to wait between two retries, in seconds",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxWaitSeconds,
+				Description:  "Maximum time to wait between two retries, in seconds. Also caps the wait requested by a Retry-After header",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_on_status": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "HTTP status codes that are retried, defaults to 429, 500, 502, 503 and 504",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+			},
+			"on_delete_references": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ZPA_ON_DELETE_REFERENCES", onDeleteReferencesFail),
+				Description:  "What deleting an application segment, segment group, server group or app connector group other objects refer to does: fail listing the objects, or detach the objects from it first and report the changes. Defaults to fail",
+				ValidateFunc: validation.StringInSlice([]string{onDeleteReferencesFail, onDeleteReferencesDetach}, false),
+			},
+			"read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZPA_READ_CACHE", true),
+				Description: "Caches the policy sets, platforms, client types, IdPs and SAML and SCIM attributes read during a run, the policy sets are read again after every write to a policy rule. Defaults to true",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -59,10 +157,16 @@ func Provider() *schema.Provider {
 			"zpa_segment_group":                      resourceSegmentGroup(),
 			"zpa_server_group":                       resourceServerGroup(),
 			"zpa_policy_access_rule":                 resourcePolicyAccessRule(),
+			"zpa_policy_access_rule_v2":              resourcePolicyAccessRuleV2(),
 			"zpa_policy_inspection_rule":             resourcePolicyInspectionRule(),
 			"zpa_policy_timeout_rule":                resourcePolicyTimeoutRule(),
 			"zpa_policy_forwarding_rule":             resourcePolicyForwardingRule(),
 			"zpa_policy_isolation_rule":              resourcePolicyIsolationRule(),
+			"zpa_policy_credential_rule":             resourcePolicyCredentialRule(),
+			"zpa_policy_redirection_rule":            resourcePolicyRedirectionRule(),
+			"zpa_policy_capabilities_rule":           resourcePolicyCapabilitiesRule(),
+			"zpa_policy_rule":                        resourcePolicyRule(),
+			"zpa_policy_rule_order":                  resourcePolicyRuleOrder(),
 			"zpa_provisioning_key":                   resourceProvisioningKey(),
 			"zpa_service_edge_group":                 resourceServiceEdgeGroup(),
 			"zpa_lss_config_controller":              resourceLSSConfigController(),
@@ -89,6 +193,9 @@ func Provider() *schema.Provider {
 			"zpa_machine_group":                      dataSourceMachineGroup(),
 			"zpa_provisioning_key":                   dataSourceProvisioningKey(),
 			"zpa_policy_type":                        dataSourcePolicyType(),
+			"zpa_policy_rules":                       dataSourcePolicyRules(),
+			"zpa_policy_evaluation":                  dataSourcePolicyEvaluation(),
+			"zpa_policy_analysis":                    dataSourcePolicyAnalysis(),
 			"zpa_isolation_profile":                  dataSourceIsolationProfile(),
 			"zpa_posture_profile":                    dataSourcePostureProfile(),
 			"zpa_service_edge_group":                 dataSourceServiceEdgeGroup(),
@@ -142,13 +249,57 @@ func deprecateIncorrectNaming(d *schema.Resource, newResource string) *schema.Re
 
 func zscalerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
 	log.Printf("[INFO] Initializing ZPA client")
+	retry, err := expandRetryConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := resolveCredentials(Credentials{
+		ClientID:          d.Get("zpa_client_id").(string),
+		ClientSecret:      d.Get("zpa_client_secret").(string),
+		CustomerID:        d.Get("zpa_customer_id").(string),
+		Cloud:             d.Get("zpa_cloud").(string),
+		CredentialProcess: d.Get("credential_process").(string),
+	}, d.Get("shared_credentials_file").(string), d.Get("profile").(string))
+	if err != nil {
+		return nil, err
+	}
+	if creds.Cloud == "" {
+		creds.Cloud = "PRODUCTION"
+	}
 	config := Config{
-		ClientID:     d.Get("zpa_client_id").(string),
-		ClientSecret: d.Get("zpa_client_secret").(string),
-		CustomerID:   d.Get("zpa_customer_id").(string),
-		BaseURL:      d.Get("zpa_cloud").(string),
-		UserAgent:    fmt.Sprintf("(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, terraformVersion),
+		ClientID:           creds.ClientID,
+		ClientSecret:       creds.ClientSecret,
+		CustomerID:         creds.CustomerID,
+		Cloud:              creds.Cloud,
+		BaseURL:            d.Get("base_url").(string),
+		UserAgent:          fmt.Sprintf("(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, terraformVersion),
+		Retry:              retry,
+		HTTPProxy:          d.Get("http_proxy").(string),
+		CABundleFile:       d.Get("ca_bundle_file").(string),
+		MinTLSVersion:      d.Get("min_tls_version").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		OnDeleteReferences: d.Get("on_delete_references").(string),
+		ReadCache:          d.Get("read_cache").(bool),
 	}
 
 	return config.Client()
 }
+
+func expandRetryConfig(d *schema.ResourceData) (RetryConfig, error) {
+	retry := RetryConfig{
+		MaxRetries:    d.Get("max_retries").(int),
+		MinWait:       time.Duration(d.Get("min_wait").(int)) * time.Second,
+		MaxWait:       time.Duration(d.Get("max_wait").(int)) * time.Second,
+		RetryOnStatus: defaultRetryOnStatus,
+	}
+	if retry.MinWait > retry.MaxWait {
+		return retry, fmt.Errorf("min_wait (%s) must not be greater than max_wait (%s)", retry.MinWait, retry.MaxWait)
+	}
+	if codes, ok := d.GetOk("retry_on_status"); ok {
+		retry.RetryOnStatus = nil
+		for _, code := range codes.([]interface{}) {
+			retry.RetryOnStatus = append(retry.RetryOnStatus, code.(int))
+		}
+	}
+	return retry, nil
+}
@@ -1,30 +1,17 @@
 package zpa
 
 import (
+	"context"
 	"errors"
 	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var testAccProvider *schema.Provider
-var testAccProviders map[string]*schema.Provider
-var testAccProviderFactories map[string]func() (*schema.Provider, error)
-
-func init() {
-	testAccProvider = Provider()
-	testAccProviders = map[string]*schema.Provider{
-		"zpa": testAccProvider,
-	}
-
-	testAccProviderFactories = map[string]func() (*schema.Provider, error){
-		"zpa": func() (*schema.Provider, error) {
-			return testAccProvider, nil
-		},
-	}
-}
-
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -63,3 +50,36 @@ func accPreCheck() error {
 	}
 	return nil
 }
+
+// resourceTest runs the test case with the given provider, as an acceptance test against the ZPA API when TF_ACC
+// is set, and as a unit test against the in-memory fake backend otherwise. Every test builds its own provider, so
+// that tests running in parallel don't share its configuration. Unit tests still need a terraform binary, they are
+// skipped when none is found in TF_ACC_TERRAFORM_PATH or in the PATH.
+func resourceTest(t *testing.T, provider *schema.Provider, tc resource.TestCase) {
+	t.Helper()
+	tc.Providers = map[string]*schema.Provider{
+		"zpa": provider,
+	}
+	if os.Getenv(resource.EnvTfAcc) != "" {
+		resource.Test(t, tc)
+		return
+	}
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("unit tests against the fake backend need a terraform binary, set TF_ACC_TERRAFORM_PATH or add terraform to the PATH")
+		}
+	}
+	fakeClient := newFakeClient()
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// the settings that don't concern the connection to the API apply to the fake client too, every
+		// configuration starts a provider process with an empty read cache
+		client := *fakeClient
+		client.onDeleteReferences = d.Get("on_delete_references").(string)
+		if d.Get("read_cache").(bool) {
+			client.useReadCache(newReadCache())
+		}
+		return &client, nil
+	}
+	tc.PreCheck = nil
+	resource.UnitTest(t, tc)
+}
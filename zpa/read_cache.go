@@ -0,0 +1,268 @@
+package zpa
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/clienttypes"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/platforms"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
+)
+
+// readCache keeps the results of the lookups that rarely or never change during a run, the policy sets, the
+// platforms, the client types, the IdPs and the SAML and SCIM attributes, for the lifetime of the provider
+// process. Every context scoped copy of the client shares it, see useReadCache.
+type readCache struct {
+	sync.Mutex
+	entries map[string]readCacheEntry
+	// generation counts the invalidations, a read that overlaps one may have fetched what it dropped
+	generation uint64
+}
+
+type readCacheEntry struct {
+	value interface{}
+	resp  *http.Response
+}
+
+func newReadCache() *readCache {
+	return &readCache{entries: map[string]readCacheEntry{}}
+}
+
+// invalidate drops the entries whose key starts with prefix, and keeps the reads in flight from caching their result.
+func (c *readCache) invalidate(prefix string) {
+	c.Lock()
+	defer c.Unlock()
+	c.generation++
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cachedRead returns the result of read cached under key, and caches it when read succeeds and no invalidation
+// happened during the read. Every caller gets a copy of the result, so that changing it doesn't change what the
+// next caller gets.
+func cachedRead[T any](c *readCache, key string, read func() (T, *http.Response, error)) (T, *http.Response, error) {
+	c.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.Unlock()
+	if ok {
+		log.Printf("[DEBUG] Read cache hit for %s\n", key)
+		return cloneCached(entry.value.(T)), entry.resp, nil
+	}
+	v, resp, err := read()
+	if err != nil {
+		return v, resp, err
+	}
+	c.Lock()
+	if c.generation == generation {
+		c.entries[key] = readCacheEntry{value: v, resp: resp}
+	} else {
+		log.Printf("[DEBUG] Not caching %s, the cache was invalidated during the read\n", key)
+	}
+	c.Unlock()
+	return cloneCached(v), resp, nil
+}
+
+func cloneCached[T any](v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var clone T
+	if err := json.Unmarshal(data, &clone); err != nil {
+		panic(err)
+	}
+	return clone
+}
+
+// useReadCache routes the lookups of the client through the cache, a nil cache leaves the client unchanged.
+func (c *Client) useReadCache(cache *readCache) {
+	c.cache = cache
+	if cache == nil {
+		return
+	}
+	c.policysetcontroller = &cachedPolicySetController{policySetControllerService: c.policysetcontroller, cache: cache}
+	c.extendedpolicyrule = &cachedExtendedPolicyRules{extendedPolicyRuleService: c.extendedpolicyrule, cache: cache}
+	c.policyrulereorder = &cachedPolicyRuleBulkReorder{policyRuleBulkReorderService: c.policyrulereorder, cache: cache}
+	c.lssconfigcontroller = &cachedLSSConfigController{lssConfigControllerService: c.lssconfigcontroller, cache: cache}
+	c.platforms = &cachedPlatforms{platformsService: c.platforms, cache: cache}
+	c.clienttypes = &cachedClientTypes{clientTypesService: c.clienttypes, cache: cache}
+	c.idpcontroller = &cachedIdpController{idpControllerService: c.idpcontroller, cache: cache}
+	c.samlattribute = &cachedSamlAttributes{samlAttributeService: c.samlattribute, cache: cache}
+	c.scimattributeheader = &cachedScimAttributeHeaders{scimAttributeHeaderService: c.scimattributeheader, cache: cache}
+}
+
+// The policy sets list their rules, every write to a rule drops them from the cache. The SIEM_POLICY rules
+// are written through the LSS configs.
+const policySetCacheKey = "policySet/"
+
+type cachedPolicySetController struct {
+	policySetControllerService
+	cache *readCache
+}
+
+func (s *cachedPolicySetController) GetByPolicyType(policyType string) (*policysetcontroller.PolicySet, *http.Response, error) {
+	return cachedRead(s.cache, policySetCacheKey+policyType, func() (*policysetcontroller.PolicySet, *http.Response, error) {
+		return s.policySetControllerService.GetByPolicyType(policyType)
+	})
+}
+
+func (s *cachedPolicySetController) Create(rule *policysetcontroller.PolicyRule) (*policysetcontroller.PolicyRule, *http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.policySetControllerService.Create(rule)
+}
+
+func (s *cachedPolicySetController) Update(policySetID, ruleID string, rule *policysetcontroller.PolicyRule) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.policySetControllerService.Update(policySetID, ruleID, rule)
+}
+
+func (s *cachedPolicySetController) Delete(policySetID, ruleID string) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.policySetControllerService.Delete(policySetID, ruleID)
+}
+
+func (s *cachedPolicySetController) Reorder(policySetID, ruleID string, order int) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.policySetControllerService.Reorder(policySetID, ruleID, order)
+}
+
+type cachedExtendedPolicyRules struct {
+	extendedPolicyRuleService
+	cache *readCache
+}
+
+func (s *cachedExtendedPolicyRules) Create(rule *extendedPolicyRule) (*extendedPolicyRule, *http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.extendedPolicyRuleService.Create(rule)
+}
+
+func (s *cachedExtendedPolicyRules) Update(policySetID, ruleID string, rule *extendedPolicyRule) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.extendedPolicyRuleService.Update(policySetID, ruleID, rule)
+}
+
+type cachedPolicyRuleBulkReorder struct {
+	policyRuleBulkReorderService
+	cache *readCache
+}
+
+func (s *cachedPolicyRuleBulkReorder) BulkReorder(policySetID string, ruleIDs []string) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.policyRuleBulkReorderService.BulkReorder(policySetID, ruleIDs)
+}
+
+type cachedLSSConfigController struct {
+	lssConfigControllerService
+	cache *readCache
+}
+
+func (s *cachedLSSConfigController) Create(lssConfig *lssconfigcontroller.LSSResource) (*lssconfigcontroller.LSSResource, *http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.lssConfigControllerService.Create(lssConfig)
+}
+
+func (s *cachedLSSConfigController) Update(lssID string, lssConfig *lssconfigcontroller.LSSResource) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.lssConfigControllerService.Update(lssID, lssConfig)
+}
+
+func (s *cachedLSSConfigController) Delete(lssID string) (*http.Response, error) {
+	defer s.cache.invalidate(policySetCacheKey)
+	return s.lssConfigControllerService.Delete(lssID)
+}
+
+type cachedPlatforms struct {
+	platformsService
+	cache *readCache
+}
+
+func (s *cachedPlatforms) GetAllPlatforms() (*platforms.Platforms, *http.Response, error) {
+	return cachedRead(s.cache, "platforms", s.platformsService.GetAllPlatforms)
+}
+
+type cachedClientTypes struct {
+	clientTypesService
+	cache *readCache
+}
+
+func (s *cachedClientTypes) GetAllClientTypes() (*clienttypes.ClientTypes, *http.Response, error) {
+	return cachedRead(s.cache, "clientTypes", s.clientTypesService.GetAllClientTypes)
+}
+
+type cachedIdpController struct {
+	idpControllerService
+	cache *readCache
+}
+
+func (s *cachedIdpController) Get(idpID string) (*idpcontroller.IdpController, *http.Response, error) {
+	return cachedRead(s.cache, "idp/id/"+idpID, func() (*idpcontroller.IdpController, *http.Response, error) {
+		return s.idpControllerService.Get(idpID)
+	})
+}
+
+func (s *cachedIdpController) GetByName(idpName string) (*idpcontroller.IdpController, *http.Response, error) {
+	return cachedRead(s.cache, "idp/name/"+idpName, func() (*idpcontroller.IdpController, *http.Response, error) {
+		return s.idpControllerService.GetByName(idpName)
+	})
+}
+
+func (s *cachedIdpController) GetAll() ([]idpcontroller.IdpController, *http.Response, error) {
+	return cachedRead(s.cache, "idp/all", s.idpControllerService.GetAll)
+}
+
+type cachedSamlAttributes struct {
+	samlAttributeService
+	cache *readCache
+}
+
+func (s *cachedSamlAttributes) Get(samlAttributeID string) (*samlattribute.SamlAttribute, *http.Response, error) {
+	return cachedRead(s.cache, "samlAttribute/id/"+samlAttributeID, func() (*samlattribute.SamlAttribute, *http.Response, error) {
+		return s.samlAttributeService.Get(samlAttributeID)
+	})
+}
+
+func (s *cachedSamlAttributes) GetByName(samlAttrName string) (*samlattribute.SamlAttribute, *http.Response, error) {
+	return cachedRead(s.cache, "samlAttribute/name/"+samlAttrName, func() (*samlattribute.SamlAttribute, *http.Response, error) {
+		return s.samlAttributeService.GetByName(samlAttrName)
+	})
+}
+
+func (s *cachedSamlAttributes) GetAll() ([]samlattribute.SamlAttribute, *http.Response, error) {
+	return cachedRead(s.cache, "samlAttribute/all", s.samlAttributeService.GetAll)
+}
+
+// cachedScimAttributeHeaders caches the SCIM attributes of the IdPs, not their values, which change with the
+// users the IdPs provision.
+type cachedScimAttributeHeaders struct {
+	scimAttributeHeaderService
+	cache *readCache
+}
+
+func (s *cachedScimAttributeHeaders) Get(idpID, scimAttrHeaderID string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	return cachedRead(s.cache, "scimAttributeHeader/"+idpID+"/id/"+scimAttrHeaderID, func() (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+		return s.scimAttributeHeaderService.Get(idpID, scimAttrHeaderID)
+	})
+}
+
+func (s *cachedScimAttributeHeaders) GetByName(scimAttributeName, idpID string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	return cachedRead(s.cache, "scimAttributeHeader/"+idpID+"/name/"+scimAttributeName, func() (*scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+		return s.scimAttributeHeaderService.GetByName(scimAttributeName, idpID)
+	})
+}
+
+func (s *cachedScimAttributeHeaders) GetAllByIdpId(idpID string) ([]scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+	return cachedRead(s.cache, "scimAttributeHeader/"+idpID+"/all", func() ([]scimattributeheader.ScimAttributeHeader, *http.Response, error) {
+		return s.scimAttributeHeaderService.GetAllByIdpId(idpID)
+	})
+}
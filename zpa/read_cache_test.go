@@ -0,0 +1,128 @@
+package zpa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadCache(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			var policySetGets, ruleDeletes int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/signin", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]string{"token_type": "Bearer", "access_token": testAccessToken()})
+			})
+			mux.HandleFunc("/mgmtconfig/v1/admin/customers/123/policySet/policyType/ACCESS_POLICY", func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&policySetGets, 1)
+				fmt.Fprint(w, `{"id": "7", "policyType": "1", "rules": [{"id": "8", "name": "rule"}]}`)
+			})
+			mux.HandleFunc("/mgmtconfig/v1/admin/customers/123/policySet/7/rule/8", func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&ruleDeletes, 1)
+				w.WriteHeader(http.StatusNoContent)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			config := Config{
+				ClientID:     "client",
+				ClientSecret: "secret",
+				CustomerID:   "123",
+				Cloud:        "DEV",
+				BaseURL:      server.URL,
+				UserAgent:    "terraform-provider-zpa/test",
+				Retry:        RetryConfig{RetryOnStatus: defaultRetryOnStatus},
+				ReadCache:    enabled,
+			}
+			zClient, err := config.Client()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// every operation reads with a client of its own, they share the cache of the provider client
+			for i := 0; i < 3; i++ {
+				policySet, _, err := zClient.withContext(context.Background()).policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if policySet.ID != "7" || len(policySet.Rules) != 1 {
+					t.Fatalf("unexpected policy set %+v", policySet)
+				}
+				// changing a result doesn't change the cached one
+				policySet.Rules = nil
+			}
+			want := int32(3)
+			if enabled {
+				want = 1
+			}
+			if policySetGets != want {
+				t.Errorf("got %d reads of the policy set, want %d", policySetGets, want)
+			}
+
+			if _, err := zClient.withContext(context.Background()).policysetcontroller.Delete("7", "8"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policySetGets != want+1 || ruleDeletes != 1 {
+				t.Errorf("got %d reads of the policy set and %d rule deletes, want the policy set read again after the delete", policySetGets, ruleDeletes)
+			}
+		})
+	}
+}
+
+func TestReadCacheDoesNotCacheErrors(t *testing.T) {
+	cache := newReadCache()
+	calls := 0
+	read := func() ([]string, *http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, nil, fmt.Errorf("failed")
+		}
+		return []string{"value"}, nil, nil
+	}
+	if _, _, err := cachedRead(cache, "key", read); err == nil {
+		t.Fatal("expected the error of the read")
+	}
+	for i := 0; i < 2; i++ {
+		if v, _, err := cachedRead(cache, "key", read); err != nil || len(v) != 1 {
+			t.Fatalf("got %v, %v", v, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d reads, want 2", calls)
+	}
+	cache.invalidate("k")
+	if _, _, _ = cachedRead(cache, "key", read); calls != 3 {
+		t.Errorf("got %d reads, want the invalidated entry read again", calls)
+	}
+}
+
+func TestReadCacheDoesNotCacheReadsOverlappingAnInvalidation(t *testing.T) {
+	cache := newReadCache()
+	calls := 0
+	read := func() ([]string, *http.Response, error) {
+		calls++
+		if calls == 1 {
+			// a write to the object drops it from the cache while the read is in flight
+			cache.invalidate("key")
+			return []string{"stale"}, nil, nil
+		}
+		return []string{"fresh"}, nil, nil
+	}
+	if v, _, err := cachedRead(cache, "key", read); err != nil || v[0] != "stale" {
+		t.Fatalf("got %v, %v", v, err)
+	}
+	if v, _, err := cachedRead(cache, "key", read); err != nil || v[0] != "fresh" {
+		t.Errorf("got %v, %v, want the read after the invalidation not to return the stale result", v, err)
+	}
+	if _, _, _ = cachedRead(cache, "key", read); calls != 2 {
+		t.Errorf("got %d reads, want the result of the second read cached", calls)
+	}
+}
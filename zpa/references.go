@@ -0,0 +1,716 @@
+package zpa
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
+)
+
+// The values of the on_delete_references provider setting.
+const (
+	onDeleteReferencesFail   = "fail"
+	onDeleteReferencesDetach = "detach"
+)
+
+// detachConcurrency bounds the number of objects read or updated in parallel to detach an object, the API rate
+// limits the calls of a tenant.
+const detachConcurrency = 4
+
+// objectLocks serializes the detaches that read and update the same object. Terraform deletes several objects in
+// parallel, and two of them may be detached from the same object: without the lock the second update writes back
+// the reference the first one removed.
+var objectLocks = struct {
+	sync.Mutex
+	locks map[objectReference]*sync.Mutex
+}{locks: map[objectReference]*sync.Mutex{}}
+
+// lockObject locks the object until the returned function is called.
+func lockObject(kind, id string) func() {
+	objectLocks.Lock()
+	lock, ok := objectLocks.locks[objectReference{kind, id}]
+	if !ok {
+		lock = &sync.Mutex{}
+		objectLocks.locks[objectReference{kind, id}] = lock
+	}
+	objectLocks.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
+
+// objectReference identifies an object other objects refer to, kind is the name of its type in messages.
+type objectReference struct {
+	kind string
+	id   string
+}
+
+// policyOperandReference returns the object the operand refers to, if any.
+func policyOperandReference(operand policysetcontroller.Operands) (objectReference, bool) {
+	switch operand.ObjectType {
+	case "APP":
+		return objectReference{"application segment", operand.RHS}, true
+	case "APP_GROUP":
+		return objectReference{"segment group", operand.RHS}, true
+	case "IDP":
+		return objectReference{"IdP", operand.RHS}, true
+	case "SCIM_GROUP":
+		return objectReference{"SCIM group", operand.RHS}, true
+	case "SAML":
+		return objectReference{"SAML attribute", operand.LHS}, true
+	case "MACHINE_GRP":
+		return objectReference{"machine group", operand.RHS}, true
+	case "EDGE_CONNECTOR_GROUP", "CLOUD_CONNECTOR_GROUP":
+		return objectReference{"cloud connector group", operand.RHS}, true
+	case "POSTURE":
+		return objectReference{"posture profile", operand.LHS}, true
+	case "TRUSTED_NETWORK":
+		return objectReference{"trusted network", operand.LHS}, true
+	case "LOCATION":
+		return objectReference{"location", operand.RHS}, true
+	case "BRANCH_CONNECTOR_GROUP":
+		return objectReference{"branch connector group", operand.RHS}, true
+	}
+	return objectReference{}, false
+}
+
+// policyRuleReferences returns the objects the operands and the groups of the rule refer to.
+func policyRuleReferences(rule policysetcontroller.PolicyRule) []objectReference {
+	var references []objectReference
+	for _, condition := range rule.Conditions {
+		for _, operand := range condition.Operands {
+			if reference, ok := policyOperandReference(operand); ok {
+				references = append(references, reference)
+			}
+		}
+	}
+	for _, group := range rule.AppServerGroups {
+		references = append(references, objectReference{"server group", group.ID})
+	}
+	for _, group := range rule.AppConnectorGroups {
+		references = append(references, objectReference{"app connector group", group.ID})
+	}
+	return references
+}
+
+// lssOperandKinds are the kinds of objects the operands of the policy rule of an LSS config refer to, by object type.
+var lssOperandKinds = map[string]string{
+	"APP":       "application segment",
+	"APP_GROUP": "segment group",
+}
+
+// objectReferrer is an object that refers to another object, along with the change that removes the reference.
+// blocked is the reason the reference can't be removed without changing what the object does, detach is nil then.
+type objectReferrer struct {
+	kind    string
+	id      string
+	name    string
+	change  string
+	blocked string
+	detach  func() error
+}
+
+func (r objectReferrer) String() string {
+	return fmt.Sprintf("%s %q (%s)", r.kind, r.name, r.id)
+}
+
+type referrerFinder func(zClient *Client, reference objectReference) ([]objectReferrer, error)
+
+// referrerFinders are the finders of the objects that may refer to an object, by kind of object.
+var referrerFinders = map[string][]referrerFinder{
+	"application segment": {findPolicyRuleReferrers, findLSSConfigReferrers, findServerGroupApplicationReferrers},
+	"segment group":       {findPolicyRuleReferrers, findLSSConfigReferrers},
+	"server group":        {findPolicyRuleReferrers, findApplicationSegmentReferrers, findAppConnectorGroupReferrers},
+	"app connector group": {findPolicyRuleReferrers, findLSSConfigReferrers, findServerGroupReferrers},
+}
+
+// findReferrers returns every object that refers to the object.
+func findReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	var referrers []objectReferrer
+	for _, find := range referrerFinders[reference.kind] {
+		found, err := find(zClient, reference)
+		if err != nil {
+			return nil, err
+		}
+		referrers = append(referrers, found...)
+	}
+	return referrers, nil
+}
+
+// deleteReferencedObject deletes an object that other objects may refer to. Depending on the on_delete_references
+// setting of the provider, the objects that refer to it either fail the delete or are detached from it first,
+// and every change made to them is reported as a warning. A policy rule that detaching would widen fails the
+// delete in both cases.
+func deleteReferencedObject(zClient *Client, reference objectReference, delete func() error) diag.Diagnostics {
+	referrers, err := findReferrers(zClient, reference)
+	if err != nil {
+		return diag.Errorf("failed to find the objects that refer to the %s %s: %v", reference.kind, reference.id, err)
+	}
+	if len(referrers) > 0 && zClient.onDeleteReferences != onDeleteReferencesDetach {
+		lines := make([]string, len(referrers))
+		for i, referrer := range referrers {
+			lines[i] = "  - " + referrer.String()
+			if referrer.blocked != "" {
+				lines[i] += ": " + referrer.blocked
+			}
+		}
+		return diag.Errorf("the %s %s can't be deleted, it is referred to by:\n%s\nRemove the references, or set on_delete_references to %q in the provider configuration to detach them on delete",
+			reference.kind, reference.id, strings.Join(lines, "\n"), onDeleteReferencesDetach)
+	}
+	var blocked []string
+	for _, referrer := range referrers {
+		if referrer.blocked != "" {
+			blocked = append(blocked, fmt.Sprintf("  - %s: %s", referrer, referrer.blocked))
+		}
+	}
+	if len(blocked) > 0 {
+		return diag.Errorf("the %s %s can't be detached from every object that refers to it:\n%s\nUpdate or delete those objects first",
+			reference.kind, reference.id, strings.Join(blocked, "\n"))
+	}
+
+	var detached []string
+	report := func() diag.Diagnostics {
+		if len(detached) == 0 {
+			return nil
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Detached the %s %s from %d objects", reference.kind, reference.id, len(detached)),
+			Detail:   strings.Join(detached, "\n"),
+		}}
+	}
+	errs := forEachConcurrently(len(referrers), func(i int) error {
+		log.Printf("[INFO] Detaching the %s %s from %s: %s\n", reference.kind, reference.id, referrers[i], referrers[i].change)
+		return referrers[i].detach()
+	})
+	var diags diag.Diagnostics
+	for i, referrer := range referrers {
+		if errs[i] != nil {
+			diags = append(diags, diag.Errorf("failed to detach the %s %s from %s: %v", reference.kind, reference.id, referrer, errs[i])...)
+			continue
+		}
+		detached = append(detached, fmt.Sprintf("%s: %s", referrer, referrer.change))
+	}
+	if diags.HasError() {
+		return append(report(), diags...)
+	}
+	if err := delete(); err != nil {
+		return append(report(), diag.FromErr(err)...)
+	}
+	return report()
+}
+
+// forEachConcurrently calls fn for every index below n, at most detachConcurrency calls at a time, and returns
+// the errors by index.
+func forEachConcurrently(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	semaphore := make(chan struct{}, detachConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func findPolicyRuleReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	var referrers []objectReferrer
+	// the SIEM_POLICY rules belong to LSS configs, they are found by findLSSConfigReferrers
+	for _, policyType := range policyRuleTypeNames() {
+		policySet, _, err := zClient.policysetcontroller.GetByPolicyType(policyType)
+		if err != nil {
+			if isNotFoundError(err) {
+				// the policy set doesn't exist in tenants without the feature
+				continue
+			}
+			return nil, err
+		}
+		rules, _, err := zClient.policysetcontroller.GetAllByType(policyType)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			change, blocked := removePolicyRuleReference(&rule, reference)
+			if blocked != "" {
+				referrers = append(referrers, objectReferrer{kind: policyType + " rule", id: rule.ID, name: rule.Name, blocked: blocked})
+				continue
+			}
+			if change == "" {
+				continue
+			}
+			policySetID, ruleID := policySet.ID, rule.ID
+			referrers = append(referrers, objectReferrer{
+				kind:   policyType + " rule",
+				id:     rule.ID,
+				name:   rule.Name,
+				change: change,
+				detach: func() error {
+					defer lockObject("policy rule", ruleID)()
+					// the extended rule keeps the attributes of the rule the SDK doesn't know about
+					rule, _, err := zClient.extendedpolicyrule.GetPolicyRule(policySetID, ruleID)
+					if err != nil {
+						return err
+					}
+					change, blocked := removePolicyRuleReference(&rule.PolicyRule, reference)
+					if blocked != "" {
+						return fmt.Errorf("the rule changed since it was read, %s", blocked)
+					}
+					if change == "" {
+						return nil
+					}
+					_, err = zClient.extendedpolicyrule.Update(policySetID, ruleID, rule)
+					return err
+				},
+			})
+		}
+	}
+	return referrers, nil
+}
+
+// removePolicyRuleReference removes the operands and the groups of the rule that refer to the object, and describes
+// the change. It returns an empty change when the rule doesn't refer to the object. A condition left without
+// operands would no longer restrict what the rule matches, so the rule is left unchanged when removing the operands
+// would empty one of its conditions, and blocked says why.
+func removePolicyRuleReference(rule *policysetcontroller.PolicyRule, reference objectReference) (change, blocked string) {
+	var changes []string
+	removed := 0
+	conditions := []policysetcontroller.Conditions{}
+	for _, condition := range rule.Conditions {
+		operands := []policysetcontroller.Operands{}
+		for _, operand := range condition.Operands {
+			if r, ok := policyOperandReference(operand); ok && r == reference {
+				removed++
+				continue
+			}
+			operands = append(operands, operand)
+		}
+		if len(operands) == 0 && len(condition.Operands) > 0 {
+			return "", "removing the operands would leave a condition without operands, which widens what the rule matches"
+		}
+		condition.Operands = operands
+		conditions = append(conditions, condition)
+	}
+	if removed > 0 {
+		rule.Conditions = conditions
+		changes = append(changes, fmt.Sprintf("removed %s", pluralize(removed, "operand")))
+	}
+	if reference.kind == "server group" {
+		groups := []policysetcontroller.AppServerGroups{}
+		for _, group := range rule.AppServerGroups {
+			if group.ID != reference.id {
+				groups = append(groups, group)
+			}
+		}
+		if len(groups) < len(rule.AppServerGroups) {
+			rule.AppServerGroups = groups
+			changes = append(changes, "removed it from the app server groups")
+		}
+	}
+	if reference.kind == "app connector group" {
+		groups := []policysetcontroller.AppConnectorGroups{}
+		for _, group := range rule.AppConnectorGroups {
+			if group.ID != reference.id {
+				groups = append(groups, group)
+			}
+		}
+		if len(groups) < len(rule.AppConnectorGroups) {
+			rule.AppConnectorGroups = groups
+			changes = append(changes, "removed it from the app connector groups")
+		}
+	}
+	return strings.Join(changes, ", "), ""
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// findApplicationSegmentReferrers finds the application segments that list the server group, among the applications
+// of the server group, so that the other segments of the tenant are neither read nor updated.
+func findApplicationSegmentReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	serverGroup, _, err := zClient.servergroup.Get(reference.id)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]*applicationsegment.ApplicationSegmentResource, len(serverGroup.Applications))
+	errs := forEachConcurrently(len(serverGroup.Applications), func(i int) error {
+		segment, _, err := zClient.applicationsegment.Get(serverGroup.Applications[i].ID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		segments[i] = segment
+		return nil
+	})
+	var referrers []objectReferrer
+	for i, segment := range segments {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if segment == nil || !applicationSegmentHasServerGroup(*segment, reference.id) {
+			continue
+		}
+		segmentID := segment.ID
+		referrers = append(referrers, objectReferrer{
+			kind:   "application segment",
+			id:     segment.ID,
+			name:   segment.Name,
+			change: "removed it from the server groups",
+			detach: func() error {
+				defer lockObject("application segment", segmentID)()
+				segment, _, err := zClient.applicationsegment.Get(segmentID)
+				if err != nil {
+					return err
+				}
+				groups := []applicationsegment.AppServerGroups{}
+				for _, group := range segment.ServerGroups {
+					if group.ID != reference.id {
+						groups = append(groups, group)
+					}
+				}
+				segment.ServerGroups = groups
+				_, err = zClient.applicationsegment.Update(segmentID, *segment)
+				return err
+			},
+		})
+	}
+	return referrers, nil
+}
+
+func applicationSegmentHasServerGroup(segment applicationsegment.ApplicationSegmentResource, serverGroupID string) bool {
+	for _, group := range segment.ServerGroups {
+		if group.ID == serverGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+// findAppConnectorGroupReferrers finds the app connector groups that list the server group, among the app connector
+// groups of the server group.
+func findAppConnectorGroupReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	serverGroup, _, err := zClient.servergroup.Get(reference.id)
+	if err != nil {
+		return nil, err
+	}
+	var referrers []objectReferrer
+	for _, group := range serverGroup.AppConnectorGroups {
+		appConnectorGroup, _, err := zClient.appconnectorgroup.Get(group.ID)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !appConnectorGroupHasServerGroup(appConnectorGroup.AppServerGroup, reference.id) {
+			continue
+		}
+		groupID := appConnectorGroup.ID
+		referrers = append(referrers, objectReferrer{
+			kind:   "app connector group",
+			id:     appConnectorGroup.ID,
+			name:   appConnectorGroup.Name,
+			change: "removed it from the server groups",
+			detach: func() error {
+				defer lockObject("app connector group", groupID)()
+				appConnectorGroup, _, err := zClient.appconnectorgroup.Get(groupID)
+				if err != nil {
+					return err
+				}
+				groups := []appconnectorgroup.AppServerGroup{}
+				for _, group := range appConnectorGroup.AppServerGroup {
+					if group.ID != reference.id {
+						groups = append(groups, group)
+					}
+				}
+				appConnectorGroup.AppServerGroup = groups
+				_, err = zClient.appconnectorgroup.Update(groupID, appConnectorGroup)
+				return err
+			},
+		})
+	}
+	return referrers, nil
+}
+
+func appConnectorGroupHasServerGroup(groups []appconnectorgroup.AppServerGroup, serverGroupID string) bool {
+	for _, group := range groups {
+		if group.ID == serverGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+// findServerGroupReferrers finds the server groups that list the app connector group.
+func findServerGroupReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	serverGroups, _, err := zClient.servergroup.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var referrers []objectReferrer
+	for _, serverGroup := range serverGroups {
+		if !serverGroupHasAppConnectorGroup(serverGroup, reference.id) {
+			continue
+		}
+		serverGroupID := serverGroup.ID
+		referrers = append(referrers, objectReferrer{
+			kind:   "server group",
+			id:     serverGroup.ID,
+			name:   serverGroup.Name,
+			change: "removed it from the app connector groups",
+			detach: func() error {
+				defer lockObject("server group", serverGroupID)()
+				serverGroup, _, err := zClient.servergroup.Get(serverGroupID)
+				if err != nil {
+					return err
+				}
+				groups := []servergroup.AppConnectorGroups{}
+				for _, group := range serverGroup.AppConnectorGroups {
+					if group.ID != reference.id {
+						groups = append(groups, group)
+					}
+				}
+				serverGroup.AppConnectorGroups = groups
+				_, err = zClient.servergroup.Update(serverGroupID, serverGroup)
+				return err
+			},
+		})
+	}
+	return referrers, nil
+}
+
+func serverGroupHasAppConnectorGroup(serverGroup servergroup.ServerGroup, appConnectorGroupID string) bool {
+	for _, group := range serverGroup.AppConnectorGroups {
+		if group.ID == appConnectorGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+// findServerGroupApplicationReferrers finds the server groups whose applications list the application segment,
+// among the server groups of the application segment.
+func findServerGroupApplicationReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	segment, _, err := zClient.applicationsegment.Get(reference.id)
+	if err != nil {
+		return nil, err
+	}
+	var referrers []objectReferrer
+	for _, group := range segment.ServerGroups {
+		serverGroup, _, err := zClient.servergroup.Get(group.ID)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !serverGroupHasApplication(*serverGroup, reference.id) {
+			continue
+		}
+		serverGroupID := serverGroup.ID
+		referrers = append(referrers, objectReferrer{
+			kind:   "server group",
+			id:     serverGroup.ID,
+			name:   serverGroup.Name,
+			change: "removed it from the applications",
+			detach: func() error {
+				defer lockObject("server group", serverGroupID)()
+				serverGroup, _, err := zClient.servergroup.Get(serverGroupID)
+				if err != nil {
+					return err
+				}
+				applications := []servergroup.Applications{}
+				for _, application := range serverGroup.Applications {
+					if application.ID != reference.id {
+						applications = append(applications, application)
+					}
+				}
+				serverGroup.Applications = applications
+				_, err = zClient.servergroup.Update(serverGroupID, serverGroup)
+				return err
+			},
+		})
+	}
+	return referrers, nil
+}
+
+func serverGroupHasApplication(serverGroup servergroup.ServerGroup, applicationID string) bool {
+	for _, application := range serverGroup.Applications {
+		if application.ID == applicationID {
+			return true
+		}
+	}
+	return false
+}
+
+func findLSSConfigReferrers(zClient *Client, reference objectReference) ([]objectReferrer, error) {
+	configs, _, err := zClient.lssconfigcontroller.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var referrers []objectReferrer
+	for _, config := range configs {
+		_, change, blocked := removeLSSConfigReference(config, reference)
+		if change == "" && blocked == "" {
+			continue
+		}
+		configID, name := config.ID, config.ID
+		if config.LSSConfig != nil {
+			name = config.LSSConfig.Name
+		}
+		if blocked != "" {
+			referrers = append(referrers, objectReferrer{kind: "LSS config", id: config.ID, name: name, blocked: blocked})
+			continue
+		}
+		referrers = append(referrers, objectReferrer{
+			kind:   "LSS config",
+			id:     config.ID,
+			name:   name,
+			change: change,
+			detach: func() error {
+				defer lockObject("LSS config", configID)()
+				config, _, err := zClient.lssconfigcontroller.Get(configID)
+				if err != nil {
+					return err
+				}
+				req, change, blocked := removeLSSConfigReference(*config, reference)
+				if blocked != "" {
+					return fmt.Errorf("the LSS config changed since it was read, %s", blocked)
+				}
+				if change == "" {
+					return nil
+				}
+				_, err = zClient.lssconfigcontroller.Update(configID, &req)
+				return err
+			},
+		})
+	}
+	return referrers, nil
+}
+
+// removeLSSConfigReference returns the update of the LSS config that removes its references to the object, and
+// describes the change. The description is empty when the config doesn't refer to the object. Like for the policy
+// rules, a condition left without operands would stream the logs of every application, and a config left without
+// connector groups would stream none, so the config is left unchanged then, and blocked says why.
+func removeLSSConfigReference(config lssconfigcontroller.LSSResource, reference objectReference) (req lssconfigcontroller.LSSResource, change, blocked string) {
+	var changes []string
+	req = lssconfigcontroller.LSSResource{
+		ID:                 config.ID,
+		LSSConfig:          config.LSSConfig,
+		PolicyRuleResource: lssPolicyRuleResource(config),
+	}
+	for _, group := range config.ConnectorGroups {
+		if reference.kind == "app connector group" && group.ID == reference.id {
+			changes = append(changes, "removed it from the connector groups")
+			continue
+		}
+		req.ConnectorGroups = append(req.ConnectorGroups, group)
+	}
+	if len(req.ConnectorGroups) == 0 && len(config.ConnectorGroups) > 0 {
+		return config, "", "removing it would leave the config without connector groups, which stops its log streaming"
+	}
+	if req.PolicyRuleResource != nil {
+		removed := 0
+		conditions := []lssconfigcontroller.PolicyRuleResourceConditions{}
+		for _, condition := range req.PolicyRuleResource.Conditions {
+			operands := []lssconfigcontroller.PolicyRuleResourceOperands{}
+			if condition.Operands != nil {
+				for _, operand := range *condition.Operands {
+					values := []string{}
+					for _, value := range operand.Values {
+						if lssOperandKinds[operand.ObjectType] == reference.kind && value == reference.id {
+							removed++
+							continue
+						}
+						values = append(values, value)
+					}
+					if len(values) > 0 {
+						operand.Values = values
+						operands = append(operands, operand)
+					}
+				}
+			}
+			if len(operands) == 0 && condition.Operands != nil && len(*condition.Operands) > 0 {
+				return config, "", "removing the operand values would leave a condition of the policy rule without operands, which widens the logs the config streams"
+			}
+			if len(operands) > 0 {
+				condition.Operands = &operands
+				conditions = append(conditions, condition)
+			}
+		}
+		if removed > 0 {
+			req.PolicyRuleResource.Conditions = conditions
+			changes = append(changes, fmt.Sprintf("removed %s from the policy rule", pluralize(removed, "operand value")))
+		}
+	}
+	return req, strings.Join(changes, ", "), ""
+}
+
+// lssPolicyRuleResource returns the policy rule of the LSS config in the shape it is updated with. The API returns
+// the policy rule with an operand per value, they are grouped back by object type.
+func lssPolicyRuleResource(config lssconfigcontroller.LSSResource) *lssconfigcontroller.PolicyRuleResource {
+	if config.PolicyRuleResource != nil {
+		rule := *config.PolicyRuleResource
+		return &rule
+	}
+	if config.PolicyRule == nil {
+		return nil
+	}
+	rule := config.PolicyRule
+	resource := &lssconfigcontroller.PolicyRuleResource{
+		Action:            rule.Action,
+		ActionID:          rule.ActionID,
+		CustomMsg:         rule.CustomMsg,
+		DefaultRule:       rule.DefaultRule,
+		Description:       rule.Description,
+		ID:                rule.ID,
+		Name:              rule.Name,
+		Operator:          rule.Operator,
+		PolicySetID:       rule.PolicySetID,
+		PolicyType:        rule.PolicyType,
+		Priority:          rule.Priority,
+		ReauthDefaultRule: rule.ReauthDefaultRule,
+		ReauthIdleTimeout: rule.ReauthIdleTimeout,
+		ReauthTimeout:     rule.ReauthTimeout,
+		RuleOrder:         rule.RuleOrder,
+		LssDefaultRule:    rule.LssDefaultRule,
+	}
+	for _, condition := range rule.Conditions {
+		operands := []lssconfigcontroller.PolicyRuleResourceOperands{}
+		if condition.Operands != nil {
+			index := map[string]int{}
+			for _, operand := range *condition.Operands {
+				i, ok := index[operand.ObjectType]
+				if !ok {
+					i = len(operands)
+					index[operand.ObjectType] = i
+					operands = append(operands, lssconfigcontroller.PolicyRuleResourceOperands{ObjectType: operand.ObjectType})
+				}
+				operands[i].Values = append(operands[i].Values, operand.RHS)
+			}
+		}
+		resource.Conditions = append(resource.Conditions, lssconfigcontroller.PolicyRuleResourceConditions{
+			Negated:  condition.Negated,
+			Operator: condition.Operator,
+			Operands: &operands,
+		})
+	}
+	return resource
+}
@@ -0,0 +1,545 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
+)
+
+func TestAccDeleteReferencedObject_SegmentGroup(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	var segmentGroupID, ruleID, policySetID string
+	// the test framework writes the provider block, the setting is read from the environment
+	t.Setenv("ZPA_ON_DELETE_REFERENCES", onDeleteReferencesFail)
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDeleteReferencedObjectConfigure(rName, true),
+				Check: func(s *terraform.State) error {
+					// the rule is created outside of terraform, like the rules of another workspace
+					zClient := provider.Meta().(*Client)
+					segmentGroupID = s.RootModule().Resources[resourcetype.ZPASegmentGroup+".this"].Primary.ID
+					policySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+					if err != nil {
+						return err
+					}
+					rule, _, err := zClient.policysetcontroller.Create(&policysetcontroller.PolicyRule{
+						Name:        rName + "-rule",
+						Action:      "ALLOW",
+						Operator:    "AND",
+						PolicySetID: policySet.ID,
+						Conditions: []policysetcontroller.Conditions{{
+							Operator: "OR",
+							Operands: []policysetcontroller.Operands{{ObjectType: "APP_GROUP", LHS: "id", RHS: segmentGroupID}},
+						}},
+					})
+					if err != nil {
+						return err
+					}
+					ruleID, policySetID = rule.ID, policySet.ID
+					return nil
+				},
+			},
+			{
+				Config:      testAccCheckDeleteReferencedObjectConfigure(rName, false),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`(?s)referred to by:.*ACCESS_POLICY rule "%s-rule"`, rName)),
+			},
+			// the rule only matches the segment group, detaching it would make the rule match every application
+			{
+				PreConfig:   func() { t.Setenv("ZPA_ON_DELETE_REFERENCES", onDeleteReferencesDetach) },
+				Config:      testAccCheckDeleteReferencedObjectConfigure(rName, false),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`(?s)can't be detached from every object.*ACCESS_POLICY rule "%s-rule".*widens`, rName)),
+			},
+			{
+				PreConfig: func() {
+					zClient := provider.Meta().(*Client)
+					rule, _, err := zClient.policysetcontroller.GetPolicyRule(policySetID, ruleID)
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if len(rule.Conditions) != 1 || len(rule.Conditions[0].Operands) != 1 {
+						t.Fatalf("expected rule %s to be left unchanged, got %+v", ruleID, rule.Conditions)
+					}
+					if _, err := zClient.policysetcontroller.Delete(policySetID, ruleID); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				},
+				Config: testAccCheckDeleteReferencedObjectConfigure(rName, false),
+				Check: func(s *terraform.State) error {
+					zClient := provider.Meta().(*Client)
+					if _, _, err := zClient.segmentgroup.Get(segmentGroupID); err == nil {
+						return fmt.Errorf("segment group %s wasn't deleted", segmentGroupID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckDeleteReferencedObjectConfigure(rName string, segmentGroup bool) string {
+	if !segmentGroup {
+		return `
+data "zpa_policy_type" "access_policy" {
+	policy_type = "ACCESS_POLICY"
+}
+`
+	}
+	return fmt.Sprintf(`
+resource "%s" "this" {
+	name    = "%s"
+	enabled = true
+}
+`, resourcetype.ZPASegmentGroup, rName)
+}
+
+func TestAccDeleteReferencedObject_ApplicationSegment(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	var appSegmentID, ruleID, policySetID, serverGroupID string
+	t.Setenv("ZPA_ON_DELETE_REFERENCES", onDeleteReferencesDetach)
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDeleteReferencedApplicationSegmentConfigure(rName, true),
+				Check: func(s *terraform.State) error {
+					zClient := provider.Meta().(*Client)
+					appSegmentID = s.RootModule().Resources[resourcetype.ZPAApplicationSegment+".this"].Primary.ID
+					policySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+					if err != nil {
+						return err
+					}
+					rule, _, err := zClient.policysetcontroller.Create(&policysetcontroller.PolicyRule{
+						Name:        rName + "-rule",
+						Action:      "ALLOW",
+						Operator:    "AND",
+						PolicySetID: policySet.ID,
+						Conditions: []policysetcontroller.Conditions{{
+							Operator: "OR",
+							Operands: []policysetcontroller.Operands{
+								{ObjectType: "APP", LHS: "id", RHS: appSegmentID},
+								{ObjectType: "APP_GROUP", LHS: "id", RHS: s.RootModule().Resources[resourcetype.ZPASegmentGroup+".this"].Primary.ID},
+							},
+						}},
+					})
+					if err != nil {
+						return err
+					}
+					ruleID, policySetID = rule.ID, policySet.ID
+					serverGroupID = s.RootModule().Resources[resourcetype.ZPAServerGroup+".this"].Primary.ID
+					return nil
+				},
+			},
+			{
+				Config: testAccCheckDeleteReferencedApplicationSegmentConfigure(rName, false),
+				Check: func(s *terraform.State) error {
+					zClient := provider.Meta().(*Client)
+					rule, _, err := zClient.policysetcontroller.GetPolicyRule(policySetID, ruleID)
+					if err != nil {
+						return err
+					}
+					if len(rule.Conditions) != 1 || len(rule.Conditions[0].Operands) != 1 || rule.Conditions[0].Operands[0].ObjectType != "APP_GROUP" {
+						return fmt.Errorf("expected only the APP_GROUP operand left in rule %s, got %+v", ruleID, rule.Conditions)
+					}
+					serverGroup, _, err := zClient.servergroup.Get(serverGroupID)
+					if err != nil {
+						return err
+					}
+					if len(serverGroup.Applications) != 0 {
+						return fmt.Errorf("server group %s still lists the applications %+v", serverGroupID, serverGroup.Applications)
+					}
+					// the rule is left with the segment group alone, which can't be detached from it on destroy
+					_, err = zClient.policysetcontroller.Delete(policySetID, ruleID)
+					return err
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckDeleteReferencedApplicationSegmentConfigure(rName string, appSegment bool) string {
+	config := fmt.Sprintf(`
+resource "%s" "this" {
+	name    = "%s"
+	enabled = true
+}
+
+resource "%s" "this" {
+	name              = "%s"
+	enabled           = true
+	dynamic_discovery = true
+	app_connector_groups {
+		id = []
+	}
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAServerGroup, rName)
+	if appSegment {
+		config += fmt.Sprintf(`
+resource "%s" "this" {
+	name             = "%s"
+	enabled          = true
+	health_reporting = "ON_ACCESS"
+	bypass_type      = "NEVER"
+	tcp_port_ranges  = ["8080", "8080"]
+	domain_names     = ["test.example.com"]
+	segment_group_id = %s.this.id
+	server_groups {
+		id = [%s.this.id]
+	}
+}
+`, resourcetype.ZPAApplicationSegment, rName, resourcetype.ZPASegmentGroup, resourcetype.ZPAServerGroup)
+	}
+	return config
+}
+
+func TestRemovePolicyRuleReference(t *testing.T) {
+	rule := policysetcontroller.PolicyRule{
+		Conditions: []policysetcontroller.Conditions{
+			{
+				Operator: "OR",
+				Operands: []policysetcontroller.Operands{
+					{ObjectType: "APP_GROUP", LHS: "id", RHS: "1"},
+					{ObjectType: "APP_GROUP", LHS: "id", RHS: "2"},
+				},
+			},
+			{
+				Operator: "OR",
+				Operands: []policysetcontroller.Operands{{ObjectType: "APP_GROUP", LHS: "id", RHS: "1"}},
+			},
+			{
+				Operator: "OR",
+				Operands: []policysetcontroller.Operands{{ObjectType: "APP", LHS: "id", RHS: "1"}},
+			},
+		},
+		AppServerGroups: []policysetcontroller.AppServerGroups{{ID: "1"}, {ID: "3"}},
+	}
+
+	if change, blocked := removePolicyRuleReference(&rule, objectReference{"segment group", "3"}); change != "" || blocked != "" {
+		t.Errorf("unexpected change %q for a segment group the rule doesn't refer to", change)
+	}
+	// the second condition would be left without operands, the rule would no longer require it
+	unchanged := append([]policysetcontroller.Conditions{}, rule.Conditions...)
+	change, blocked := removePolicyRuleReference(&rule, objectReference{"segment group", "1"})
+	if change != "" || blocked == "" {
+		t.Errorf("got change %q, want the rule to be blocked", change)
+	}
+	if !reflect.DeepEqual(rule.Conditions, unchanged) {
+		t.Errorf("got conditions %+v, want the rule unchanged", rule.Conditions)
+	}
+
+	rule.Conditions = rule.Conditions[:1]
+	change, blocked = removePolicyRuleReference(&rule, objectReference{"segment group", "1"})
+	if want := "removed 1 operand"; change != want || blocked != "" {
+		t.Errorf("got change %q, blocked %q, want %q", change, blocked, want)
+	}
+	want := []policysetcontroller.Conditions{
+		{
+			Operator: "OR",
+			Operands: []policysetcontroller.Operands{{ObjectType: "APP_GROUP", LHS: "id", RHS: "2"}},
+		},
+	}
+	if !reflect.DeepEqual(rule.Conditions, want) {
+		t.Errorf("got conditions %+v, want %+v", rule.Conditions, want)
+	}
+
+	change, _ = removePolicyRuleReference(&rule, objectReference{"server group", "3"})
+	if want := "removed it from the app server groups"; change != want {
+		t.Errorf("got change %q, want %q", change, want)
+	}
+	if want := []policysetcontroller.AppServerGroups{{ID: "1"}}; !reflect.DeepEqual(rule.AppServerGroups, want) {
+		t.Errorf("got app server groups %+v, want %+v", rule.AppServerGroups, want)
+	}
+}
+
+func TestRemoveLSSConfigReference(t *testing.T) {
+	config := lssconfigcontroller.LSSResource{
+		ID:              "10",
+		LSSConfig:       &lssconfigcontroller.LSSConfig{Name: "config"},
+		ConnectorGroups: []lssconfigcontroller.ConnectorGroups{{ID: "5"}, {ID: "6"}},
+		PolicyRule: &lssconfigcontroller.PolicyRule{
+			ID:       "20",
+			Operator: "AND",
+			Conditions: []lssconfigcontroller.Conditions{
+				{
+					Operator: "OR",
+					Operands: &[]lssconfigcontroller.Operands{
+						{ObjectType: "APP", RHS: "1"},
+						{ObjectType: "APP", RHS: "2"},
+						{ObjectType: "APP_GROUP", RHS: "1"},
+					},
+				},
+			},
+		},
+	}
+
+	if _, change, blocked := removeLSSConfigReference(config, objectReference{"app connector group", "7"}); change != "" || blocked != "" {
+		t.Errorf("unexpected change %q (%q) for an app connector group the config doesn't refer to", change, blocked)
+	}
+	req, change, _ := removeLSSConfigReference(config, objectReference{"app connector group", "5"})
+	if want := "removed it from the connector groups"; change != want {
+		t.Errorf("got change %q, want %q", change, want)
+	}
+	if want := []lssconfigcontroller.ConnectorGroups{{ID: "6"}}; !reflect.DeepEqual(req.ConnectorGroups, want) {
+		t.Errorf("got connector groups %+v, want %+v", req.ConnectorGroups, want)
+	}
+
+	req, change, _ = removeLSSConfigReference(config, objectReference{"application segment", "1"})
+	if want := "removed 1 operand value from the policy rule"; change != want {
+		t.Errorf("got change %q, want %q", change, want)
+	}
+	want := []lssconfigcontroller.PolicyRuleResourceOperands{
+		{ObjectType: "APP", Values: []string{"2"}},
+		{ObjectType: "APP_GROUP", Values: []string{"1"}},
+	}
+	if len(req.PolicyRuleResource.Conditions) != 1 || !reflect.DeepEqual(*req.PolicyRuleResource.Conditions[0].Operands, want) {
+		t.Errorf("got conditions %+v, want one condition with the operands %+v", req.PolicyRuleResource.Conditions, want)
+	}
+
+	single := lssconfigcontroller.LSSResource{
+		ID:              "11",
+		LSSConfig:       &lssconfigcontroller.LSSConfig{Name: "single"},
+		ConnectorGroups: []lssconfigcontroller.ConnectorGroups{{ID: "5"}},
+		PolicyRule: &lssconfigcontroller.PolicyRule{
+			ID: "21",
+			Conditions: []lssconfigcontroller.Conditions{
+				{Operands: &[]lssconfigcontroller.Operands{{ObjectType: "APP", RHS: "1"}}},
+			},
+		},
+	}
+	for _, reference := range []objectReference{{"application segment", "1"}, {"app connector group", "5"}} {
+		if _, change, blocked := removeLSSConfigReference(single, reference); change != "" || blocked == "" {
+			t.Errorf("%s: got change %q, want the detach blocked since it would leave the config without its last %s", reference, change, reference.kind)
+		}
+	}
+}
+
+// countingApplicationSegments counts the calls made to the application segments of the tenant.
+type countingApplicationSegments struct {
+	applicationSegmentService
+	gets, updates, lists int32
+}
+
+func (c *countingApplicationSegments) Get(id string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	atomic.AddInt32(&c.gets, 1)
+	return c.applicationSegmentService.Get(id)
+}
+
+func (c *countingApplicationSegments) Update(id string, v applicationsegment.ApplicationSegmentResource) (*http.Response, error) {
+	atomic.AddInt32(&c.updates, 1)
+	return c.applicationSegmentService.Update(id, v)
+}
+
+func (c *countingApplicationSegments) GetAll() ([]applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	atomic.AddInt32(&c.lists, 1)
+	return c.applicationSegmentService.GetAll()
+}
+
+func TestDeleteServerGroupDetachesOnlyReferrers(t *testing.T) {
+	zClient := newFakeClient()
+	zClient.onDeleteReferences = onDeleteReferencesDetach
+	serverGroup, _, err := zClient.servergroup.Create(&servergroup.ServerGroup{Name: "server group"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other, _, _ := zClient.servergroup.Create(&servergroup.ServerGroup{Name: "other"})
+	var referring []string
+	for i := 0; i < 10; i++ {
+		groups := []applicationsegment.AppServerGroups{{ID: other.ID}}
+		if i%3 == 0 {
+			groups = append(groups, applicationsegment.AppServerGroups{ID: serverGroup.ID})
+		}
+		segment, _, err := zClient.applicationsegment.Create(applicationsegment.ApplicationSegmentResource{Name: fmt.Sprintf("segment %d", i), ServerGroups: groups})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if i%3 == 0 {
+			referring = append(referring, segment.ID)
+		}
+	}
+	segments := &countingApplicationSegments{applicationSegmentService: zClient.applicationsegment}
+	zClient.applicationsegment = segments
+
+	diags := deleteReferencedObject(zClient, objectReference{"server group", serverGroup.ID}, func() error {
+		_, err := zClient.servergroup.Delete(serverGroup.ID)
+		return err
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if want := fmt.Sprintf("Detached the server group %s from 4 objects", serverGroup.ID); len(diags) != 1 || diags[0].Summary != want {
+		t.Errorf("got %+v, want a warning %q", diags, want)
+	}
+	// every referring segment is read once to be found and once to be updated
+	if segments.lists != 0 || segments.gets != 8 || segments.updates != 4 {
+		t.Errorf("got %d lists, %d gets and %d updates of application segments, want 0, 8 and 4", segments.lists, segments.gets, segments.updates)
+	}
+	for _, id := range referring {
+		segment, _, _ := zClient.applicationsegment.Get(id)
+		if applicationSegmentHasServerGroup(*segment, serverGroup.ID) || !applicationSegmentHasServerGroup(*segment, other.ID) {
+			t.Errorf("expected segment %s to keep only the other server group, got %+v", id, segment.ServerGroups)
+		}
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	var running, maxRunning int32
+	errs := forEachConcurrently(20, func(i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if i == 7 {
+			return fmt.Errorf("failed %d", i)
+		}
+		return nil
+	})
+	if maxRunning > detachConcurrency {
+		t.Errorf("got %d calls at a time, want at most %d", maxRunning, detachConcurrency)
+	}
+	for i, err := range errs {
+		if (err != nil) != (i == 7) {
+			t.Errorf("got error %v for index %d", err, i)
+		}
+	}
+}
+
+// slowPolicyRules delays the reads of the policy rules, so that detaches running in parallel read the same rule
+// before either of them updates it.
+type slowPolicyRules struct {
+	extendedPolicyRuleService
+}
+
+func (s *slowPolicyRules) GetPolicyRule(policySetID, ruleID string) (*extendedPolicyRule, *http.Response, error) {
+	rule, resp, err := s.extendedPolicyRuleService.GetPolicyRule(policySetID, ruleID)
+	time.Sleep(20 * time.Millisecond)
+	return rule, resp, err
+}
+
+func TestDeleteReferencedObjectsDetachPolicyRuleInParallel(t *testing.T) {
+	zClient := newFakeClient()
+	zClient.onDeleteReferences = onDeleteReferencesDetach
+	var segmentIDs []string
+	for i := 0; i < 2; i++ {
+		segment, _, err := zClient.applicationsegment.Create(applicationsegment.ApplicationSegmentResource{Name: fmt.Sprintf("segment %d", i)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		segmentIDs = append(segmentIDs, segment.ID)
+	}
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rule, _, err := zClient.policysetcontroller.Create(&policysetcontroller.PolicyRule{
+		Name:        "rule",
+		Action:      "ALLOW",
+		Operator:    "AND",
+		PolicySetID: policySet.ID,
+		Conditions: []policysetcontroller.Conditions{{
+			Operator: "OR",
+			Operands: []policysetcontroller.Operands{
+				{ObjectType: "APP", LHS: "id", RHS: segmentIDs[0]},
+				{ObjectType: "APP", LHS: "id", RHS: segmentIDs[1]},
+				{ObjectType: "APP_GROUP", LHS: "id", RHS: "1"},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zClient.extendedpolicyrule = &slowPolicyRules{extendedPolicyRuleService: zClient.extendedpolicyrule}
+
+	// Terraform deletes both segments at once
+	errs := forEachConcurrently(len(segmentIDs), func(i int) error {
+		diags := deleteReferencedObject(zClient, objectReference{"application segment", segmentIDs[i]}, func() error { return nil })
+		if diags.HasError() {
+			return fmt.Errorf("%v", diags)
+		}
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	rule, _, err = zClient.policysetcontroller.GetPolicyRule(policySet.ID, rule.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rule.Conditions) != 1 || len(rule.Conditions[0].Operands) != 1 || rule.Conditions[0].Operands[0].ObjectType != "APP_GROUP" {
+		t.Errorf("got conditions %+v, want only the APP_GROUP operand left", rule.Conditions)
+	}
+}
+
+// slowApplicationSegments delays the reads of the application segments, like slowPolicyRules.
+type slowApplicationSegments struct {
+	applicationSegmentService
+}
+
+func (s *slowApplicationSegments) Get(id string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error) {
+	segment, resp, err := s.applicationSegmentService.Get(id)
+	time.Sleep(20 * time.Millisecond)
+	return segment, resp, err
+}
+
+func TestDeleteServerGroupsDetachApplicationSegmentInParallel(t *testing.T) {
+	zClient := newFakeClient()
+	zClient.onDeleteReferences = onDeleteReferencesDetach
+	var groups []applicationsegment.AppServerGroups
+	for i := 0; i < 3; i++ {
+		serverGroup, _, err := zClient.servergroup.Create(&servergroup.ServerGroup{Name: fmt.Sprintf("server group %d", i)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		groups = append(groups, applicationsegment.AppServerGroups{ID: serverGroup.ID})
+	}
+	segment, _, err := zClient.applicationsegment.Create(applicationsegment.ApplicationSegmentResource{Name: "segment", ServerGroups: groups})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zClient.applicationsegment = &slowApplicationSegments{applicationSegmentService: zClient.applicationsegment}
+
+	// Terraform deletes the first two server groups at once
+	errs := forEachConcurrently(2, func(i int) error {
+		diags := deleteReferencedObject(zClient, objectReference{"server group", groups[i].ID}, func() error { return nil })
+		if diags.HasError() {
+			return fmt.Errorf("%v", diags)
+		}
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	segment, _, err = zClient.applicationsegment.Get(segment.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(segment.ServerGroups) != 1 || segment.ServerGroups[0].ID != groups[2].ID {
+		t.Errorf("got server groups %+v, want only the server group %s left", segment.ServerGroups, groups[2].ID)
+	}
+}
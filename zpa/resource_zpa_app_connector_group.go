@@ -1,26 +1,27 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
-	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
 func resourceAppConnectorGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAppConnectorGroupCreate,
-		Read:   resourceAppConnectorGroupRead,
-		Update: resourceAppConnectorGroupUpdate,
-		Delete: resourceAppConnectorGroupDelete,
+		CreateContext: resourceAppConnectorGroupCreate,
+		ReadContext:   resourceAppConnectorGroupRead,
+		UpdateContext: resourceAppConnectorGroupUpdate,
+		DeleteContext: resourceAppConnectorGroupDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -175,41 +176,41 @@ func resourceAppConnectorGroup() *schema.Resource {
 	}
 }
 
-func resourceAppConnectorGroupCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceAppConnectorGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	if err := validateAndSetProfileNameID(d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	req := expandAppConnectorGroup(d)
 	log.Printf("[INFO] Creating zpa app connector group with request\n%+v\n", req)
 
 	if err := validateTCPQuickAck(req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	resp, _, err := zClient.appconnectorgroup.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "app connector group", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created app connector group request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceAppConnectorGroupRead(d, m)
+	return resourceAppConnectorGroupRead(ctx, d, m)
 }
 
-func resourceAppConnectorGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceAppConnectorGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.appconnectorgroup.Get(d.Id())
 	if err != nil {
-		if err.(*client.ErrorResponse).IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing app connector group %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting application server:\n%+v\n", resp)
@@ -237,74 +238,48 @@ func resourceAppConnectorGroupRead(d *schema.ResourceData, m interface{}) error
 
 }
 
-func resourceAppConnectorGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceAppConnectorGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	if err := validateAndSetProfileNameID(d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	id := d.Id()
 	log.Printf("[INFO] Updating app connector group ID: %v\n", id)
 	req := expandAppConnectorGroup(d)
 
 	if err := validateTCPQuickAck(req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if _, _, err := zClient.appconnectorgroup.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "app connector group")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.appconnectorgroup.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "app connector group", d.Get("name").(string)))
 	}
 
-	return resourceAppConnectorGroupRead(d, m)
+	return resourceAppConnectorGroupRead(ctx, d, m)
 }
 
-func detachAppConnectorGroupFromAllAccessPolicyRules(id string, zClient *Client) {
-	accessPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
-	if err != nil {
-		return
-	}
-	rules, _, err := zClient.policysetcontroller.GetAllByType("ACCESS_POLICY")
-	if err != nil {
-		return
-	}
-	for _, rule := range rules {
-		ids := []policysetcontroller.AppConnectorGroups{}
-		for _, app := range rule.AppConnectorGroups {
-			if app.ID == id {
-				continue
-			}
-			ids = append(ids, policysetcontroller.AppConnectorGroups{
-				ID: app.ID,
-			})
-		}
-		rule.AppConnectorGroups = ids
-		if _, err := zClient.policysetcontroller.Update(accessPolicySet.ID, rule.ID, &rule); err != nil {
-			continue
-		}
-	}
-}
-
-func resourceAppConnectorGroupDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceAppConnectorGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting app connector groupID: %v\n", d.Id())
-
-	// detach app connector group from all access policy rules
-	detachAppConnectorGroupFromAllAccessPolicyRules(d.Id(), zClient)
-
-	if _, err := zClient.appconnectorgroup.Delete(d.Id()); err != nil {
+	diags := deleteReferencedObject(zClient, objectReference{"app connector group", d.Id()}, func() error {
+		_, err := zClient.appconnectorgroup.Delete(d.Id())
 		return err
+	})
+	if diags.HasError() {
+		return diags
 	}
 	d.SetId("")
 	log.Printf("[INFO] app connector group deleted")
-	return nil
+	return diags
 }
 
 func expandAppConnectorGroup(d *schema.ResourceData) appconnectorgroup.AppConnectorGroup {
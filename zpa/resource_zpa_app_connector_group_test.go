@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -17,15 +18,15 @@ func TestAccResourceAppConnectorGroupBasic(t *testing.T) {
 	var groups appconnectorgroup.AppConnectorGroup
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAppConnectorGroupDestroy,
+		CheckDestroy: testAccCheckAppConnectorGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckAppConnectorGroupConfigure(resourceTypeAndName, generatedName, variable.AppConnectorDescription, variable.AppConnectorEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAppConnectorGroupExists(resourceTypeAndName, &groups),
+					testAccCheckAppConnectorGroupExists(provider, resourceTypeAndName, &groups),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.AppConnectorDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.AppConnectorEnabled)),
@@ -40,7 +41,7 @@ func TestAccResourceAppConnectorGroupBasic(t *testing.T) {
 			{
 				Config: testAccCheckAppConnectorGroupConfigure(resourceTypeAndName, generatedName, variable.AppConnectorDescription, variable.AppConnectorEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAppConnectorGroupExists(resourceTypeAndName, &groups),
+					testAccCheckAppConnectorGroupExists(provider, resourceTypeAndName, &groups),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.AppConnectorDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.AppConnectorEnabled)),
@@ -54,29 +55,31 @@ func TestAccResourceAppConnectorGroupBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckAppConnectorGroupDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckAppConnectorGroupDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAAppConnectorGroup {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAAppConnectorGroup {
+				continue
+			}
 
-		rule, _, err := apiClient.appconnectorgroup.Get(rs.Primary.ID)
+			rule, _, err := apiClient.appconnectorgroup.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("app connector group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("app connector group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckAppConnectorGroupExists(resource string, rule *appconnectorgroup.AppConnectorGroup) resource.TestCheckFunc {
+func testAccCheckAppConnectorGroupExists(provider *schema.Provider, resource string, rule *appconnectorgroup.AppConnectorGroup) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -86,7 +89,7 @@ func testAccCheckAppConnectorGroupExists(resource string, rule *appconnectorgrou
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedRule, _, err := apiClient.appconnectorgroup.Get(rs.Primary.ID)
 
 		if err != nil {
@@ -1,24 +1,26 @@
 package zpa
 
 import (
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/appservercontroller"
 )
 
 func resourceApplicationServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationServerCreate,
-		Read:   resourceApplicationServerRead,
-		Update: resourceApplicationServerUpdate,
-		Delete: resourceApplicationServerDelete,
+		CreateContext: resourceApplicationServerCreate,
+		ReadContext:   resourceApplicationServerRead,
+		UpdateContext: resourceApplicationServerUpdate,
+		DeleteContext: resourceApplicationServerDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -88,34 +90,34 @@ func resourceApplicationServer() *schema.Resource {
 	}
 }
 
-func resourceApplicationServerCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandCreateAppServerRequest(d)
 	log.Printf("[INFO] Creating zpa application server with request\n%+v\n", req)
 
 	resp, _, err := zClient.appservercontroller.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "application server", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created application server request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceApplicationServerRead(d, m)
+	return resourceApplicationServerRead(ctx, d, m)
 }
 
-func resourceApplicationServerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.appservercontroller.Get(d.Id())
 	if err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing application server %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting application server:\n%+v\n", resp)
@@ -129,8 +131,8 @@ func resourceApplicationServerRead(d *schema.ResourceData, m interface{}) error
 
 }
 
-func resourceApplicationServerUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Println("An updated occurred")
 
@@ -138,10 +140,10 @@ func resourceApplicationServerUpdate(d *schema.ResourceData, m interface{}) erro
 		log.Println("The AppServerGroupID, name, description or address has been changed")
 
 		if _, _, err := zClient.appservercontroller.Get(d.Id()); err != nil {
-			if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-				d.SetId("")
-				return nil
+			if isNotFoundError(err) {
+				return notFoundOnUpdate(d, "application server")
 			}
+			return diag.FromErr(err)
 		}
 
 		if _, err := zClient.appservercontroller.Update(d.Id(), appservercontroller.ApplicationServer{
@@ -151,29 +153,29 @@ func resourceApplicationServerUpdate(d *schema.ResourceData, m interface{}) erro
 			Address:           d.Get("address").(string),
 			Enabled:           d.Get("enabled").(bool),
 		}); err != nil {
-			return err
+			return diag.FromErr(describeAPIError(err, "application server", d.Get("name").(string)))
 		}
 	}
 
 	return nil
 }
 
-func resourceApplicationServerDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting application server ID: %v\n", d.Id())
 
 	err := removeServerFromGroup(zClient, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if _, err = zClient.appservercontroller.Delete(d.Id()); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -184,7 +186,7 @@ func removeServerFromGroup(zClient *Client, serverID string) error {
 
 	resp, _, err := zClient.appservercontroller.Get(serverID)
 	if err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			return nil
 		}
 		return err
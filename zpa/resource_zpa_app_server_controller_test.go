@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -17,15 +18,15 @@ func TestAccResourceApplicationServerBasic(t *testing.T) {
 	var servers appservercontroller.ApplicationServer
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAApplicationServer)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationServerDestroy,
+		CheckDestroy: testAccCheckApplicationServerDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationServerConfigure(resourceTypeAndName, generatedName, variable.AppServerDescription, variable.AppServerAddress, variable.AppServerEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationServerExists(resourceTypeAndName, &servers),
+					testAccCheckApplicationServerExists(provider, resourceTypeAndName, &servers),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.AppServerDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "address", variable.AppServerAddress),
@@ -37,7 +38,7 @@ func TestAccResourceApplicationServerBasic(t *testing.T) {
 			{
 				Config: testAccCheckApplicationServerConfigure(resourceTypeAndName, generatedName, variable.AppServerDescription, variable.AppServerAddress, variable.AppServerEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationServerExists(resourceTypeAndName, &servers),
+					testAccCheckApplicationServerExists(provider, resourceTypeAndName, &servers),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.AppServerDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "address", variable.AppServerAddress),
@@ -48,29 +49,31 @@ func TestAccResourceApplicationServerBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckApplicationServerDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckApplicationServerDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAApplicationServer {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAApplicationServer {
+				continue
+			}
 
-		rule, _, err := apiClient.appservercontroller.Get(rs.Primary.ID)
+			rule, _, err := apiClient.appservercontroller.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("application server with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("application server with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckApplicationServerExists(resource string, server *appservercontroller.ApplicationServer) resource.TestCheckFunc {
+func testAccCheckApplicationServerExists(provider *schema.Provider, resource string, server *appservercontroller.ApplicationServer) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -80,7 +83,7 @@ func testAccCheckApplicationServerExists(resource string, server *appservercontr
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedServer, _, err := apiClient.appservercontroller.Get(rs.Primary.ID)
 
 		if err != nil {
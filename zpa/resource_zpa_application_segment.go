@@ -1,26 +1,27 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
 )
 
 func resourceApplicationSegment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationSegmentCreate,
-		Read:   resourceApplicationSegmentRead,
-		Update: resourceApplicationSegmentUpdate,
-		Delete: resourceApplicationSegmentDelete,
+		CreateContext: resourceApplicationSegmentCreate,
+		ReadContext:   resourceApplicationSegmentRead,
+		UpdateContext: resourceApplicationSegmentUpdate,
+		DeleteContext: resourceApplicationSegmentDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -210,40 +211,40 @@ func resourceApplicationSegment() *schema.Resource {
 	}
 }
 
-func resourceApplicationSegmentCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandApplicationSegmentRequest(d, zClient, "")
 
 	log.Printf("[INFO] Creating application segment request\n%+v\n", req)
 	if req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provde a valid segment group for the application segment")
-		return fmt.Errorf("please provde a valid segment group for the application segment")
+		return diag.Errorf("please provde a valid segment group for the application segment")
 	}
 	resp, _, err := zClient.applicationsegment.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "application segment", d.Get("name").(string)))
 	}
 
 	log.Printf("[INFO] Created application segment request. ID: %v\n", resp.ID)
 	d.SetId(resp.ID)
 
-	return resourceApplicationSegmentRead(d, m)
+	return resourceApplicationSegmentRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.applicationsegment.Get(d.Id())
 
 	if err != nil {
-		if err.(*client.ErrorResponse).IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing application segment %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Reading application segment and settings states: %+v\n", resp)
@@ -273,11 +274,11 @@ func resourceApplicationSegmentRead(d *schema.ResourceData, m interface{}) error
 	_ = d.Set("server_groups", flattenAppServerGroupsSimple(resp))
 
 	if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("udp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -294,8 +295,8 @@ func flattenAppServerGroupsSimple(serverGroup *applicationsegment.ApplicationSeg
 	return result
 }
 
-func resourceApplicationSegmentUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating application segment ID: %v\n", id)
@@ -303,33 +304,32 @@ func resourceApplicationSegmentUpdate(d *schema.ResourceData, m interface{}) err
 
 	if d.HasChange("segment_group_id") && req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provide a valid segment group for the application segment")
-		return fmt.Errorf("please provide a valid segment group for the application segment")
+		return diag.Errorf("please provide a valid segment group for the application segment")
 	}
 
 	if _, _, err := zClient.applicationsegment.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "application segment")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.applicationsegment.Update(id, req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "application segment", d.Get("name").(string)))
 	}
 
-	return resourceApplicationSegmentRead(d, m)
+	return resourceApplicationSegmentRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	id := d.Id()
 	log.Printf("[INFO] Deleting application segment with id %v\n", id)
 
-	if _, err := zClient.applicationsegment.Delete(id); err != nil {
+	return deleteReferencedObject(zClient, objectReference{"application segment", id}, func() error {
+		_, err := zClient.applicationsegment.Delete(id)
 		return err
-	}
-
-	return nil
+	})
 }
 
 func expandApplicationSegmentRequest(d *schema.ResourceData, zClient *Client, id string) applicationsegment.ApplicationSegmentResource {
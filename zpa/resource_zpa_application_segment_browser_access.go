@@ -1,6 +1,7 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -8,9 +9,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/browseraccess"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
@@ -18,13 +19,14 @@ import (
 
 func resourceApplicationSegmentBrowserAccess() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationSegmentBrowserAccessCreate,
-		Read:   resourceApplicationSegmentBrowserAccessRead,
-		Update: resourceApplicationSegmentBrowserAccessUpdate,
-		Delete: resourceApplicationSegmentBrowserAccessDelete,
+		CreateContext: resourceApplicationSegmentBrowserAccessCreate,
+		ReadContext:   resourceApplicationSegmentBrowserAccessRead,
+		UpdateContext: resourceApplicationSegmentBrowserAccessUpdate,
+		DeleteContext: resourceApplicationSegmentBrowserAccessDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -277,42 +279,42 @@ func resourceApplicationSegmentBrowserAccess() *schema.Resource {
 	}
 }
 
-func resourceApplicationSegmentBrowserAccessCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentBrowserAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandBrowserAccess(d, zClient, "")
 	if err := checkForBrowserAccessPortsOverlap(zClient, req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Creating browser access request\n%+v\n", req)
 
 	if req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provide a valid segment group for the application segment")
-		return fmt.Errorf("please provde a valid segment group for the application segment")
+		return diag.Errorf("please provde a valid segment group for the application segment")
 	}
 
 	browseraccess, _, err := zClient.browseraccess.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "browser access", d.Get("name").(string)))
 	}
 
 	log.Printf("[INFO] Created browser access request. ID: %v\n", browseraccess.ID)
 	d.SetId(browseraccess.ID)
 
-	return resourceApplicationSegmentBrowserAccessRead(d, m)
+	return resourceApplicationSegmentBrowserAccessRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentBrowserAccessRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentBrowserAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.browseraccess.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing browser access %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting browser access:\n%+v\n", resp)
@@ -341,78 +343,75 @@ func resourceApplicationSegmentBrowserAccessRead(d *schema.ResourceData, m inter
 	_ = d.Set("udp_port_ranges", resp.UDPPortRanges)
 
 	if err := d.Set("clientless_apps", flattenBaClientlessApps(resp)); err != nil {
-		return fmt.Errorf("failed to read clientless apps %s", err)
+		return diag.Errorf("failed to read clientless apps %s", err)
 	}
 
 	if err := d.Set("server_groups", flattenClientlessAppServerGroups(resp.AppServerGroups)); err != nil {
-		return fmt.Errorf("failed to read app server groups %s", err)
+		return diag.Errorf("failed to read app server groups %s", err)
 	}
 
 	if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("udp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 
 }
 
-func resourceApplicationSegmentBrowserAccessUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentBrowserAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating browser access ID: %v\n", id)
 	req := expandBrowserAccess(d, zClient, "")
 
 	if err := checkForBrowserAccessPortsOverlap(zClient, req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("segment_group_id") && req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provide a valid segment group for the browser access application segment")
-		return fmt.Errorf("please provide a valid segment group for the browser access application segment")
+		return diag.Errorf("please provide a valid segment group for the browser access application segment")
 	}
 
 	if _, _, err := zClient.browseraccess.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "browser access")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.browseraccess.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "browser access", d.Get("name").(string)))
 	}
 
-	return resourceApplicationSegmentBrowserAccessRead(d, m)
+	return resourceApplicationSegmentBrowserAccessRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentBrowserAccessDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentBrowserAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	id := d.Id()
-	segmentGroupID, ok := d.GetOk("segment_group_id")
-	if ok && segmentGroupID != nil {
-		gID, ok := segmentGroupID.(string)
-		if ok && gID != "" {
+	segmentGroupID, _ := d.Get("segment_group_id").(string)
+	log.Printf("[INFO] Deleting browser access application with id %v\n", id)
+	return deleteReferencedObject(zClient, objectReference{"application segment", id}, func() error {
+		if segmentGroupID != "" {
 			// detach it from segment group first
-			if err := detachBrowserAccessFromGroup(zClient, id, gID); err != nil {
+			if err := detachBrowserAccessFromGroup(zClient, id, segmentGroupID); err != nil {
 				return err
 			}
 		}
-	}
-	log.Printf("[INFO] Deleting browser access application with id %v\n", id)
-	if _, err := zClient.browseraccess.Delete(id); err != nil {
+		_, err := zClient.browseraccess.Delete(id)
 		return err
-	}
-
-	return nil
+	})
 }
 
 func detachBrowserAccessFromGroup(client *Client, segmentID, segmentGroupID string) error {
 	log.Printf("[INFO] Detaching browser access  %s from segment group: %s\n", segmentID, segmentGroupID)
+	defer lockObject("segment group", segmentGroupID)()
 	segGroup, _, err := client.segmentgroup.Get(segmentGroupID)
 	if err != nil {
 		log.Printf("[error] Error while getting segment group id: %s", segmentGroupID)
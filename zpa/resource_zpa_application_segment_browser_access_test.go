@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -25,15 +26,15 @@ func TestAccResourceApplicationSegmentBrowserAccessBasic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationSegmentBrowserAccessDestroy,
+		CheckDestroy: testAccCheckApplicationSegmentBrowserAccessDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationSegmentBrowserAccessConfigure(browserAccessTypeAndName, browserAccessGeneratedName, browserAccessGeneratedName, browserAccessGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.BrowserAccessEnabled, variable.BrowserAccessCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentBrowserAccessExists(browserAccessTypeAndName, &browserAccess),
+					testAccCheckApplicationSegmentBrowserAccessExists(provider, browserAccessTypeAndName, &browserAccess),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "name", "tf-acc-test-"+browserAccessGeneratedName),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "description", "tf-acc-test-"+browserAccessGeneratedName),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "enabled", strconv.FormatBool(variable.BrowserAccessEnabled)),
@@ -50,7 +51,7 @@ func TestAccResourceApplicationSegmentBrowserAccessBasic(t *testing.T) {
 			{
 				Config: testAccCheckApplicationSegmentBrowserAccessConfigure(browserAccessTypeAndName, browserAccessGeneratedName, browserAccessGeneratedName, browserAccessGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.BrowserAccessEnabled, variable.BrowserAccessCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentBrowserAccessExists(browserAccessTypeAndName, &browserAccess),
+					testAccCheckApplicationSegmentBrowserAccessExists(provider, browserAccessTypeAndName, &browserAccess),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "name", "tf-acc-test-"+browserAccessGeneratedName),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "description", "tf-acc-test-"+browserAccessGeneratedName),
 					resource.TestCheckResourceAttr(browserAccessTypeAndName, "enabled", strconv.FormatBool(variable.BrowserAccessEnabled)),
@@ -66,25 +67,27 @@ func TestAccResourceApplicationSegmentBrowserAccessBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckApplicationSegmentBrowserAccessDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*Client)
+func testAccCheckApplicationSegmentBrowserAccessDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAApplicationSegmentBrowserAccess {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAApplicationSegmentBrowserAccess {
+				continue
+			}
 
-		_, _, err := client.browseraccess.GetByName(rs.Primary.Attributes["name"])
-		if err == nil {
-			return fmt.Errorf("Broser Access still exists")
-		}
+			_, _, err := client.browseraccess.GetByName(rs.Primary.Attributes["name"])
+			if err == nil {
+				return fmt.Errorf("Broser Access still exists")
+			}
 
+			return nil
+		}
 		return nil
 	}
-	return nil
 }
 
-func testAccCheckApplicationSegmentBrowserAccessExists(resource string, segment *browseraccess.BrowserAccess) resource.TestCheckFunc {
+func testAccCheckApplicationSegmentBrowserAccessExists(provider *schema.Provider, resource string, segment *browseraccess.BrowserAccess) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
@@ -93,7 +96,7 @@ func testAccCheckApplicationSegmentBrowserAccessExists(resource string, segment
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("no Browser Access ID is set")
 		}
-		client := testAccProvider.Meta().(*Client)
+		client := provider.Meta().(*Client)
 		resp, _, err := client.browseraccess.GetByName(rs.Primary.Attributes["name"])
 		if err != nil {
 			return err
@@ -1,6 +1,7 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -8,22 +9,23 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentinspection"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
 )
 
 func resourceApplicationSegmentInspection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationSegmentInspectionCreate,
-		Read:   resourceApplicationSegmentInspectionRead,
-		Update: resourceApplicationSegmentInspectionUpdate,
-		Delete: resourceApplicationSegmentInspectionDelete,
+		CreateContext: resourceApplicationSegmentInspectionCreate,
+		ReadContext:   resourceApplicationSegmentInspectionRead,
+		UpdateContext: resourceApplicationSegmentInspectionUpdate,
+		DeleteContext: resourceApplicationSegmentInspectionDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -324,41 +326,41 @@ func resourceApplicationSegmentInspection() *schema.Resource {
 	}
 }
 
-func resourceApplicationSegmentInspectionCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentInspectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandInspectionApplicationSegment(d, zClient, "")
 	if err := checkForInspectionPortsOverlap(zClient, req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Creating application segment request\n%+v\n", req)
 	if req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provde a valid segment group for the application segment")
-		return fmt.Errorf("please provde a valid segment group for the application segment")
+		return diag.Errorf("please provde a valid segment group for the application segment")
 	}
 
 	resp, _, err := zClient.applicationsegmentinspection.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "inspection application segment", d.Get("name").(string)))
 	}
 
 	log.Printf("[INFO] Created inspection application segment request. ID: %v\n", resp.ID)
 	d.SetId(resp.ID)
 
-	return resourceApplicationSegmentInspectionRead(d, m)
+	return resourceApplicationSegmentInspectionRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentInspectionRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentInspectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.applicationsegmentinspection.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing inspection application segment %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting inspection application segment:\n%+v\n", resp)
@@ -388,15 +390,15 @@ func resourceApplicationSegmentInspectionRead(d *schema.ResourceData, m interfac
 	_ = d.Set("server_groups", flattenInspectionAppServerGroupsSimple(resp))
 
 	if err := d.Set("common_apps_dto", flattenInspectionCommonAppsDto(resp.InspectionAppDto)); err != nil {
-		return fmt.Errorf("failed to read common application in application segment %s", err)
+		return diag.Errorf("failed to read common application in application segment %s", err)
 	}
 
 	if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -415,8 +417,8 @@ func flattenInspectionAppServerGroupsSimple(serverGroup *applicationsegmentinspe
 	return result
 }
 
-func resourceApplicationSegmentInspectionUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentInspectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating inspection application segment ID: %v\n", id)
@@ -424,46 +426,43 @@ func resourceApplicationSegmentInspectionUpdate(d *schema.ResourceData, m interf
 
 	if d.HasChange("segment_group_id") && req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provde a valid segment group for the inspection application segment")
-		return fmt.Errorf("please provde a valid segment group for the inspection application segment")
+		return diag.Errorf("please provde a valid segment group for the inspection application segment")
 	}
 
 	if _, _, err := zClient.applicationsegmentinspection.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "inspection application segment")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.applicationsegmentinspection.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "inspection application segment", d.Get("name").(string)))
 	}
 
-	return resourceApplicationSegmentInspectionRead(d, m)
+	return resourceApplicationSegmentInspectionRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentInspectionDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentInspectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	id := d.Id()
-	segmentGroupID, ok := d.GetOk("segment_group_id")
-	if ok && segmentGroupID != nil {
-		gID, ok := segmentGroupID.(string)
-		if ok && gID != "" {
+	segmentGroupID, _ := d.Get("segment_group_id").(string)
+	log.Printf("[INFO] Deleting inspection application segment with id %v\n", id)
+	return deleteReferencedObject(zClient, objectReference{"application segment", id}, func() error {
+		if segmentGroupID != "" {
 			// detach it from segment group first
-			if err := detachInspectionPortalsFromGroup(zClient, id, gID); err != nil {
+			if err := detachInspectionPortalsFromGroup(zClient, id, segmentGroupID); err != nil {
 				return err
 			}
 		}
-	}
-	log.Printf("[INFO] Deleting inspection application segment with id %v\n", id)
-	if _, err := zClient.applicationsegmentinspection.Delete(id); err != nil {
+		_, err := zClient.applicationsegmentinspection.Delete(id)
 		return err
-	}
-
-	return nil
+	})
 }
 
 func detachInspectionPortalsFromGroup(client *Client, segmentID, segmentGroupID string) error {
 	log.Printf("[INFO] Detaching inspection application segment  %s from segment group: %s\n", segmentID, segmentGroupID)
+	defer lockObject("segment group", segmentGroupID)()
 	segGroup, _, err := client.segmentgroup.Get(segmentGroupID)
 	if err != nil {
 		log.Printf("[error] Error while getting segment group id: %s", segmentGroupID)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -23,15 +24,15 @@ func TestAccResourceApplicationSegmentInspectionBasic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationSegmentInspectionDestroy,
+		CheckDestroy: testAccCheckApplicationSegmentInspectionDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationSegmentInspectionConfigure(appSegmentTypeAndName, appSegmentGeneratedName, appSegmentGeneratedName, appSegmentGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.AppSegmentEnabled, variable.AppSegmentCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentInspectionExists(appSegmentTypeAndName, &appSegment),
+					testAccCheckApplicationSegmentInspectionExists(provider, appSegmentTypeAndName, &appSegment),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "name", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "description", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "enabled", strconv.FormatBool(variable.AppSegmentEnabled)),
@@ -49,7 +50,7 @@ func TestAccResourceApplicationSegmentInspectionBasic(t *testing.T) {
 			{
 				Config: testAccCheckApplicationSegmentInspectionConfigure(appSegmentTypeAndName, appSegmentGeneratedName, appSegmentGeneratedName, appSegmentGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.AppSegmentEnabled, variable.AppSegmentCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentInspectionExists(appSegmentTypeAndName, &appSegment),
+					testAccCheckApplicationSegmentInspectionExists(provider, appSegmentTypeAndName, &appSegment),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "name", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "description", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "enabled", strconv.FormatBool(variable.AppSegmentEnabled)),
@@ -66,25 +67,27 @@ func TestAccResourceApplicationSegmentInspectionBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckApplicationSegmentInspectionDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*Client)
+func testAccCheckApplicationSegmentInspectionDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAApplicationSegmentInspection {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAApplicationSegmentInspection {
+				continue
+			}
 
-		_, _, err := client.applicationsegmentinspection.GetByName(rs.Primary.Attributes["name"])
-		if err == nil {
-			return fmt.Errorf("Inspection Application Segment still exists")
-		}
+			_, _, err := client.applicationsegmentinspection.GetByName(rs.Primary.Attributes["name"])
+			if err == nil {
+				return fmt.Errorf("Inspection Application Segment still exists")
+			}
 
+			return nil
+		}
 		return nil
 	}
-	return nil
 }
 
-func testAccCheckApplicationSegmentInspectionExists(resource string, segment *applicationsegmentinspection.AppSegmentInspection) resource.TestCheckFunc {
+func testAccCheckApplicationSegmentInspectionExists(provider *schema.Provider, resource string, segment *applicationsegmentinspection.AppSegmentInspection) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
@@ -93,7 +96,7 @@ func testAccCheckApplicationSegmentInspectionExists(resource string, segment *ap
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("no Inspection Application Segment ID is set")
 		}
-		client := testAccProvider.Meta().(*Client)
+		client := provider.Meta().(*Client)
 		resp, _, err := client.applicationsegmentinspection.GetByName(rs.Primary.Attributes["name"])
 		if err != nil {
 			return err
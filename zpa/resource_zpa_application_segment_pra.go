@@ -1,6 +1,7 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -8,9 +9,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentpra"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
@@ -18,13 +19,14 @@ import (
 
 func resourceApplicationSegmentPRA() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationSegmentPRACreate,
-		Read:   resourceApplicationSegmentPRARead,
-		Update: resourceApplicationSegmentPRAUpdate,
-		Delete: resourceApplicationSegmentPRADelete,
+		CreateContext: resourceApplicationSegmentPRACreate,
+		ReadContext:   resourceApplicationSegmentPRARead,
+		UpdateContext: resourceApplicationSegmentPRAUpdate,
+		DeleteContext: resourceApplicationSegmentPRADelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -323,41 +325,41 @@ func resourceApplicationSegmentPRA() *schema.Resource {
 	}
 }
 
-func resourceApplicationSegmentPRACreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentPRACreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandSRAApplicationSegment(d, zClient, "")
 	if err := checkForPRAPortsOverlap(zClient, req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Creating application segment request\n%+v\n", req)
 	if req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provde a valid segment group for the application segment")
-		return fmt.Errorf("please provde a valid segment group for the application segment")
+		return diag.Errorf("please provde a valid segment group for the application segment")
 	}
 
 	resp, _, err := zClient.applicationsegmentpra.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "sra application segment", d.Get("name").(string)))
 	}
 
 	log.Printf("[INFO] Created application segment request. ID: %v\n", resp.ID)
 	d.SetId(resp.ID)
 
-	return resourceApplicationSegmentRead(d, m)
+	return resourceApplicationSegmentRead(ctx, d, m)
 }
 
-func resourceApplicationSegmentPRARead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentPRARead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.applicationsegmentpra.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing sra application segment %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting sra application segment:\n%+v\n", resp)
@@ -387,15 +389,15 @@ func resourceApplicationSegmentPRARead(d *schema.ResourceData, m interface{}) er
 	_ = d.Set("server_groups", flattenPRAAppServerGroupsSimple(resp))
 
 	if err := d.Set("common_apps_dto", flattenCommonAppsDto(resp.SRAAppsDto)); err != nil {
-		return fmt.Errorf("failed to read common application in application segment %s", err)
+		return diag.Errorf("failed to read common application in application segment %s", err)
 	}
 
 	if err := d.Set("tcp_port_range", flattenNetworkPorts(resp.TCPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("udp_port_range", flattenNetworkPorts(resp.UDPAppPortRange)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -413,59 +415,56 @@ func flattenPRAAppServerGroupsSimple(serverGroup *applicationsegmentpra.AppSegme
 	result[0] = mapIds
 	return result
 }
-func resourceApplicationSegmentPRAUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentPRAUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating pra application segment ID: %v\n", id)
 	req := expandSRAApplicationSegment(d, zClient, id)
 
 	if err := checkForPRAPortsOverlap(zClient, req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("segment_group_id") && req.SegmentGroupID == "" {
 		log.Println("[ERROR] Please provde a valid segment group for the sra application segment")
-		return fmt.Errorf("please provde a valid segment group for the sra application segment")
+		return diag.Errorf("please provde a valid segment group for the sra application segment")
 	}
 
 	if _, _, err := zClient.applicationsegmentpra.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "sra application segment")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.applicationsegmentpra.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "sra application segment", d.Get("name").(string)))
 	}
 
-	return resourceApplicationSegmentPRARead(d, m)
+	return resourceApplicationSegmentPRARead(ctx, d, m)
 }
 
-func resourceApplicationSegmentPRADelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceApplicationSegmentPRADelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	id := d.Id()
-	segmentGroupID, ok := d.GetOk("segment_group_id")
-	if ok && segmentGroupID != nil {
-		gID, ok := segmentGroupID.(string)
-		if ok && gID != "" {
+	segmentGroupID, _ := d.Get("segment_group_id").(string)
+	log.Printf("[INFO] Deleting sra application segment with id %v\n", id)
+	return deleteReferencedObject(zClient, objectReference{"application segment", id}, func() error {
+		if segmentGroupID != "" {
 			// detach it from segment group first
-			if err := detachSraPortalsFromGroup(zClient, id, gID); err != nil {
+			if err := detachSraPortalsFromGroup(zClient, id, segmentGroupID); err != nil {
 				return err
 			}
 		}
-	}
-	log.Printf("[INFO] Deleting sra application segment with id %v\n", id)
-	if _, err := zClient.applicationsegmentpra.Delete(id); err != nil {
+		_, err := zClient.applicationsegmentpra.Delete(id)
 		return err
-	}
-
-	return nil
+	})
 }
 
 func detachSraPortalsFromGroup(client *Client, segmentID, segmentGroupID string) error {
 	log.Printf("[INFO] Detaching pra application segment  %s from segment group: %s\n", segmentID, segmentGroupID)
+	defer lockObject("segment group", segmentGroupID)()
 	segGroup, _, err := client.segmentgroup.Get(segmentGroupID)
 	if err != nil {
 		log.Printf("[error] Error while getting segment group id: %s", segmentGroupID)
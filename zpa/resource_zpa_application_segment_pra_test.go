@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -23,15 +24,15 @@ func TestAccResourceApplicationSegmentPRABasic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckApplicationSegmentPRADestroy,
+		CheckDestroy: testAccCheckApplicationSegmentPRADestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckApplicationSegmentPRAConfigure(appSegmentTypeAndName, appSegmentGeneratedName, appSegmentGeneratedName, appSegmentGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.AppSegmentEnabled, variable.AppSegmentCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentPRAExists(appSegmentTypeAndName, &appSegment),
+					testAccCheckApplicationSegmentPRAExists(provider, appSegmentTypeAndName, &appSegment),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "name", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "description", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "enabled", strconv.FormatBool(variable.AppSegmentEnabled)),
@@ -49,7 +50,7 @@ func TestAccResourceApplicationSegmentPRABasic(t *testing.T) {
 			{
 				Config: testAccCheckApplicationSegmentPRAConfigure(appSegmentTypeAndName, appSegmentGeneratedName, appSegmentGeneratedName, appSegmentGeneratedName, segmentGroupHCL, segmentGroupTypeAndName, serverGroupHCL, serverGroupTypeAndName, variable.AppSegmentEnabled, variable.AppSegmentCnameEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckApplicationSegmentPRAExists(appSegmentTypeAndName, &appSegment),
+					testAccCheckApplicationSegmentPRAExists(provider, appSegmentTypeAndName, &appSegment),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "name", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "description", "tf-acc-test-"+appSegmentGeneratedName),
 					resource.TestCheckResourceAttr(appSegmentTypeAndName, "enabled", strconv.FormatBool(variable.AppSegmentEnabled)),
@@ -66,25 +67,27 @@ func TestAccResourceApplicationSegmentPRABasic(t *testing.T) {
 	})
 }
 
-func testAccCheckApplicationSegmentPRADestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*Client)
+func testAccCheckApplicationSegmentPRADestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAApplicationSegmentPRA {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAApplicationSegmentPRA {
+				continue
+			}
 
-		_, _, err := client.applicationsegmentpra.GetByName(rs.Primary.Attributes["name"])
-		if err == nil {
-			return fmt.Errorf("Application Segment PRA still exists")
-		}
+			_, _, err := client.applicationsegmentpra.GetByName(rs.Primary.Attributes["name"])
+			if err == nil {
+				return fmt.Errorf("Application Segment PRA still exists")
+			}
 
+			return nil
+		}
 		return nil
 	}
-	return nil
 }
 
-func testAccCheckApplicationSegmentPRAExists(resource string, segment *applicationsegmentpra.AppSegmentPRA) resource.TestCheckFunc {
+func testAccCheckApplicationSegmentPRAExists(provider *schema.Provider, resource string, segment *applicationsegmentpra.AppSegmentPRA) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
@@ -93,7 +96,7 @@ func testAccCheckApplicationSegmentPRAExists(resource string, segment *applicati
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("no Application Segment PRA ID is set")
 		}
-		client := testAccProvider.Meta().(*Client)
+		client := provider.Meta().(*Client)
 		resp, _, err := client.applicationsegmentpra.GetByName(rs.Primary.Attributes["name"])
 		if err != nil {
 			return err
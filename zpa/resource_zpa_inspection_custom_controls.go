@@ -1,26 +1,28 @@
 package zpa
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_custom_controls"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_profile"
 )
 
 func resourceInspectionCustomControls() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceInspectionCustomControlsCreate,
-		Read:   resourceInspectionCustomControlsRead,
-		Update: resourceInspectionCustomControlsUpdate,
-		Delete: resourceInspectionCustomControlsDelete,
+		CreateContext: resourceInspectionCustomControlsCreate,
+		ReadContext:   resourceInspectionCustomControlsRead,
+		UpdateContext: resourceInspectionCustomControlsUpdate,
+		DeleteContext: resourceInspectionCustomControlsDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -273,39 +275,39 @@ func updateInspectionProfile(zClient *Client, customControlId string, req *inspe
 	}
 }
 
-func resourceInspectionCustomControlsCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionCustomControlsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandInspectionCustomControls(d)
 	log.Printf("[INFO] Creating custom inspection control with request\n%+v\n", req)
 	if req.Action == "REDIRECT" && req.ActionValue == "" {
-		return errors.New("when action is REDIRECT, action value must be set")
+		return diag.FromErr(errors.New("when action is REDIRECT, action value must be set"))
 	}
 	if err := validateRules(req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	resp, _, err := zClient.inspection_custom_controls.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "custom inspection control", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created custom inspection control request. ID: %v\n", resp)
 
 	d.SetId(resp.ID)
 	updateInspectionProfile(zClient, resp.ID, &req)
-	return resourceInspectionCustomControlsRead(d, m)
+	return resourceInspectionCustomControlsRead(ctx, d, m)
 }
 
-func resourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionCustomControlsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.inspection_custom_controls.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing custom inspection control %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting custom inspection control:\n%+v\n", resp)
 	d.SetId(resp.ID)
@@ -323,43 +325,43 @@ func resourceInspectionCustomControlsRead(d *schema.ResourceData, m interface{})
 	_ = d.Set("type", resp.Type)
 
 	if err := d.Set("rules", flattenInspectionCustomRules(resp.Rules)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceInspectionCustomControlsUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionCustomControlsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating custom inspection control ID: %v\n", id)
 	req := expandInspectionCustomControls(d)
 	if err := validateRules(req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if _, _, err := zClient.inspection_custom_controls.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "custom inspection control")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.inspection_custom_controls.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "custom inspection control", d.Get("name").(string)))
 	}
 	updateInspectionProfile(zClient, id, &req)
-	return resourceInspectionCustomControlsRead(d, m)
+	return resourceInspectionCustomControlsRead(ctx, d, m)
 }
 
-func resourceInspectionCustomControlsDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionCustomControlsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting custom inspection control ID: %v\n", d.Id())
 	// First de-associate it from all inspection profiles
 	c, _, err := zClient.inspection_custom_controls.Get(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	for _, inspectionProfile := range c.AssociatedInspectionProfileNames {
 		inspectionProfileRemote, _, err := zClient.inspection_profile.Get(inspectionProfile.ID)
@@ -378,7 +380,7 @@ func resourceInspectionCustomControlsDelete(d *schema.ResourceData, m interface{
 		zClient.inspection_profile.Update(inspectionProfile.ID, inspectionProfileRemote)
 	}
 	if _, err := zClient.inspection_custom_controls.Delete(d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	log.Printf("[INFO] custom inspection control deleted")
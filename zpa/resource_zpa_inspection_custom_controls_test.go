@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -16,72 +17,72 @@ func TestAccResourceInspectionCustomControlsBasic(t *testing.T) {
 	var control inspection_custom_controls.InspectionCustomControl
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAInspectionCustomControl)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckInspectionCustomControlsDestroy,
+		CheckDestroy: testAccCheckInspectionCustomControlsDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckInspectionCustomControlsConfigure(resourceTypeAndName, generatedName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckInspectionCustomControlsExists(resourceTypeAndName, &control),
+					testAccCheckInspectionCustomControlsExists(provider, resourceTypeAndName, &control),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", "tf-acc-test-"+generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", "tf-acc-test-"+generatedName),
-					// resource.TestCheckResourceAttr(resourceTypeAndName, "action", variable.InspectionCustomControlAction),
-					// resource.TestCheckResourceAttr(resourceTypeAndName, "default_action", variable.InspectionCustomControlDefaultAction),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", variable.InspectionCustomControlAction),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "default_action", variable.InspectionCustomControlDefaultAction),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "paranoia_level", "1"),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "protocol_type", "HTTP"),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "severity", variable.InspectionCustomControlSeverity),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "type", variable.InspectionCustomControlType),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "rules.#", "2"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 
 			// Update test
 			{
 				Config: testAccCheckInspectionCustomControlsConfigure(resourceTypeAndName, generatedName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckInspectionCustomControlsExists(resourceTypeAndName, &control),
+					testAccCheckInspectionCustomControlsExists(provider, resourceTypeAndName, &control),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", "tf-acc-test-"+generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", "tf-acc-test-"+generatedName),
-					// resource.TestCheckResourceAttr(resourceTypeAndName, "action", variable.InspectionCustomControlAction),
-					// resource.TestCheckResourceAttr(resourceTypeAndName, "default_action", variable.InspectionCustomControlDefaultAction),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", variable.InspectionCustomControlAction),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "default_action", variable.InspectionCustomControlDefaultAction),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "paranoia_level", "1"),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "protocol_type", "HTTP"),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "severity", variable.InspectionCustomControlSeverity),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "type", variable.InspectionCustomControlType),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "rules.#", "2"),
 				),
-				ExpectNonEmptyPlan: true,
 			},
 		},
 	})
 }
 
-func testAccCheckInspectionCustomControlsDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckInspectionCustomControlsDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAInspectionCustomControl {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAInspectionCustomControl {
+				continue
+			}
 
-		rule, _, err := apiClient.inspection_custom_controls.Get(rs.Primary.ID)
+			rule, _, err := apiClient.inspection_custom_controls.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("inspection custom control with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("inspection custom control with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckInspectionCustomControlsExists(resource string, rule *inspection_custom_controls.InspectionCustomControl) resource.TestCheckFunc {
+func testAccCheckInspectionCustomControlsExists(provider *schema.Provider, resource string, rule *inspection_custom_controls.InspectionCustomControl) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -91,7 +92,7 @@ func testAccCheckInspectionCustomControlsExists(resource string, rule *inspectio
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedControl, _, err := apiClient.inspection_custom_controls.Get(rs.Primary.ID)
 
 		if err != nil {
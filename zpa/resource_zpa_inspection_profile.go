@@ -1,25 +1,27 @@
 package zpa
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_profile"
 )
 
 func resourceInspectionProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceInspectionProfileCreate,
-		Read:   resourceInspectionProfileRead,
-		Update: resourceInspectionProfileUpdate,
-		Delete: resourceInspectionProfileDelete,
+		CreateContext: resourceInspectionProfileCreate,
+		ReadContext:   resourceInspectionProfileRead,
+		UpdateContext: resourceInspectionProfileUpdate,
+		DeleteContext: resourceInspectionProfileDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -259,18 +261,18 @@ func validateInspectionProfile(profile *inspection_profile.InspectionProfile) er
 	return nil
 }
 
-func resourceInspectionProfileCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandInspectionProfile(d)
 	log.Printf("[INFO] Creating inspection profile with request\n%+v\n", req)
 	if err := validateInspectionProfile(&req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	//injectPredefinedControls(zClient, &req)
 	resp, _, err := zClient.inspection_profile.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "inspection profile", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created inspection profile  request. ID: %v\n", resp)
 
@@ -278,24 +280,24 @@ func resourceInspectionProfileCreate(d *schema.ResourceData, m interface{}) erro
 	if v, ok := d.GetOk("associate_all_controls"); ok && v.(bool) {
 		p, _, err := zClient.inspection_profile.Get(resp.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		zClient.inspection_profile.PutAssociate(resp.ID, p)
 	}
-	return resourceInspectionProfileRead(d, m)
+	return resourceInspectionProfileRead(ctx, d, m)
 }
 
-func resourceInspectionProfileRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.inspection_profile.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing inspection profile %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting inspection profile:\n%+v\n", resp)
 	d.SetId(resp.ID)
@@ -310,20 +312,20 @@ func resourceInspectionProfileRead(d *schema.ResourceData, m interface{}) error
 	}
 	if len(resp.ControlInfoResource) > 0 {
 		if err := d.Set("controls_info", flattenControlInfoResource(resp.ControlInfoResource)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if err := d.Set("custom_controls", flattenCustomControlsSimple(resp.CustomControls)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("predefined_controls", flattenPredefinedControlsSimple(resp.PredefinedControls)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("web_socket_controls", flattenPredefinedControlsSimple(resp.WebSocketControls)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 
@@ -357,44 +359,44 @@ func flattenCustomControlsSimple(customControl []inspection_profile.InspectionCu
 	return customControls
 }
 
-func resourceInspectionProfileUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating inspection profile ID: %v\n", id)
 	req := expandInspectionProfile(d)
 	if err := validateInspectionProfile(&req); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if _, _, err := zClient.inspection_profile.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "inspection profile")
 		}
+		return diag.FromErr(err)
 	}
 
 	//injectPredefinedControls(zClient, &req)
 	if _, err := zClient.inspection_profile.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "inspection profile", d.Get("name").(string)))
 	}
 	if v, ok := d.GetOk("associate_all_controls"); ok && v.(bool) {
 		p, _, err := zClient.inspection_profile.Get(req.ID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		zClient.inspection_profile.PutAssociate(req.ID, p)
 	}
-	return resourceInspectionProfileRead(d, m)
+	return resourceInspectionProfileRead(ctx, d, m)
 }
 
-func resourceInspectionProfileDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceInspectionProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting inspection profile ID: %v\n", d.Id())
 
 	if _, err := zClient.inspection_profile.Delete(d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	log.Printf("[INFO] inspection profile deleted")
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -16,15 +17,15 @@ func TestAccResourceInspectionProfileBasic(t *testing.T) {
 	var profile inspection_profile.InspectionProfile
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAInspectionProfile)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckInspectionProfileDestroy,
+		CheckDestroy: testAccCheckInspectionProfileDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckInspectionProfileConfigure(resourceTypeAndName, generatedName, variable.InspectionProfileDescription),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckInspectionProfileExists(resourceTypeAndName, &profile),
+					testAccCheckInspectionProfileExists(provider, resourceTypeAndName, &profile),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.InspectionProfileDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "paranoia_level", "1"),
@@ -37,7 +38,7 @@ func TestAccResourceInspectionProfileBasic(t *testing.T) {
 			{
 				Config: testAccCheckInspectionProfileConfigure(resourceTypeAndName, generatedName, variable.InspectionProfileDescription),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckInspectionProfileExists(resourceTypeAndName, &profile),
+					testAccCheckInspectionProfileExists(provider, resourceTypeAndName, &profile),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.InspectionProfileDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "paranoia_level", "1"),
@@ -49,29 +50,31 @@ func TestAccResourceInspectionProfileBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckInspectionProfileDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckInspectionProfileDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAInspectionProfile {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAInspectionProfile {
+				continue
+			}
 
-		rule, _, err := apiClient.inspection_profile.Get(rs.Primary.ID)
+			rule, _, err := apiClient.inspection_profile.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("inspection profile with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("inspection profile with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckInspectionProfileExists(resource string, rule *inspection_profile.InspectionProfile) resource.TestCheckFunc {
+func testAccCheckInspectionProfileExists(provider *schema.Provider, resource string, rule *inspection_profile.InspectionProfile) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -81,7 +84,7 @@ func testAccCheckInspectionProfileExists(resource string, rule *inspection_profi
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedProfile, _, err := apiClient.inspection_profile.Get(rs.Primary.ID)
 
 		if err != nil {
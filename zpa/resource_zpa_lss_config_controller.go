@@ -1,17 +1,18 @@
 package zpa
 
 import (
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
 )
 
 func getPolicyRuleResourceSchema() map[string]*schema.Schema {
-	return MergeSchema(
+	s := MergeSchema(
 		CommonPolicySchema(), map[string]*schema.Schema{
 			"action": {
 				Type:        schema.TypeString,
@@ -76,17 +77,22 @@ func getPolicyRuleResourceSchema() map[string]*schema.Schema {
 			},
 		},
 	)
+	// the SIEM rule of a log receiver isn't placed among other rules
+	delete(s, "insert_after")
+	delete(s, "insert_before")
+	return s
 }
 
 func resourceLSSConfigController() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceLSSConfigControllerCreate,
-		Read:   resourceLSSConfigControllerRead,
-		Update: resourceLSSConfigControllerUpdate,
-		Delete: resourceLSSConfigControllerDelete,
+		CreateContext: resourceLSSConfigControllerCreate,
+		ReadContext:   resourceLSSConfigControllerRead,
+		UpdateContext: resourceLSSConfigControllerUpdate,
+		DeleteContext: resourceLSSConfigControllerDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -218,34 +224,34 @@ func resourceLSSConfigController() *schema.Resource {
 	}
 }
 
-func resourceLSSConfigControllerCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceLSSConfigControllerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandLSSResource(d)
 	log.Printf("[INFO] Creating zpa lss config controller with request\n%+v\n", req)
 
 	resp, _, err := zClient.lssconfigcontroller.Create(&req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "lss config controller", req.LSSConfig.Name))
 	}
 	log.Printf("[INFO] Created lss config controller request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceLSSConfigControllerRead(d, m)
+	return resourceLSSConfigControllerRead(ctx, d, m)
 }
 
-func resourceLSSConfigControllerRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceLSSConfigControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.lssconfigcontroller.Get(d.Id())
 	if err != nil {
-		if err.(*client.ErrorResponse).IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing lss config controller %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting lss config controller:\n%+v\n", resp)
@@ -259,34 +265,34 @@ func resourceLSSConfigControllerRead(d *schema.ResourceData, m interface{}) erro
 
 }
 
-func resourceLSSConfigControllerUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceLSSConfigControllerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating lss config controller ID: %v\n", id)
 	req := expandLSSResource(d)
 
 	if _, _, err := zClient.lssconfigcontroller.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "lss config controller")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.lssconfigcontroller.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "lss config controller", req.LSSConfig.Name))
 	}
 
-	return resourceLSSConfigControllerRead(d, m)
+	return resourceLSSConfigControllerRead(ctx, d, m)
 }
 
-func resourceLSSConfigControllerDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceLSSConfigControllerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting lss config controller ID: %v\n", d.Id())
 
 	if _, err := zClient.lssconfigcontroller.Delete(d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	log.Printf("[INFO] lss config controller deleted")
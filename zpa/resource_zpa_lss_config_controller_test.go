@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -23,15 +24,15 @@ func TestAccResourceLSSConfigControllerBasic(t *testing.T) {
 	appConnectorGroupTypeAndName, _, appConnectorGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 	appConnectorGroupHCL := testAccCheckAppConnectorGroupConfigure(appConnectorGroupTypeAndName, appConnectorGroupGeneratedName, variable.AppConnectorDescription, variable.AppConnectorEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLSSConfigControllerDestroy,
+		CheckDestroy: testAccCheckLSSConfigControllerDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckLSSConfigControllerConfigure(lssControllerTypeAndName, lssControllerGeneratedName, lssControllerGeneratedName, lssControllerGeneratedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, rIP, rPort, variable.LSSControllerEnabled, variable.LSSControllerTLSEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLSSConfigControllerExists(lssControllerTypeAndName, &lssConfig),
+					testAccCheckLSSConfigControllerExists(provider, lssControllerTypeAndName, &lssConfig),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.name", "test-lss-config-"+lssControllerGeneratedName),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.description", "test-lss-config-"+lssControllerGeneratedName),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.enabled", strconv.FormatBool(variable.LSSControllerEnabled)),
@@ -45,7 +46,7 @@ func TestAccResourceLSSConfigControllerBasic(t *testing.T) {
 			{
 				Config: testAccCheckLSSConfigControllerConfigure(lssControllerTypeAndName, lssControllerGeneratedName, lssControllerGeneratedName, lssControllerGeneratedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, rIP, rPort, variable.LSSControllerEnabled, variable.LSSControllerTLSEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckLSSConfigControllerExists(lssControllerTypeAndName, &lssConfig),
+					testAccCheckLSSConfigControllerExists(provider, lssControllerTypeAndName, &lssConfig),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.name", "test-lss-config-"+lssControllerGeneratedName),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.description", "test-lss-config-"+lssControllerGeneratedName),
 					resource.TestCheckResourceAttr(lssControllerTypeAndName, "config.0.enabled", strconv.FormatBool(variable.LSSControllerEnabled)),
@@ -58,27 +59,29 @@ func TestAccResourceLSSConfigControllerBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckLSSConfigControllerDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*Client)
+func testAccCheckLSSConfigControllerDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPALSSController {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPALSSController {
+				continue
+			}
 
-		lss, _, err := client.lssconfigcontroller.Get(rs.Primary.ID)
-		if err == nil {
-			return fmt.Errorf("id %s still exists", rs.Primary.ID)
-		}
+			lss, _, err := client.lssconfigcontroller.Get(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("id %s still exists", rs.Primary.ID)
+			}
 
-		if lss != nil {
-			return fmt.Errorf("lss config controller with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if lss != nil {
+				return fmt.Errorf("lss config controller with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
-func testAccCheckLSSConfigControllerExists(resource string, lss *lssconfigcontroller.LSSConfig) resource.TestCheckFunc {
+func testAccCheckLSSConfigControllerExists(provider *schema.Provider, resource string, lss *lssconfigcontroller.LSSConfig) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
@@ -87,7 +90,7 @@ func testAccCheckLSSConfigControllerExists(resource string, lss *lssconfigcontro
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("no lss config controller ID is set")
 		}
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		resp, _, err := apiClient.lssconfigcontroller.Get(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -1,98 +1,88 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
 func resourcePolicyForwardingRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyForwardingRuleCreate,
-		Read:   resourcePolicyForwardingRuleRead,
-		Update: resourcePolicyForwardingRuleUpdate,
-		Delete: resourcePolicyForwardingRuleDelete,
+		CreateContext: resourcePolicyForwardingRuleCreate,
+		ReadContext:   resourcePolicyForwardingRuleRead,
+		UpdateContext: resourcePolicyForwardingRuleUpdate,
+		DeleteContext: resourcePolicyForwardingRuleDelete,
+		CustomizeDiff: customizeDiffPolicyConditions,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			StateContext: importPolicyStateContextFunc([]string{"CLIENT_FORWARDING_POLICY", "BYPASS_POLICY"}),
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("CLIENT_FORWARDING_POLICY")),
 		},
 
 		Schema: MergeSchema(
 			CommonPolicySchema(),
 			map[string]*schema.Schema{
 				"action": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "  This is for providing the rule action.",
-					ValidateFunc: validation.StringInSlice([]string{
-						"BYPASS",
-						"INTERCEPT",
-						"INTERCEPT_ACCESSIBLE",
-					}, false),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "  This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["CLIENT_FORWARDING_POLICY"].actions, false),
 				},
-				"conditions": GetPolicyConditionsSchema([]string{
-					"APP",
-					"APP_GROUP",
-					"CLIENT_TYPE",
-					"EDGE_CONNECTOR_GROUP",
-					"POSTURE",
-					"MACHINE_GRP",
-					"TRUSTED_NETWORK",
-					"IDP",
-					"SAML",
-					"SCIM",
-					"SCIM_GROUP",
-				}),
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["CLIENT_FORWARDING_POLICY"].objectTypes),
 			},
 		),
 	}
 }
 
-func resourcePolicyForwardingRuleCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyForwardingRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req, err := expandCreatePolicyForwardingRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
 	}
 	log.Printf("[INFO] Creating zpa policy forwarding rule with request\n%+v\n", req)
-	if ValidateConditions(req.Conditions, zClient) {
-		policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
-		if err != nil {
-			return err
-		}
-		d.SetId(policysetcontroller.ID)
-		order, ok := d.GetOk("rule_order")
-		if ok {
-			reorder(order, policysetcontroller.PolicySetID, "CLIENT_FORWARDING_POLICY", policysetcontroller.ID, zClient)
+	policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
+	if err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	d.SetId(policysetcontroller.ID)
+	order, ok := d.GetOk("rule_order")
+	if ok {
+		if err := reorder(order, policysetcontroller.PolicySetID, "CLIENT_FORWARDING_POLICY", policysetcontroller.ID, zClient); err != nil {
+			return diag.FromErr(err)
 		}
-		return resourcePolicyForwardingRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy forwarding (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, policysetcontroller.PolicySetID, "CLIENT_FORWARDING_POLICY", policysetcontroller.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyForwardingRuleRead(ctx, d, m)
 }
 
-func resourcePolicyForwardingRuleRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyForwardingRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting Policy Set Rule: globalPolicySet:%s id: %s\n", globalPolicySet.ID, d.Id())
 	resp, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, d.Id())
 	if err != nil {
-		if obj, ok := err.(*client.ErrorResponse); ok && obj.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Got Policy Set Forwarding Rule:\n%+v\n", resp)
@@ -109,58 +99,64 @@ func resourcePolicyForwardingRuleRead(d *schema.ResourceData, m interface{}) err
 	_ = d.Set("policy_type", resp.PolicyType)
 	_ = d.Set("priority", resp.Priority)
 	_ = d.Set("rule_order", resp.RuleOrder)
-	_ = d.Set("conditions", flattenPolicyConditions(resp.Conditions))
+	if err := readRulePlacement(d, "CLIENT_FORWARDING_POLICY", resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("conditions", flattenPolicyConditionsLike(d, resp.Conditions))
 
 	return nil
 }
 
-func resourcePolicyForwardingRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyForwardingRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ruleID := d.Id()
 	log.Printf("[INFO] Updating policy forwarding rule ID: %v\n", ruleID)
-	req, err := expandCreatePolicyRule(d)
+	req, err := expandCreatePolicyForwardingRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if ValidateConditions(req.Conditions, zClient) {
-		if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
-			if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-				d.SetId("")
-				return nil
-			}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
 		}
+		return diag.FromErr(err)
+	}
 
-		if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
-			return err
-		}
-		if d.HasChange("rule_order") {
-			order, ok := d.GetOk("rule_order")
-			if ok {
-				reorder(order, globalPolicySet.ID, "CLIENT_FORWARDING_POLICY", ruleID, zClient)
+	if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	if d.HasChange("rule_order") {
+		order, ok := d.GetOk("rule_order")
+		if ok {
+			if err := reorder(order, globalPolicySet.ID, "CLIENT_FORWARDING_POLICY", ruleID, zClient); err != nil {
+				return diag.FromErr(err)
 			}
 		}
-		return resourcePolicyForwardingRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy forwarding (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, globalPolicySet.ID, "CLIENT_FORWARDING_POLICY", ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyForwardingRuleRead(ctx, d, m)
 }
 
-func resourcePolicyForwardingRuleDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyForwardingRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting policy forwarding rule with id %v\n", d.Id())
 
 	if _, err := zClient.policysetcontroller.Delete(globalPolicySet.ID, d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -16,15 +17,15 @@ func TestAccPolicyForwardingRuleBasic(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	randDesc := acctest.RandString(20)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckPolicyForwardingRuleDestroy,
+		CheckDestroy: testAccCheckPolicyForwardingRuleDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckPolicyForwardingRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyForwardingRuleExists(resourceTypeAndName),
+					testAccCheckPolicyForwardingRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "BYPASS"),
@@ -37,7 +38,7 @@ func TestAccPolicyForwardingRuleBasic(t *testing.T) {
 			{
 				Config: testAccCheckPolicyForwardingRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyForwardingRuleExists(resourceTypeAndName),
+					testAccCheckPolicyForwardingRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "BYPASS"),
@@ -49,32 +50,34 @@ func TestAccPolicyForwardingRuleBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckPolicyForwardingRuleDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
-	accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
-	if err != nil {
-		return fmt.Errorf("failed fetching resource CLIENT_FORWARDING_POLICY. Recevied error: %s", err)
-	}
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAPolicyAccessRule {
-			continue
+func testAccCheckPolicyForwardingRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource CLIENT_FORWARDING_POLICY. Recevied error: %s", err)
 		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyAccessRule {
+				continue
+			}
 
-		rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
+			rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("policy forwarding rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("policy forwarding rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckPolicyForwardingRuleExists(resource string) resource.TestCheckFunc {
+func testAccCheckPolicyForwardingRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -84,7 +87,7 @@ func testAccCheckPolicyForwardingRuleExists(resource string) resource.TestCheckF
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		resp, _, err := apiClient.policysetcontroller.GetByPolicyType("CLIENT_FORWARDING_POLICY")
 		if err != nil {
 			return fmt.Errorf("failed fetching resource CLIENT_FORWARDING_POLICY. Recevied error: %s", err)
@@ -1,97 +1,89 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
 func resourcePolicyInspectionRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyInspectionRuleCreate,
-		Read:   resourcePolicyInspectionRuleRead,
-		Update: resourcePolicyInspectionRuleUpdate,
-		Delete: resourcePolicyInspectionRuleDelete,
+		CreateContext: resourcePolicyInspectionRuleCreate,
+		ReadContext:   resourcePolicyInspectionRuleRead,
+		UpdateContext: resourcePolicyInspectionRuleUpdate,
+		DeleteContext: resourcePolicyInspectionRuleDelete,
+		CustomizeDiff: customizeDiffPolicyConditions,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			StateContext: importPolicyStateContextFunc([]string{"INSPECTION_POLICY"}),
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("INSPECTION_POLICY")),
 		},
 
 		Schema: MergeSchema(
 			CommonPolicySchema(),
 			map[string]*schema.Schema{
 				"action": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "This is for providing the rule action.",
-					ValidateFunc: validation.StringInSlice([]string{
-						"INSPECT",
-						"BYPASS_INSPECT",
-					}, false),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["INSPECTION_POLICY"].actions, false),
 				},
-				"conditions": GetPolicyConditionsSchema([]string{
-					"APP",
-					"APP_GROUP",
-					"CLIENT_TYPE",
-					"CLOUD_CONNECTOR_GROUP",
-					"IDP",
-					"POSTURE",
-					"SAML",
-					"SCIM",
-					"SCIM_GROUP",
-					"TRUSTED_NETWORK",
-				}),
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["INSPECTION_POLICY"].objectTypes),
 			},
 		),
 	}
 }
 
-func resourcePolicyInspectionRuleCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyInspectionRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req, err := expandCreatePolicyInspectionRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
 	}
 	log.Printf("[INFO] Creating zpa policy inspection rule with request\n%+v\n", req)
 
-	if ValidateConditions(req.Conditions, zClient) {
-		policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
-		if err != nil {
-			return err
-		}
-		d.SetId(policysetcontroller.ID)
-		order, ok := d.GetOk("rule_order")
-		if ok {
-			reorder(order, policysetcontroller.PolicySetID, "INSPECTION_POLICY", policysetcontroller.ID, zClient)
+	policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
+	if err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	d.SetId(policysetcontroller.ID)
+	order, ok := d.GetOk("rule_order")
+	if ok {
+		if err := reorder(order, policysetcontroller.PolicySetID, "INSPECTION_POLICY", policysetcontroller.ID, zClient); err != nil {
+			return diag.FromErr(err)
 		}
-		return resourcePolicyInspectionRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy inspection (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, policysetcontroller.PolicySetID, "INSPECTION_POLICY", policysetcontroller.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyInspectionRuleRead(ctx, d, m)
 }
 
-func resourcePolicyInspectionRuleRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyInspectionRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("INSPECTION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting Policy Set Rule: globalPolicySet:%s id: %s\n", globalPolicySet.ID, d.Id())
 	resp, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, d.Id())
 	if err != nil {
-		if obj, ok := err.(*client.ErrorResponse); ok && obj.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Got Policy Set Inspection Rule:\n%+v\n", resp)
@@ -107,58 +99,64 @@ func resourcePolicyInspectionRuleRead(d *schema.ResourceData, m interface{}) err
 	_ = d.Set("priority", resp.Priority)
 	_ = d.Set("zpn_inspection_profile_id", resp.ZpnInspectionProfileID)
 	_ = d.Set("rule_order", resp.RuleOrder)
-	_ = d.Set("conditions", flattenPolicyConditions(resp.Conditions))
+	if err := readRulePlacement(d, "INSPECTION_POLICY", resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("conditions", flattenPolicyConditionsLike(d, resp.Conditions))
 
 	return nil
 }
 
-func resourcePolicyInspectionRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyInspectionRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("INSPECTION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ruleID := d.Id()
 	log.Printf("[INFO] Updating policy inspection rule ID: %v\n", ruleID)
-	req, err := expandCreatePolicyRule(d)
+	req, err := expandCreatePolicyInspectionRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if ValidateConditions(req.Conditions, zClient) {
-		if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
-			if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-				d.SetId("")
-				return nil
-			}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
 		}
+		return diag.FromErr(err)
+	}
 
-		if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
-			return err
-		}
-		if d.HasChange("rule_order") {
-			order, ok := d.GetOk("rule_order")
-			if ok {
-				reorder(order, globalPolicySet.ID, "INSPECTION_POLICY", ruleID, zClient)
+	if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	if d.HasChange("rule_order") {
+		order, ok := d.GetOk("rule_order")
+		if ok {
+			if err := reorder(order, globalPolicySet.ID, "INSPECTION_POLICY", ruleID, zClient); err != nil {
+				return diag.FromErr(err)
 			}
 		}
-		return resourcePolicyInspectionRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy inspection (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, globalPolicySet.ID, "INSPECTION_POLICY", ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyInspectionRuleRead(ctx, d, m)
 }
 
-func resourcePolicyInspectionRuleDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyInspectionRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("INSPECTION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting policy inspection rule with id %v\n", d.Id())
 
 	if _, err := zClient.policysetcontroller.Delete(globalPolicySet.ID, d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
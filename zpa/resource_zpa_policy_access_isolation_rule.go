@@ -1,97 +1,88 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
 func resourcePolicyIsolationRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyIsolationRuleCreate,
-		Read:   resourcePolicyIsolationRuleRead,
-		Update: resourcePolicyIsolationRuleUpdate,
-		Delete: resourcePolicyIsolationRuleDelete,
+		CreateContext: resourcePolicyIsolationRuleCreate,
+		ReadContext:   resourcePolicyIsolationRuleRead,
+		UpdateContext: resourcePolicyIsolationRuleUpdate,
+		DeleteContext: resourcePolicyIsolationRuleDelete,
+		CustomizeDiff: customizeDiffPolicyConditions,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			StateContext: importPolicyStateContextFunc([]string{"ISOLATE", "BYPASS_ISOLATE"}),
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("ISOLATION_POLICY")),
 		},
 
 		Schema: MergeSchema(
 			CommonPolicySchema(),
 			map[string]*schema.Schema{
 				"action": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "  This is for providing the rule action.",
-					ValidateFunc: validation.StringInSlice([]string{
-						"ISOLATE",
-						"BYPASS_ISOLATE",
-					}, false),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "  This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["ISOLATION_POLICY"].actions, false),
 				},
-				"conditions": GetPolicyConditionsSchema([]string{
-					"APP",
-					"CLIENT_TYPE",
-					"EDGE_CONNECTOR_GROUP",
-					"POSTURE",
-					"MACHINE_GRP",
-					"TRUSTED_NETWORK",
-					"PLATFORM",
-					"IDP",
-					"SAML",
-					"SCIM",
-					"SCIM_GROUP",
-				}),
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["ISOLATION_POLICY"].objectTypes),
 			},
 		),
 	}
 }
 
-func resourcePolicyIsolationRuleCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyIsolationRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req, err := expandCreatePolicyIsolationRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
 	}
 	log.Printf("[INFO] Creating zpa policy isolation rule with request\n%+v\n", req)
-	if ValidateConditions(req.Conditions, zClient) {
-		policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
-		if err != nil {
-			return err
-		}
-		d.SetId(policysetcontroller.ID)
-		order, ok := d.GetOk("rule_order")
-		if ok {
-			reorder(order, policysetcontroller.PolicySetID, "ISOLATION_POLICY", policysetcontroller.ID, zClient)
+	policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
+	if err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	d.SetId(policysetcontroller.ID)
+	order, ok := d.GetOk("rule_order")
+	if ok {
+		if err := reorder(order, policysetcontroller.PolicySetID, "ISOLATION_POLICY", policysetcontroller.ID, zClient); err != nil {
+			return diag.FromErr(err)
 		}
-		return resourcePolicyIsolationRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy isolation (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, policysetcontroller.PolicySetID, "ISOLATION_POLICY", policysetcontroller.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyIsolationRuleRead(ctx, d, m)
 }
 
-func resourcePolicyIsolationRuleRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyIsolationRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting Policy Set Rule: globalPolicySet:%s id: %s\n", globalPolicySet.ID, d.Id())
 	resp, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, d.Id())
 	if err != nil {
-		if obj, ok := err.(*client.ErrorResponse); ok && obj.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Got Policy Set Isolation Rule:\n%+v\n", resp)
@@ -105,58 +96,64 @@ func resourcePolicyIsolationRuleRead(d *schema.ResourceData, m interface{}) erro
 	_ = d.Set("zpn_cbi_profile_id", resp.ZpnCbiProfileID)
 	_ = d.Set("zpn_isolation_profile_id", resp.ZpnIsolationProfileID)
 	_ = d.Set("rule_order", resp.RuleOrder)
-	_ = d.Set("conditions", flattenPolicyConditions(resp.Conditions))
+	if err := readRulePlacement(d, "ISOLATION_POLICY", resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("conditions", flattenPolicyConditionsLike(d, resp.Conditions))
 
 	return nil
 }
 
-func resourcePolicyIsolationRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyIsolationRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ruleID := d.Id()
 	log.Printf("[INFO] Updating policy isolation rule ID: %v\n", ruleID)
-	req, err := expandCreatePolicyRule(d)
+	req, err := expandCreatePolicyIsolationRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if ValidateConditions(req.Conditions, zClient) {
-		if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
-			if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-				d.SetId("")
-				return nil
-			}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
 		}
+		return diag.FromErr(err)
+	}
 
-		if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
-			return err
-		}
-		if d.HasChange("rule_order") {
-			order, ok := d.GetOk("rule_order")
-			if ok {
-				reorder(order, globalPolicySet.ID, "ISOLATION_POLICY", ruleID, zClient)
+	if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	if d.HasChange("rule_order") {
+		order, ok := d.GetOk("rule_order")
+		if ok {
+			if err := reorder(order, globalPolicySet.ID, "ISOLATION_POLICY", ruleID, zClient); err != nil {
+				return diag.FromErr(err)
 			}
 		}
-		return resourcePolicyIsolationRuleRead(d, m)
-	} else {
-		return fmt.Errorf("couldn't validate the zpa policy isolation (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
 	}
-
+	if err := placeRule(d, globalPolicySet.ID, "ISOLATION_POLICY", ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyIsolationRuleRead(ctx, d, m)
 }
 
-func resourcePolicyIsolationRuleDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyIsolationRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting policy isolation rule with id %v\n", d.Id())
 
 	if _, err := zClient.policysetcontroller.Delete(globalPolicySet.ID, d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -16,15 +17,15 @@ func TestAccPolicyIsolationRuleBasic(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	randDesc := acctest.RandString(20)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckPolicyIsolationRuleDestroy,
+		CheckDestroy: testAccCheckPolicyIsolationRuleDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckPolicyIsolationRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyIsolationRuleExists(resourceTypeAndName),
+					testAccCheckPolicyIsolationRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "ISOLATE"),
@@ -37,7 +38,7 @@ func TestAccPolicyIsolationRuleBasic(t *testing.T) {
 			{
 				Config: testAccCheckPolicyIsolationRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyIsolationRuleExists(resourceTypeAndName),
+					testAccCheckPolicyIsolationRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "ISOLATE"),
@@ -49,32 +50,34 @@ func TestAccPolicyIsolationRuleBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckPolicyIsolationRuleDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
-	accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
-	if err != nil {
-		return fmt.Errorf("failed fetching resource ISOLATION_POLICY. Received error: %s", err)
-	}
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAPolicyIsolationRule {
-			continue
+func testAccCheckPolicyIsolationRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource ISOLATION_POLICY. Received error: %s", err)
 		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyIsolationRule {
+				continue
+			}
 
-		rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
+			rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("policy isolation rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("policy isolation rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckPolicyIsolationRuleExists(resource string) resource.TestCheckFunc {
+func testAccCheckPolicyIsolationRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -84,7 +87,7 @@ func testAccCheckPolicyIsolationRuleExists(resource string) resource.TestCheckFu
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		resp, _, err := apiClient.policysetcontroller.GetByPolicyType("ISOLATION_POLICY")
 		if err != nil {
 			return fmt.Errorf("failed fetching resource ISOLATION_POLICY. Recevied error: %s", err)
@@ -1,13 +1,14 @@
 package zpa
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 )
 
@@ -32,127 +33,101 @@ func validateAccessPolicyRuleOrder(order string, zClient *Client) error {
 
 func resourcePolicyAccessRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyAccessCreate,
-		Read:   resourcePolicyAccessRead,
-		Update: resourcePolicyAccessUpdate,
-		Delete: resourcePolicyAccessDelete,
+		CreateContext: resourcePolicyAccessCreate,
+		ReadContext:   resourcePolicyAccessRead,
+		UpdateContext: resourcePolicyAccessUpdate,
+		DeleteContext: resourcePolicyAccessDelete,
+		CustomizeDiff: customizeDiffPolicyConditions,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			StateContext: importPolicyStateContextFunc([]string{"ACCESS_POLICY", "GLOBAL_POLICY"}),
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("ACCESS_POLICY")),
 		},
 
 		Schema: MergeSchema(
 			CommonPolicySchema(), map[string]*schema.Schema{
 				"action": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "  This is for providing the rule action.",
-					ValidateFunc: validation.StringInSlice([]string{
-						"ALLOW",
-						"DENY",
-						"REQUIRE_APPROVAL",
-					}, false),
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "  This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["ACCESS_POLICY"].actions, false),
 				},
-				"app_server_groups": {
-					Type:        schema.TypeSet,
-					Optional:    true,
-					Computed:    true,
-					Description: "List of the server group IDs.",
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"id": {
-								Type:     schema.TypeSet,
-								Optional: true,
-								Elem: &schema.Schema{
-									Type: schema.TypeString,
-								},
-							},
-						},
-					},
-				},
-				"app_connector_groups": {
-					Type:        schema.TypeSet,
-					Optional:    true,
-					Computed:    true,
-					Description: "List of app-connector IDs.",
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"id": {
-								Type:     schema.TypeSet,
-								Optional: true,
-								Elem: &schema.Schema{
-									Type: schema.TypeString,
-								},
-							},
-						},
+				"app_server_groups":    policyRuleGroupsSchema("List of the server group IDs."),
+				"app_connector_groups": policyRuleGroupsSchema("List of app-connector IDs."),
+				"conditions":           GetPolicyConditionsSchema(policyRuleTypes["ACCESS_POLICY"].objectTypes),
+			},
+		),
+	}
+}
+
+// policyRuleGroupsSchema is the schema of the app server groups and app connector groups of access rules.
+func policyRuleGroupsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
 				},
-				"conditions": GetPolicyConditionsSchema([]string{
-					"USER",
-					"USER_GROUP",
-					"APP",
-					"APP_GROUP",
-					"LOCATION",
-					"IDP",
-					"SAML",
-					"SCIM",
-					"SCIM_GROUP",
-					"CLIENT_TYPE",
-					"POSTURE",
-					"TRUSTED_NETWORK",
-					"BRANCH_CONNECTOR_GROUP",
-					"EDGE_CONNECTOR_GROUP",
-					"MACHINE_GRP",
-					"COUNTRY_CODE",
-					"PLATFORM",
-				}),
 			},
-		),
+		},
 	}
 }
 
-func resourcePolicyAccessCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req, err := expandCreatePolicyRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
 	}
 	log.Printf("[INFO] Creating zpa policy rule with request\n%+v\n", req)
 	if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
-		return err
-	}
-	if !ValidateConditions(req.Conditions, zClient) {
-		return fmt.Errorf("couldn't validate the zpa policy rule (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
+		return diag.FromErr(err)
 	}
 	policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
 	}
 	d.SetId(policysetcontroller.ID)
 	order, ok := d.GetOk("rule_order")
 	if ok {
-		reorder(order, policysetcontroller.PolicySetID, "ACCESS_POLICY", policysetcontroller.ID, zClient)
+		if err := reorder(order, policysetcontroller.PolicySetID, "ACCESS_POLICY", policysetcontroller.ID, zClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := placeRule(d, policysetcontroller.PolicySetID, "ACCESS_POLICY", policysetcontroller.ID, zClient); err != nil {
+		return diag.FromErr(err)
 	}
-	return resourcePolicyAccessRead(d, m)
+	return resourcePolicyAccessRead(ctx, d, m)
 }
 
-func resourcePolicyAccessRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Getting Policy Set Rule: globalPolicySet:%s id: %s\n", globalPolicySet.ID, d.Id())
 	resp, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, d.Id())
 	if err != nil {
-		if obj, ok := err.(*client.ErrorResponse); ok && obj.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Got Policy Set Rule:\n%+v\n", resp)
@@ -168,62 +143,70 @@ func resourcePolicyAccessRead(d *schema.ResourceData, m interface{}) error {
 	_ = d.Set("policy_type", resp.PolicyType)
 	_ = d.Set("priority", resp.Priority)
 	_ = d.Set("rule_order", resp.RuleOrder)
+	if err := readRulePlacement(d, "ACCESS_POLICY", resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
 	_ = d.Set("lss_default_rule", resp.LSSDefaultRule)
-	_ = d.Set("conditions", flattenPolicyConditions(resp.Conditions))
+	_ = d.Set("conditions", flattenPolicyConditionsLike(d, resp.Conditions))
 	_ = d.Set("app_server_groups", flattenPolicyRuleServerGroups(resp.AppServerGroups))
 	_ = d.Set("app_connector_groups", flattenPolicyRuleAppConnectorGroups(resp.AppConnectorGroups))
 
 	return nil
 }
 
-func resourcePolicyAccessUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ruleID := d.Id()
 	log.Printf("[INFO] Updating policy rule ID: %v\n", ruleID)
 	req, err := expandCreatePolicyRule(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
-		return err
+	if diags := validatePolicyConditionsOnApply(d, zClient); diags.HasError() {
+		return diags
 	}
-	if !ValidateConditions(req.Conditions, zClient) {
-		return fmt.Errorf("couldn't validate the zpa policy rule (%s) operands, please make sure you are using valid inputs for APP type, LHS & RHS", req.Name)
+	if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
+		return diag.FromErr(err)
 	}
 	if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
 	}
 	if d.HasChange("rule_order") {
 		order, ok := d.GetOk("rule_order")
 		if ok {
-			reorder(order, globalPolicySet.ID, "ACCESS_POLICY", ruleID, zClient)
+			if err := reorder(order, globalPolicySet.ID, "ACCESS_POLICY", ruleID, zClient); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
-	return resourcePolicyAccessRead(d, m)
+	if err := placeRule(d, globalPolicySet.ID, "ACCESS_POLICY", ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyAccessRead(ctx, d, m)
 }
 
-func resourcePolicyAccessDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourcePolicyAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting policy set rule with id %v\n", d.Id())
 
 	if _, err := zClient.policysetcontroller.Delete(globalPolicySet.ID, d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
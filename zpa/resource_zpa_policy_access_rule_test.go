@@ -2,10 +2,12 @@ package zpa
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -23,15 +25,15 @@ func TestAccPolicyAccessRuleBasic(t *testing.T) {
 	segmentGroupTypeAndName, _, segmentGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 	segmentGroupHCL := testAccCheckSegmentGroupConfigure(segmentGroupTypeAndName, segmentGroupGeneratedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckPolicyAccessRuleDestroy,
+		CheckDestroy: testAccCheckPolicyAccessRuleDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckPolicyAccessRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc, appConnectorGroupHCL, appConnectorGroupTypeAndName, segmentGroupHCL, segmentGroupTypeAndName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyAccessRuleExists(resourceTypeAndName),
+					testAccCheckPolicyAccessRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "ALLOW"),
@@ -45,7 +47,7 @@ func TestAccPolicyAccessRuleBasic(t *testing.T) {
 			{
 				Config: testAccCheckPolicyAccessRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc, appConnectorGroupHCL, appConnectorGroupTypeAndName, segmentGroupHCL, segmentGroupTypeAndName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyAccessRuleExists(resourceTypeAndName),
+					testAccCheckPolicyAccessRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "ALLOW"),
@@ -58,32 +60,34 @@ func TestAccPolicyAccessRuleBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckPolicyAccessRuleDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
-	accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
-	if err != nil {
-		return fmt.Errorf("failed fetching resource ACCESS_POLICY. Recevied error: %s", err)
-	}
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAPolicyAccessRule {
-			continue
+func testAccCheckPolicyAccessRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource ACCESS_POLICY. Recevied error: %s", err)
 		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyAccessRule {
+				continue
+			}
 
-		rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
+			rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("policy access rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("policy access rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckPolicyAccessRuleExists(resource string) resource.TestCheckFunc {
+func testAccCheckPolicyAccessRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -93,7 +97,7 @@ func testAccCheckPolicyAccessRuleExists(resource string) resource.TestCheckFunc
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		resp, _, err := apiClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
 		if err != nil {
 			return fmt.Errorf("failed fetching resource ACCESS_POLICY. Recevied error: %s", err)
@@ -207,3 +211,164 @@ resource "%s" "%s" {
 		segmentGroupTypeAndName,
 	)
 }
+
+func TestAccPolicyAccessRuleInvalidOperands(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, `
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_zapp"
+		}
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = "216196257331280999"
+		}`),
+				ExpectError: regexp.MustCompile(`conditions\.0\.operands\.1\.rhs: when operand object type is APP_GROUP rhs must be Segment Group ID`),
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, `
+		operands {
+			object_type = "APP"
+			lhs         = "name"
+			rhs         = "216196257331280999"
+		}
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs_list    = ["zpn_client_type_zapp", "zpn_client_type_unknown"]
+		}`),
+				ExpectError: regexp.MustCompile(`(?s)2 policy rule operands are invalid:.*conditions\.0\.operands\.0\.lhs: .*conditions\.0\.operands\.1\.rhs_list: .*"zpn_client_type_unknown"`),
+			},
+		},
+	})
+}
+
+func TestAccPolicyAccessRuleOperandNames(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	var locations *fakeSummaries
+	resourceTypeAndName := resourcetype.ZPAPolicyAccessRule + ".invalid"
+	names := `
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs_name    = "BD_Okta_Users"
+			rhs_name    = "engineering"
+		}
+		operands {
+			object_type = "POSTURE"
+			lhs_name    = "CrowdStrike_ZPA_ZTA_40 (zscalertwo.net)"
+			rhs         = "true"
+		}
+		operands {
+			object_type = "LOCATION"
+			lhs         = "id"
+			rhs_name    = "BD-Location01"
+		}`
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, names),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceTypeAndName, "conditions.0.operands.0.lhs"),
+					resource.TestCheckResourceAttrSet(resourceTypeAndName, "conditions.0.operands.0.rhs"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.0.operands.0.rhs_name", "engineering"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.0.operands.1.lhs", "13ba3d97-aefb-4acc-9e54-6cc230dee4a5"),
+					resource.TestCheckResourceAttrSet(resourceTypeAndName, "conditions.0.operands.2.rhs"),
+				),
+			},
+			{
+				PreConfig: func() {
+					locations = provider.Meta().(*Client).locationcontroller.(*fakeSummaries)
+					locations.calls = 0
+				},
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, names),
+				Check: func(*terraform.State) error {
+					if locations.calls != 0 {
+						return fmt.Errorf("expected the unchanged names not to be looked up again, the locations were listed %d times", locations.calls)
+					}
+					return nil
+				},
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, `
+		operands {
+			object_type = "LOCATION"
+			lhs         = "id"
+			rhs_name    = "BD-Location99"
+		}`),
+				ExpectError: regexp.MustCompile(`conditions\.0\.operands\.0\.rhs_name: .*no object named "BD-Location99" was found`),
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, `
+		operands {
+			object_type = "CLIENT_TYPE"
+			rhs         = "zpn_client_type_zapp"
+		}`),
+				ExpectError: regexp.MustCompile(`conditions\.0\.operands\.0\.lhs: .*lhs must be set when lhs_name isn't`),
+			},
+		},
+	})
+}
+
+func TestAccPolicyAccessRuleRHSList(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyAccessRule + ".invalid"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, `
+		operands {
+			object_type = "PLATFORM"
+			lhs         = "windows"
+			rhs         = "true"
+		}
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs_list    = ["zpn_client_type_zapp", "zpn_client_type_exporter", "zpn_client_type_machine_tunnel"]
+		}
+		operands {
+			object_type = "PLATFORM"
+			lhs         = "mac"
+			rhs         = "true"
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.0.operands.#", "3"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.0.operands.1.rhs_list.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyAccessRuleInvalidOperandsConfigure(rName, operands string) string {
+	return fmt.Sprintf(`
+data "zpa_policy_type" "access_policy" {
+	policy_type = "ACCESS_POLICY"
+}
+
+resource "%s" "invalid" {
+	name          = "%s"
+	action        = "ALLOW"
+	operator      = "AND"
+	policy_set_id = data.zpa_policy_type.access_policy.id
+	conditions {
+		operator = "OR"
+%s
+	}
+}
+`, resourcetype.ZPAPolicyAccessRule, rName, operands)
+}
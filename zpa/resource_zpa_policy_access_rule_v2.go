@@ -0,0 +1,533 @@
+package zpa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+// policyCriterion compiles one typed attribute of a policy rule into the operands of a condition, and back.
+// Operands are sorted so that the same configuration always compiles into the same conditions.
+type policyCriterion struct {
+	attribute  string
+	objectType string
+	expand     func(v interface{}) []policysetcontroller.Operands
+	flatten    func(operands []policysetcontroller.Operands) interface{}
+}
+
+// policyAccessRuleV2Criteria are compiled in this order, each into its own condition whose operands are ORed.
+var policyAccessRuleV2Criteria = []policyCriterion{
+	idListCriterion("app_segment_ids", "APP"),
+	idListCriterion("segment_group_ids", "APP_GROUP"),
+	{
+		attribute:  "scim_group_ids",
+		objectType: "SCIM_GROUP",
+		expand: func(v interface{}) []policysetcontroller.Operands {
+			var operands []policysetcontroller.Operands
+			for _, group := range v.(*schema.Set).List() {
+				group := group.(map[string]interface{})
+				for _, id := range SetToStringSlice(group["ids"].(*schema.Set)) {
+					operands = append(operands, policysetcontroller.Operands{ObjectType: "SCIM_GROUP", LHS: group["idp_id"].(string), RHS: id})
+				}
+			}
+			return sortOperands(operands)
+		},
+		flatten: func(operands []policysetcontroller.Operands) interface{} {
+			ids := map[string][]string{}
+			for _, operand := range operands {
+				ids[operand.LHS] = append(ids[operand.LHS], operand.RHS)
+			}
+			var groups []interface{}
+			for idpID, groupIDs := range ids {
+				groups = append(groups, map[string]interface{}{"idp_id": idpID, "ids": groupIDs})
+			}
+			return groups
+		},
+	},
+	{
+		attribute:  "saml_attributes",
+		objectType: "SAML",
+		expand: func(v interface{}) []policysetcontroller.Operands {
+			var operands []policysetcontroller.Operands
+			for id, value := range v.(map[string]interface{}) {
+				operands = append(operands, policysetcontroller.Operands{ObjectType: "SAML", LHS: id, RHS: value.(string)})
+			}
+			return sortOperands(operands)
+		},
+		flatten: func(operands []policysetcontroller.Operands) interface{} {
+			attributes := map[string]interface{}{}
+			for _, operand := range operands {
+				attributes[operand.LHS] = operand.RHS
+			}
+			return attributes
+		},
+	},
+	idListCriterion("client_types", "CLIENT_TYPE"),
+	flagListCriterion("platforms", "PLATFORM"),
+	{
+		attribute:  "posture",
+		objectType: "POSTURE",
+		expand: func(v interface{}) []policysetcontroller.Operands {
+			var operands []policysetcontroller.Operands
+			for _, posture := range v.(*schema.Set).List() {
+				posture := posture.(map[string]interface{})
+				operands = append(operands, policysetcontroller.Operands{ObjectType: "POSTURE", LHS: posture["udid"].(string), RHS: strconv.FormatBool(posture["expected"].(bool))})
+			}
+			return sortOperands(operands)
+		},
+		flatten: func(operands []policysetcontroller.Operands) interface{} {
+			var postures []interface{}
+			for _, operand := range operands {
+				postures = append(postures, map[string]interface{}{"udid": operand.LHS, "expected": operand.RHS == "true"})
+			}
+			return postures
+		},
+	},
+	flagListCriterion("trusted_networks", "TRUSTED_NETWORK"),
+	flagListCriterion("country_codes", "COUNTRY_CODE"),
+}
+
+// idListCriterion is a set of IDs, each matched by an operand with the lhs "id".
+func idListCriterion(attribute, objectType string) policyCriterion {
+	return policyCriterion{
+		attribute:  attribute,
+		objectType: objectType,
+		expand: func(v interface{}) []policysetcontroller.Operands {
+			var operands []policysetcontroller.Operands
+			for _, id := range SetToStringSlice(v.(*schema.Set)) {
+				operands = append(operands, policysetcontroller.Operands{ObjectType: objectType, LHS: "id", RHS: id})
+			}
+			return sortOperands(operands)
+		},
+		flatten: func(operands []policysetcontroller.Operands) interface{} {
+			ids := make([]string, len(operands))
+			for i, operand := range operands {
+				ids[i] = operand.RHS
+			}
+			return ids
+		},
+	}
+}
+
+// flagListCriterion is a set of keys, each matched by an operand with the key as lhs and the rhs "true".
+func flagListCriterion(attribute, objectType string) policyCriterion {
+	return policyCriterion{
+		attribute:  attribute,
+		objectType: objectType,
+		expand: func(v interface{}) []policysetcontroller.Operands {
+			var operands []policysetcontroller.Operands
+			for _, key := range SetToStringSlice(v.(*schema.Set)) {
+				operands = append(operands, policysetcontroller.Operands{ObjectType: objectType, LHS: key, RHS: "true"})
+			}
+			return sortOperands(operands)
+		},
+		flatten: func(operands []policysetcontroller.Operands) interface{} {
+			keys := make([]string, len(operands))
+			for i, operand := range operands {
+				keys[i] = operand.LHS
+			}
+			return keys
+		},
+	}
+}
+
+func sortOperands(operands []policysetcontroller.Operands) []policysetcontroller.Operands {
+	sort.Slice(operands, func(i, j int) bool {
+		if operands[i].LHS != operands[j].LHS {
+			return operands[i].LHS < operands[j].LHS
+		}
+		return operands[i].RHS < operands[j].RHS
+	})
+	return operands
+}
+
+// expandPolicyCriteria compiles the typed attributes into conditions, the criteria that aren't set are left out.
+func expandPolicyCriteria(criteria []policyCriterion, get func(string) interface{}) []policysetcontroller.Conditions {
+	var conditions []policysetcontroller.Conditions
+	for _, criterion := range criteria {
+		if operands := criterion.expand(get(criterion.attribute)); len(operands) > 0 {
+			conditions = append(conditions, policysetcontroller.Conditions{Operator: "OR", Operands: operands})
+		}
+	}
+	return conditions
+}
+
+// flattenPolicyCriteria sets the typed attributes from the operands of the conditions. Operands the criteria
+// don't cover, and those of negated conditions, can't be represented and are reported as ignored.
+func flattenPolicyCriteria(d *schema.ResourceData, criteria []policyCriterion, conditions []policysetcontroller.Conditions) []string {
+	operands := map[string][]policysetcontroller.Operands{}
+	var ignored []string
+	for _, condition := range conditions {
+		for _, operand := range condition.Operands {
+			if condition.Negated {
+				ignored = append(ignored, fmt.Sprintf("negated %s operand %s=%s", operand.ObjectType, operand.LHS, operand.RHS))
+				continue
+			}
+			operands[operand.ObjectType] = append(operands[operand.ObjectType], operand)
+		}
+	}
+	for _, criterion := range criteria {
+		_ = d.Set(criterion.attribute, criterion.flatten(operands[criterion.objectType]))
+		delete(operands, criterion.objectType)
+	}
+	for _, unsupported := range operands {
+		for _, operand := range unsupported {
+			ignored = append(ignored, fmt.Sprintf("%s operand %s=%s", operand.ObjectType, operand.LHS, operand.RHS))
+		}
+	}
+	sort.Strings(ignored)
+	return ignored
+}
+
+func resourcePolicyAccessRuleV2() *schema.Resource {
+	policySchema := CommonPolicySchema()
+	for _, key := range []string{"operator", "reauth_default_rule", "reauth_idle_timeout", "reauth_timeout", "zpn_isolation_profile_id", "zpn_cbi_profile_id", "zpn_inspection_profile_id"} {
+		delete(policySchema, key)
+	}
+	return &schema.Resource{
+		CreateContext: resourcePolicyAccessRuleV2Create,
+		ReadContext:   resourcePolicyAccessRuleV2Read,
+		UpdateContext: resourcePolicyAccessRuleV2Update,
+		DeleteContext: resourcePolicyAccessDelete,
+		CustomizeDiff: customizeDiffPolicyAccessRuleV2,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("ACCESS_POLICY")),
+		},
+
+		Schema: MergeSchema(
+			policySchema, map[string]*schema.Schema{
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["ACCESS_POLICY"].actions, false),
+				},
+				"app_server_group_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "List of the server group IDs.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"app_connector_group_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "List of the app connector group IDs.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"app_segment_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The IDs of the application segments the rule matches.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"segment_group_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The IDs of the segment groups the rule matches.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"scim_group_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The IDs of the SCIM groups the rule matches, by IdP.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"idp_id": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"ids": {
+								Type:     schema.TypeSet,
+								Required: true,
+								MinItems: 1,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"saml_attributes": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "The SAML attribute values the rule matches, keyed by SAML attribute ID.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"client_types": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The client types the rule matches.",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(policyClientTypes, false),
+					},
+				},
+				"platforms": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The platforms the rule matches.",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(platformOperandLHS, false),
+					},
+				},
+				"posture": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The posture profiles the rule matches.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"udid": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The posture UDID of the posture profile.",
+							},
+							"expected": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: "Whether the device must pass or fail the posture check.",
+							},
+						},
+					},
+				},
+				"trusted_networks": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The network IDs of the trusted networks the rule matches.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"country_codes": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The ISO 3166-1 alpha-2 codes of the countries the rule matches.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+							if _, ok := countryCodes[i.(string)]; !ok {
+								return nil, []error{fmt.Errorf("%s: %q isn't an ISO 3166-1 alpha-2 country code", k, i)}
+							}
+							return nil, nil
+						},
+					},
+				},
+			},
+		),
+	}
+}
+
+// customizeDiffPolicyAccessRuleV2 validates the IDs of the typed attributes at plan time, with the same lookups
+// as the operands of the other policy rules.
+func customizeDiffPolicyAccessRuleV2(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	errs := validatePolicyAccessRuleV2Criteria(d.Get, func(attribute string) bool {
+		return d.NewValueKnown(attribute) && (d.Id() == "" || d.HasChange(attribute))
+	}, m.(*Client).withContext(ctx))
+	if len(errs) == 0 {
+		return nil
+	}
+	// a CustomizeDiff error carries a single path, the text of errs lists every invalid operand
+	if len(errs) == 1 {
+		return cty.GetAttrPath(errs[0].key).NewError(errs[0])
+	}
+	return cty.GetAttrPath(errs[0].key).NewError(errs)
+}
+
+// validatePolicyAccessRuleV2OnApply validates the criteria again before a rule is created or they are updated,
+// customizeDiffPolicyAccessRuleV2 skips the criteria that reference values not known at plan time.
+func validatePolicyAccessRuleV2OnApply(d *schema.ResourceData, zClient *Client) diag.Diagnostics {
+	errs := validatePolicyAccessRuleV2Criteria(d.Get, func(attribute string) bool {
+		return d.Id() == "" || d.HasChange(attribute)
+	}, zClient)
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(err.key),
+		})
+	}
+	return diags
+}
+
+// validatePolicyAccessRuleV2Criteria returns one error per invalid operand of the criteria validate reports true for.
+func validatePolicyAccessRuleV2Criteria(get func(string) interface{}, validate func(attribute string) bool, zClient *Client) policyOperandErrors {
+	var errs policyOperandErrors
+	for _, criterion := range policyAccessRuleV2Criteria {
+		if !validate(criterion.attribute) {
+			continue
+		}
+		for _, operand := range criterion.expand(get(criterion.attribute)) {
+			if err := validateOperand(operand, zClient); err != nil {
+				err.key = criterion.attribute
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+func resourcePolicyAccessRuleV2Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+
+	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req := expandPolicyAccessRuleV2(d)
+	req.PolicySetID = globalPolicySet.ID
+	if diags := validatePolicyAccessRuleV2OnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	log.Printf("[INFO] Creating zpa policy rule with request\n%+v\n", req)
+	if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	policysetcontroller, _, err := zClient.policysetcontroller.Create(req)
+	if err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	d.SetId(policysetcontroller.ID)
+	order, ok := d.GetOk("rule_order")
+	if ok {
+		if err := reorder(order, policysetcontroller.PolicySetID, "ACCESS_POLICY", policysetcontroller.ID, zClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := placeRule(d, policysetcontroller.PolicySetID, "ACCESS_POLICY", policysetcontroller.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyAccessRuleV2Read(ctx, d, m)
+}
+
+func resourcePolicyAccessRuleV2Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+
+	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Getting Policy Set Rule: globalPolicySet:%s id: %s\n", globalPolicySet.ID, d.Id())
+	resp, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Removing policy rule %s from state because it no longer exists in ZPA", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Got Policy Set Rule:\n%+v\n", resp)
+	d.SetId(resp.ID)
+	_ = d.Set("description", resp.Description)
+	_ = d.Set("name", resp.Name)
+	_ = d.Set("action", resp.Action)
+	_ = d.Set("action_id", resp.ActionID)
+	_ = d.Set("custom_msg", resp.CustomMsg)
+	_ = d.Set("default_rule", resp.DefaultRule)
+	_ = d.Set("policy_set_id", resp.PolicySetID)
+	_ = d.Set("policy_type", resp.PolicyType)
+	_ = d.Set("priority", resp.Priority)
+	_ = d.Set("rule_order", resp.RuleOrder)
+	if err := readRulePlacement(d, "ACCESS_POLICY", resp.ID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("lss_default_rule", resp.LSSDefaultRule)
+	appServerGroupIDs := make([]string, len(resp.AppServerGroups))
+	for i, group := range resp.AppServerGroups {
+		appServerGroupIDs[i] = group.ID
+	}
+	_ = d.Set("app_server_group_ids", appServerGroupIDs)
+	appConnectorGroupIDs := make([]string, len(resp.AppConnectorGroups))
+	for i, group := range resp.AppConnectorGroups {
+		appConnectorGroupIDs[i] = group.ID
+	}
+	_ = d.Set("app_connector_group_ids", appConnectorGroupIDs)
+
+	var diags diag.Diagnostics
+	if ignored := flattenPolicyCriteria(d, policyAccessRuleV2Criteria, resp.Conditions); len(ignored) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Policy rule %s has conditions zpa_policy_access_rule_v2 can't represent", resp.ID),
+			Detail:   fmt.Sprintf("They will be removed on the next apply: %v", ignored),
+		})
+	}
+	return diags
+}
+
+func resourcePolicyAccessRuleV2Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	globalPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleID := d.Id()
+	log.Printf("[INFO] Updating policy rule ID: %v\n", ruleID)
+	req := expandPolicyAccessRuleV2(d)
+	req.PolicySetID = globalPolicySet.ID
+	if diags := validatePolicyAccessRuleV2OnApply(d, zClient); diags.HasError() {
+		return diags
+	}
+	if err := validateAccessPolicyRuleOrder(req.RuleOrder, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, _, err := zClient.policysetcontroller.GetPolicyRule(globalPolicySet.ID, ruleID); err != nil {
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "policy rule")
+		}
+		return diag.FromErr(err)
+	}
+
+	if _, err := zClient.policysetcontroller.Update(globalPolicySet.ID, ruleID, req); err != nil {
+		return diag.FromErr(describeAPIError(err, "policy rule", d.Get("name").(string)))
+	}
+	if d.HasChange("rule_order") {
+		order, ok := d.GetOk("rule_order")
+		if ok {
+			if err := reorder(order, globalPolicySet.ID, "ACCESS_POLICY", ruleID, zClient); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if err := placeRule(d, globalPolicySet.ID, "ACCESS_POLICY", ruleID, zClient); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyAccessRuleV2Read(ctx, d, m)
+}
+
+func expandPolicyAccessRuleV2(d *schema.ResourceData) *policysetcontroller.PolicyRule {
+	var appServerGroups []policysetcontroller.AppServerGroups
+	for _, id := range SetToStringSlice(d.Get("app_server_group_ids").(*schema.Set)) {
+		appServerGroups = append(appServerGroups, policysetcontroller.AppServerGroups{ID: id})
+	}
+	var appConnectorGroups []policysetcontroller.AppConnectorGroups
+	for _, id := range SetToStringSlice(d.Get("app_connector_group_ids").(*schema.Set)) {
+		appConnectorGroups = append(appConnectorGroups, policysetcontroller.AppConnectorGroups{ID: id})
+	}
+	return &policysetcontroller.PolicyRule{
+		ID:                 d.Id(),
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		Action:             d.Get("action").(string),
+		ActionID:           d.Get("action_id").(string),
+		BypassDefaultRule:  d.Get("bypass_default_rule").(bool),
+		CustomMsg:          d.Get("custom_msg").(string),
+		DefaultRule:        d.Get("default_rule").(bool),
+		Operator:           "AND",
+		PolicyType:         d.Get("policy_type").(string),
+		Priority:           d.Get("priority").(string),
+		RuleOrder:          d.Get("rule_order").(string),
+		LSSDefaultRule:     d.Get("lss_default_rule").(bool),
+		Conditions:         expandPolicyCriteria(policyAccessRuleV2Criteria, d.Get),
+		AppServerGroups:    appServerGroups,
+		AppConnectorGroups: appConnectorGroups,
+	}
+}
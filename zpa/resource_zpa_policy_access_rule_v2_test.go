@@ -0,0 +1,188 @@
+package zpa
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+)
+
+func TestAccPolicyAccessRuleV2Basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyAccessRuleV2 + ".this"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyAccessRuleDestroy(provider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyAccessRuleV2Configure(rName, `
+	segment_group_ids = ["216196257331280999"]`),
+				ExpectError: regexp.MustCompile(`segment_group_ids\.rhs: when operand object type is APP_GROUP rhs must be Segment Group ID`),
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleV2Configure(rName, `
+	country_codes = ["XX"]`),
+				ExpectError: regexp.MustCompile(`"XX" isn't an ISO 3166-1 alpha-2 country code`),
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleV2Configure(rName, `
+	client_types     = ["zpn_client_type_zapp", "zpn_client_type_exporter"]
+	platforms        = ["windows", "mac"]
+	country_codes    = ["CA", "US"]
+	trusted_networks = [data.zpa_trusted_network.this.network_id]
+	saml_attributes  = {
+		(data.zpa_saml_attribute.email.id) = "user1@bd-hashicorp.com"
+	}
+	scim_group_ids {
+		idp_id = data.zpa_idp_controller.users.id
+		ids    = [data.zpa_scim_groups.engineering.id, data.zpa_scim_groups.finance.id]
+	}
+	posture {
+		udid = data.zpa_posture_profile.zta_40.posture_udid
+	}
+	posture {
+		udid     = data.zpa_posture_profile.zta_80.posture_udid
+		expected = false
+	}
+	segment_group_ids = [zpa_segment_group.this.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyAccessRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "client_types.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "platforms.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "country_codes.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "trusted_networks.#", "1"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "saml_attributes.%", "1"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "scim_group_ids.0.ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "posture.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "segment_group_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyAccessRuleV2Configure(rName, `
+	client_types  = ["zpn_client_type_exporter"]
+	country_codes = ["US"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceTypeAndName, "client_types.#", "1"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "country_codes.#", "1"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "platforms.#", "0"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "posture.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceTypeAndName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule_order"},
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyAccessRuleV2Configure(rName, criteria string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_saml_attribute" "email" {
+	name     = "Email_BD_Okta_Users"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "engineering" {
+	name     = "Engineering"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "finance" {
+	name     = "Finance"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_posture_profile" "zta_40" {
+	name = "CrowdStrike_ZPA_ZTA_40 (zscalertwo.net)"
+}
+
+data "zpa_posture_profile" "zta_80" {
+	name = "CrowdStrike_ZPA_ZTA_80 (zscalertwo.net)"
+}
+
+data "zpa_trusted_network" "this" {
+	name = "BD-TrustedNetwork03 (zscalertwo.net)"
+}
+
+resource "%s" "this" {
+	name    = "%s"
+	enabled = true
+}
+
+resource "%s" "this" {
+	name   = "%s"
+	action = "ALLOW"
+%s
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAPolicyAccessRuleV2, rName, criteria)
+}
+
+func TestExpandPolicyAccessRuleV2ConditionsIsDeterministic(t *testing.T) {
+	r := resourcePolicyAccessRuleV2()
+	config := func(reversed bool) map[string]interface{} {
+		ids := []interface{}{"3", "1", "2"}
+		platforms := []interface{}{"windows", "linux", "mac"}
+		if reversed {
+			ids = []interface{}{"2", "1", "3"}
+			platforms = []interface{}{"mac", "linux", "windows"}
+		}
+		return map[string]interface{}{
+			"name":            "rule",
+			"app_segment_ids": ids,
+			"platforms":       platforms,
+			"saml_attributes": map[string]interface{}{"b": "2", "a": "1"},
+			"scim_group_ids": []interface{}{
+				map[string]interface{}{"idp_id": "20", "ids": ids},
+				map[string]interface{}{"idp_id": "10", "ids": ids},
+			},
+			"posture": []interface{}{
+				map[string]interface{}{"udid": "b", "expected": false},
+				map[string]interface{}{"udid": "a", "expected": true},
+			},
+		}
+	}
+
+	first := expandPolicyAccessRuleV2(schema.TestResourceDataRaw(t, r.Schema, config(false))).Conditions
+	second := expandPolicyAccessRuleV2(schema.TestResourceDataRaw(t, r.Schema, config(true))).Conditions
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("the same criteria compiled into different conditions:\n%+v\n%+v", first, second)
+	}
+
+	var objectTypes []string
+	for _, condition := range first {
+		objectTypes = append(objectTypes, condition.Operands[0].ObjectType)
+	}
+	if expected := []string{"APP", "SCIM_GROUP", "SAML", "PLATFORM", "POSTURE"}; !reflect.DeepEqual(objectTypes, expected) {
+		t.Errorf("expected the conditions %v, got %v", expected, objectTypes)
+	}
+	scim := first[1].Operands
+	if scim[0].LHS != "10" || scim[0].RHS != "1" || scim[5].LHS != "20" || scim[5].RHS != "3" {
+		t.Errorf("SCIM group operands aren't sorted by IdP and group: %+v", scim)
+	}
+	if posture := first[4].Operands; posture[0].LHS != "a" || posture[0].RHS != "true" || posture[1].RHS != "false" {
+		t.Errorf("unexpected posture operands: %+v", posture)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "rule"})
+	ignored := flattenPolicyCriteria(d, policyAccessRuleV2Criteria, first)
+	if len(ignored) != 0 {
+		t.Errorf("expected every operand to be represented, ignored %v", ignored)
+	}
+	if again := expandPolicyAccessRuleV2(d).Conditions; !reflect.DeepEqual(first, again) {
+		t.Errorf("the conditions didn't round-trip:\n%+v\n%+v", first, again)
+	}
+}
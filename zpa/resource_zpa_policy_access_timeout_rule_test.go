@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -16,15 +17,15 @@ func TestAccPolicyTimeoutRuleBasic(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	randDesc := acctest.RandString(20)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckPolicyTimeoutRuleDestroy,
+		CheckDestroy: testAccCheckPolicyTimeoutRuleDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckPolicyTimeoutRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyTimeoutRuleExists(resourceTypeAndName),
+					testAccCheckPolicyTimeoutRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "RE_AUTH"),
@@ -39,7 +40,7 @@ func TestAccPolicyTimeoutRuleBasic(t *testing.T) {
 			{
 				Config: testAccCheckPolicyTimeoutRuleConfigure(resourceTypeAndName, generatedName, rName, randDesc),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckPolicyTimeoutRuleExists(resourceTypeAndName),
+					testAccCheckPolicyTimeoutRuleExists(provider, resourceTypeAndName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", rName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", randDesc),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "RE_AUTH"),
@@ -53,32 +54,34 @@ func TestAccPolicyTimeoutRuleBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckPolicyTimeoutRuleDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
-	accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("TIMEOUT_POLICY")
-	if err != nil {
-		return fmt.Errorf("failed fetching resource TIMEOUT_POLICY. Recevied error: %s", err)
-	}
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAPolicyAccessRule {
-			continue
+func testAccCheckPolicyTimeoutRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		accessPolicy, _, err := apiClient.policysetcontroller.GetByPolicyType("TIMEOUT_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource TIMEOUT_POLICY. Recevied error: %s", err)
 		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyAccessRule {
+				continue
+			}
 
-		rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
+			rule, _, err := apiClient.policysetcontroller.GetPolicyRule(accessPolicy.ID, rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("policy timeout rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("policy timeout rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckPolicyTimeoutRuleExists(resource string) resource.TestCheckFunc {
+func testAccCheckPolicyTimeoutRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -88,7 +91,7 @@ func testAccCheckPolicyTimeoutRuleExists(resource string) resource.TestCheckFunc
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		resp, _, err := apiClient.policysetcontroller.GetByPolicyType("TIMEOUT_POLICY")
 		if err != nil {
 			return fmt.Errorf("failed fetching resource TIMEOUT_POLICY. Recevied error: %s", err)
@@ -155,3 +158,83 @@ resource "%s" "%s" {
 		desc,
 	)
 }
+
+func TestAccPolicyTimeoutRulePlacement(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyTimeoutRulePlacementConfigure(rName, "insert_after", fmt.Sprintf("%q", rName+"-a")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourcetype.ZPAPolicyTimeOutRule+".c", "insert_after", rName+"-a"),
+					testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "a", "c", "b"),
+				),
+			},
+			// a rule moved outside of Terraform shows in the plan
+			{
+				PreConfig: func() {
+					apiClient := provider.Meta().(*Client)
+					rule, _, err := apiClient.policysetcontroller.GetByNameAndType("TIMEOUT_POLICY", rName+"-c")
+					if err != nil {
+						t.Fatalf("failed fetching rule %s-c: %v", rName, err)
+					}
+					if _, err := apiClient.policysetcontroller.Reorder(rule.PolicySetID, rule.ID, 1); err != nil {
+						t.Fatalf("failed moving rule %s-c: %v", rName, err)
+					}
+				},
+				Config:             testAccCheckPolicyTimeoutRulePlacementConfigure(rName, "insert_after", fmt.Sprintf("%q", rName+"-a")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccCheckPolicyTimeoutRulePlacementConfigure(rName, "insert_after", fmt.Sprintf("%q", rName+"-a")),
+				Check:  testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "a", "c", "b"),
+			},
+			{
+				Config: testAccCheckPolicyTimeoutRulePlacementConfigure(rName, "insert_before", resourcetype.ZPAPolicyTimeOutRule+".a.id"),
+				Check:  testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "c", "a", "b"),
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyTimeoutRulePlacementConfigure(rName, attribute, anchor string) string {
+	return fmt.Sprintf(`
+data "zpa_policy_type" "timeout_policy" {
+	policy_type = "TIMEOUT_POLICY"
+}
+
+resource "%[1]s" "a" {
+	name                = "%[2]s-a"
+	action              = "RE_AUTH"
+	reauth_idle_timeout = "600"
+	reauth_timeout      = "172800"
+	operator            = "AND"
+	policy_set_id       = data.zpa_policy_type.timeout_policy.id
+}
+
+resource "%[1]s" "b" {
+	name                = "%[2]s-b"
+	action              = "RE_AUTH"
+	reauth_idle_timeout = "600"
+	reauth_timeout      = "172800"
+	operator            = "AND"
+	policy_set_id       = data.zpa_policy_type.timeout_policy.id
+	depends_on          = [%[1]s.a]
+}
+
+resource "%[1]s" "c" {
+	name                = "%[2]s-c"
+	action              = "RE_AUTH"
+	reauth_idle_timeout = "600"
+	reauth_timeout      = "172800"
+	operator            = "AND"
+	policy_set_id       = data.zpa_policy_type.timeout_policy.id
+	%[3]s = %[4]s
+	depends_on          = [%[1]s.b]
+}
+`, resourcetype.ZPAPolicyTimeOutRule, rName, attribute, anchor)
+}
@@ -0,0 +1,142 @@
+package zpa
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// policyRuleCapabilities maps the attributes of the privileged_capabilities block to the capabilities of the API,
+// in the order the capabilities are sent.
+var policyRuleCapabilities = []struct {
+	attribute   string
+	capability  string
+	description string
+}{
+	{"clipboard_copy", "CLIPBOARD_COPY", "Allow copying from the remote session to the local clipboard."},
+	{"clipboard_paste", "CLIPBOARD_PASTE", "Allow pasting from the local clipboard into the remote session."},
+	{"file_upload", "FILE_UPLOAD", "Allow uploading files to the remote session."},
+	{"file_download", "FILE_DOWNLOAD", "Allow downloading files from the remote session."},
+	{"inspect_file_upload", "INSPECT_FILE_UPLOAD", "Inspect the files uploaded to the remote session."},
+	{"inspect_file_download", "INSPECT_FILE_DOWNLOAD", "Inspect the files downloaded from the remote session."},
+	{"monitor_session", "MONITOR_SESSION", "Allow monitoring the session."},
+	{"record_session", "RECORD_SESSION", "Record the session."},
+	{"share_session", "SHARE_SESSION", "Allow sharing the session."},
+}
+
+func resourcePolicyCapabilitiesRule() *schema.Resource {
+	policySchema := CommonPolicySchema()
+	for _, key := range []string{"reauth_default_rule", "reauth_idle_timeout", "reauth_timeout", "zpn_isolation_profile_id", "zpn_cbi_profile_id", "zpn_inspection_profile_id"} {
+		delete(policySchema, key)
+	}
+	crud := policyRuleCRUD{policyType: "CAPABILITIES_POLICY"}
+	return &schema.Resource{
+		CreateContext: crud.create,
+		ReadContext:   crud.read,
+		UpdateContext: crud.update,
+		DeleteContext: crud.delete,
+		CustomizeDiff: customizeDiffPolicyRuleType("CAPABILITIES_POLICY"),
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("CAPABILITIES_POLICY")),
+		},
+
+		Schema: MergeSchema(
+			policySchema, policyCapabilitiesRuleSchema(), map[string]*schema.Schema{
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "CHECK_CAPABILITIES",
+					Description:  "This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["CAPABILITIES_POLICY"].actions, false),
+				},
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["CAPABILITIES_POLICY"].objectTypes),
+			},
+		),
+	}
+}
+
+// policyCapabilitiesRuleSchema returns the attributes only CAPABILITIES_POLICY rules take.
+func policyCapabilitiesRuleSchema() map[string]*schema.Schema {
+	capabilities := map[string]*schema.Schema{}
+	for _, c := range policyRuleCapabilities {
+		capabilities[c.attribute] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: c.description,
+		}
+	}
+	return map[string]*schema.Schema{
+		"privileged_capabilities": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "The capabilities of the sessions the rule matches, the capabilities that aren't enabled are disabled.",
+			Elem: &schema.Resource{
+				Schema: capabilities,
+			},
+		},
+	}
+}
+
+func expandPolicyCapabilitiesRule(d *schema.ResourceData, rule *extendedPolicyRule) {
+	rule.PrivilegedCapabilities = expandPolicyRulePrivilegedCapabilities(d.Get("privileged_capabilities").([]interface{}))
+}
+
+// flattenPolicyCapabilitiesRule warns about the enabled capabilities privileged_capabilities can't represent, they
+// are disabled by the next update.
+func flattenPolicyCapabilitiesRule(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	capabilities, ignored := flattenPolicyRulePrivilegedCapabilities(rule.PrivilegedCapabilities)
+	_ = d.Set("privileged_capabilities", capabilities)
+	if len(ignored) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Policy rule %s enables capabilities privileged_capabilities can't represent", rule.ID),
+		Detail:   fmt.Sprintf("They will be disabled on the next apply: %v", ignored),
+	}}
+}
+
+// expandPolicyRulePrivilegedCapabilities lists the enabled capabilities in the order of policyRuleCapabilities.
+func expandPolicyRulePrivilegedCapabilities(blocks []interface{}) *policyRulePrivilegedCapabilities {
+	capabilities := &policyRulePrivilegedCapabilities{Capabilities: []string{}}
+	if len(blocks) == 0 || blocks[0] == nil {
+		return capabilities
+	}
+	block := blocks[0].(map[string]interface{})
+	for _, c := range policyRuleCapabilities {
+		if enabled, _ := block[c.attribute].(bool); enabled {
+			capabilities.Capabilities = append(capabilities.Capabilities, c.capability)
+		}
+	}
+	return capabilities
+}
+
+// flattenPolicyRulePrivilegedCapabilities returns the privileged_capabilities block and the enabled capabilities
+// the block has no attribute for.
+func flattenPolicyRulePrivilegedCapabilities(capabilities *policyRulePrivilegedCapabilities) ([]interface{}, []string) {
+	block := map[string]interface{}{}
+	for _, c := range policyRuleCapabilities {
+		block[c.attribute] = false
+	}
+	var ignored []string
+	if capabilities != nil {
+		for _, capability := range capabilities.Capabilities {
+			known := false
+			for _, c := range policyRuleCapabilities {
+				if c.capability == capability {
+					block[c.attribute] = true
+					known = true
+				}
+			}
+			if !known {
+				ignored = append(ignored, capability)
+			}
+		}
+	}
+	return []interface{}{block}, ignored
+}
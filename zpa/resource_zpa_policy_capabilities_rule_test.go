@@ -0,0 +1,162 @@
+package zpa
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+)
+
+func TestAccPolicyCapabilitiesRuleBasic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyCapabilitiesRule + ".this"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyCapabilitiesRuleDestroy(provider),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyCapabilitiesRuleConfigure(rName, `
+		clipboard_copy      = true
+		file_download       = true
+		inspect_file_upload = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyCapabilitiesRuleExists(provider, resourceTypeAndName, "CLIPBOARD_COPY", "FILE_DOWNLOAD", "INSPECT_FILE_UPLOAD"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "CHECK_CAPABILITIES"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.clipboard_copy", "true"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.clipboard_paste", "false"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyCapabilitiesRuleConfigure(rName, `
+		clipboard_paste = true`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyCapabilitiesRuleExists(provider, resourceTypeAndName, "CLIPBOARD_PASTE"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.clipboard_copy", "false"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.clipboard_paste", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceTypeAndName,
+				ImportState:             true,
+				ImportStateId:           rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule_order"},
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyCapabilitiesRuleConfigure(rName, capabilities string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "engineering" {
+	name     = "Engineering"
+	idp_name = "BD_Okta_Users"
+}
+
+resource "%s" "this" {
+	name    = "%s"
+	enabled = true
+}
+
+resource "%s" "this" {
+	name        = "%s"
+	description = "%s"
+	operator    = "AND"
+	privileged_capabilities {
+	%s
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "APP_GROUP"
+			lhs         = "id"
+			rhs         = %s.this.id
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs         = data.zpa_idp_controller.users.id
+			rhs         = data.zpa_scim_groups.engineering.id
+		}
+	}
+}
+`, resourcetype.ZPASegmentGroup, rName, resourcetype.ZPAPolicyCapabilitiesRule, rName, rName, capabilities, resourcetype.ZPASegmentGroup)
+}
+
+func testAccCheckPolicyCapabilitiesRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("CAPABILITIES_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource CAPABILITIES_POLICY. Recevied error: %s", err)
+		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyCapabilitiesRule {
+				continue
+			}
+			rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
+			if rule != nil {
+				return fmt.Errorf("policy capabilities rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyCapabilitiesRuleExists(provider *schema.Provider, resource string, capabilities ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("didn't find resource: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("CAPABILITIES_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource CAPABILITIES_POLICY. Recevied error: %s", err)
+		}
+		rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed fetching resource %s. Recevied error: %s", resource, err)
+		}
+		if rule.PrivilegedCapabilities == nil || !reflect.DeepEqual(rule.PrivilegedCapabilities.Capabilities, capabilities) {
+			return fmt.Errorf("policy capabilities rule %s enables %+v, expected %v", rs.Primary.ID, rule.PrivilegedCapabilities, capabilities)
+		}
+		return nil
+	}
+}
+
+func TestFlattenPolicyRulePrivilegedCapabilities(t *testing.T) {
+	block, ignored := flattenPolicyRulePrivilegedCapabilities(&policyRulePrivilegedCapabilities{
+		Capabilities: []string{"SHARE_SESSION", "CLIPBOARD_COPY", "PRINT"},
+	})
+	if !reflect.DeepEqual(ignored, []string{"PRINT"}) {
+		t.Errorf("expected PRINT to be ignored, got %v", ignored)
+	}
+	expanded := expandPolicyRulePrivilegedCapabilities(block)
+	if expected := []string{"CLIPBOARD_COPY", "SHARE_SESSION"}; !reflect.DeepEqual(expanded.Capabilities, expected) {
+		t.Errorf("expected the capabilities %v, got %v", expected, expanded.Capabilities)
+	}
+	if len(block[0].(map[string]interface{})) != len(policyRuleCapabilities) {
+		t.Errorf("expected every capability to be flattened, got %v", block)
+	}
+}
@@ -0,0 +1,90 @@
+package zpa
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyCredentialRule() *schema.Resource {
+	policySchema := CommonPolicySchema()
+	for _, key := range []string{"reauth_default_rule", "reauth_idle_timeout", "reauth_timeout", "zpn_isolation_profile_id", "zpn_cbi_profile_id", "zpn_inspection_profile_id"} {
+		delete(policySchema, key)
+	}
+	crud := policyRuleCRUD{policyType: "CREDENTIAL_POLICY"}
+	return &schema.Resource{
+		CreateContext: crud.create,
+		ReadContext:   crud.read,
+		UpdateContext: crud.update,
+		DeleteContext: crud.delete,
+		CustomizeDiff: customizeDiffPolicyRuleType("CREDENTIAL_POLICY"),
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("CREDENTIAL_POLICY")),
+		},
+
+		Schema: MergeSchema(
+			policySchema, policyCredentialRuleSchema(), map[string]*schema.Schema{
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "INJECT_CREDENTIALS",
+					Description:  "This is for providing the rule action.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["CREDENTIAL_POLICY"].actions, false),
+				},
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["CREDENTIAL_POLICY"].objectTypes),
+			},
+		),
+	}
+}
+
+// policyCredentialRuleSchema returns the attributes only CREDENTIAL_POLICY rules take.
+func policyCredentialRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"credential": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "The privileged credential injected for the users and consoles the rule matches.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The ID of the privileged credential.",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the privileged credential.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandPolicyCredentialRule(d *schema.ResourceData, rule *extendedPolicyRule) {
+	if credentials, _ := d.Get("credential").([]interface{}); len(credentials) > 0 && credentials[0] != nil {
+		credential := credentials[0].(map[string]interface{})
+		rule.Credential = &policyRuleCredential{ID: credential["id"].(string)}
+	}
+}
+
+func flattenPolicyCredentialRule(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("credential", flattenPolicyRuleCredential(rule.Credential))
+	return nil
+}
+
+func flattenPolicyRuleCredential(credential *policyRuleCredential) []interface{} {
+	if credential == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"id":   credential.ID,
+			"name": credential.Name,
+		},
+	}
+}
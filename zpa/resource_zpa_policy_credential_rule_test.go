@@ -0,0 +1,155 @@
+package zpa
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+)
+
+func TestAccPolicyCredentialRuleBasic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyCredentialRule + ".this"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyCredentialRuleDestroy(provider),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPolicyCredentialRuleConfigure(rName, "216196257331281001", "PLATFORM"),
+				ExpectError: regexp.MustCompile(`expected conditions\.0\.operands\.0\.object_type to be one of`),
+			},
+			{
+				Config: testAccCheckPolicyCredentialRuleConfigure(rName, "216196257331281001", "CONSOLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyCredentialRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "INJECT_CREDENTIALS"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "credential.0.id", "216196257331281001"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.#", "2"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.1.operands.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyCredentialRuleConfigure(rName, "216196257331281002", "CONSOLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyCredentialRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "credential.0.id", "216196257331281002"),
+				),
+			},
+			{
+				ResourceName:            resourceTypeAndName,
+				ImportState:             true,
+				ImportStateId:           rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule_order"},
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyCredentialRuleConfigure(rName, credentialID, consoleObjectType string) string {
+	return fmt.Sprintf(`
+data "zpa_idp_controller" "users" {
+	name = "BD_Okta_Users"
+}
+
+data "zpa_saml_attribute" "email" {
+	name     = "Email_BD_Okta_Users"
+	idp_name = "BD_Okta_Users"
+}
+
+data "zpa_scim_groups" "engineering" {
+	name     = "Engineering"
+	idp_name = "BD_Okta_Users"
+}
+
+resource "%s" "this" {
+	name        = "%s"
+	description = "%s"
+	operator    = "AND"
+	credential {
+		id = "%s"
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "%s"
+			lhs         = "id"
+			rhs         = "216196257331281100"
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "IDP"
+			lhs         = "id"
+			rhs         = data.zpa_idp_controller.users.id
+		}
+		operands {
+			object_type = "SAML"
+			lhs         = data.zpa_saml_attribute.email.id
+			rhs         = "user1@bd-hashicorp.com"
+		}
+		operands {
+			object_type = "SCIM_GROUP"
+			lhs         = data.zpa_idp_controller.users.id
+			rhs         = data.zpa_scim_groups.engineering.id
+		}
+	}
+}
+`, resourcetype.ZPAPolicyCredentialRule, rName, rName, credentialID, consoleObjectType)
+}
+
+func testAccCheckPolicyCredentialRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("CREDENTIAL_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource CREDENTIAL_POLICY. Recevied error: %s", err)
+		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyCredentialRule {
+				continue
+			}
+			rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
+			if rule != nil {
+				return fmt.Errorf("policy credential rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyCredentialRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("didn't find resource: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("CREDENTIAL_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource CREDENTIAL_POLICY. Recevied error: %s", err)
+		}
+		rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed fetching resource %s. Recevied error: %s", resource, err)
+		}
+		if rule.Credential == nil || rule.Credential.ID != rs.Primary.Attributes["credential.0.id"] {
+			return fmt.Errorf("policy credential rule %s doesn't inject credential %s", rs.Primary.ID, rs.Primary.Attributes["credential.0.id"])
+		}
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package zpa
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyRedirectionRule() *schema.Resource {
+	policySchema := CommonPolicySchema()
+	for _, key := range []string{"reauth_default_rule", "reauth_idle_timeout", "reauth_timeout", "zpn_isolation_profile_id", "zpn_cbi_profile_id", "zpn_inspection_profile_id"} {
+		delete(policySchema, key)
+	}
+	crud := policyRuleCRUD{policyType: "REDIRECTION_POLICY"}
+	return &schema.Resource{
+		CreateContext: crud.create,
+		ReadContext:   crud.read,
+		UpdateContext: crud.update,
+		DeleteContext: crud.delete,
+		CustomizeDiff: customizeDiffPolicyRuleType("REDIRECTION_POLICY"),
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importPolicyStateContextFunc(policyRuleImportTypes("REDIRECTION_POLICY")),
+		},
+
+		Schema: MergeSchema(
+			policySchema, policyRedirectionRuleSchema(), map[string]*schema.Schema{
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "REDIRECT_DEFAULT",
+					Description:  "This is for providing the rule action. REDIRECT_PREFERRED and REDIRECT_ALWAYS need service_edge_groups, REDIRECT_DEFAULT takes none.",
+					ValidateFunc: validation.StringInSlice(policyRuleTypes["REDIRECTION_POLICY"].actions, false),
+				},
+				"conditions": GetPolicyConditionsSchema(policyRuleTypes["REDIRECTION_POLICY"].objectTypes),
+			},
+		),
+	}
+}
+
+// validatePolicyRedirectionServiceEdgeGroups checks the service edge groups fit the action and exist.
+func validatePolicyRedirectionServiceEdgeGroups(d *schema.ResourceDiff, zClient *Client) error {
+	// the groups are checked once every ID is known, IDs of groups created in the same run are known on apply
+	config := d.GetRawConfig()
+	if !config.IsKnown() || config.IsNull() || !config.GetAttr("action").IsKnown() || !config.GetAttr("service_edge_groups").IsWhollyKnown() {
+		return nil
+	}
+	path := cty.GetAttrPath("service_edge_groups")
+	action := d.Get("action").(string)
+	ids := expandPolicyRuleServiceEdgeGroupIDs(d.Get("service_edge_groups").(*schema.Set))
+	if action == "REDIRECT_DEFAULT" && len(ids) > 0 {
+		return path.NewErrorf("service_edge_groups can't be set when action is REDIRECT_DEFAULT")
+	}
+	// zpa_policy_rule leaves the action to the API when it isn't set
+	if action != "REDIRECT_DEFAULT" && action != "" && len(ids) == 0 {
+		return path.NewErrorf("at least one service edge group must be set when action is %s", action)
+	}
+	if d.Id() != "" && !d.HasChange("service_edge_groups") {
+		return nil
+	}
+	for _, id := range ids {
+		if _, _, err := zClient.serviceedgegroup.Get(id); err != nil {
+			if isNotFoundError(err) {
+				return path.NewErrorf("no service edge group with id %s was found", id)
+			}
+			return path.NewErrorf("couldn't look up service edge group %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// policyRedirectionRuleSchema returns the attributes only REDIRECTION_POLICY rules take.
+func policyRedirectionRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"service_edge_groups": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The service edge groups the clients matched by the rule are redirected to.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandPolicyRedirectionRule(d *schema.ResourceData, rule *extendedPolicyRule) {
+	serviceEdgeGroups := []policyRuleServiceEdgeGroup{}
+	for _, id := range expandPolicyRuleServiceEdgeGroupIDs(d.Get("service_edge_groups").(*schema.Set)) {
+		serviceEdgeGroups = append(serviceEdgeGroups, policyRuleServiceEdgeGroup{ID: id})
+	}
+	rule.ServiceEdgeGroups = &serviceEdgeGroups
+}
+
+func flattenPolicyRedirectionRule(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("service_edge_groups", flattenPolicyRuleServiceEdgeGroups(rule.ServiceEdgeGroups))
+	return nil
+}
+
+// expandPolicyRuleServiceEdgeGroupIDs returns the IDs of every service_edge_groups block.
+func expandPolicyRuleServiceEdgeGroupIDs(groups *schema.Set) []string {
+	var ids []string
+	for _, group := range groups.List() {
+		group, _ := group.(map[string]interface{})
+		if group == nil {
+			continue
+		}
+		if set, ok := group["id"].(*schema.Set); ok {
+			ids = append(ids, SetToStringSlice(set)...)
+		}
+	}
+	return ids
+}
+
+func flattenPolicyRuleServiceEdgeGroups(serviceEdgeGroups *[]policyRuleServiceEdgeGroup) []interface{} {
+	if serviceEdgeGroups == nil || len(*serviceEdgeGroups) == 0 {
+		return nil
+	}
+	ids := make([]string, len(*serviceEdgeGroups))
+	for i, group := range *serviceEdgeGroups {
+		ids[i] = group.ID
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"id": ids,
+		},
+	}
+}
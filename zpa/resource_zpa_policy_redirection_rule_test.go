@@ -0,0 +1,223 @@
+package zpa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func TestAccPolicyRedirectionRuleBasic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyRedirectionRule + ".this"
+	serviceEdgeGroupID := resourcetype.ZPAServiceEdgeGroup + ".this.id"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyRedirectionRuleDestroy(provider),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPolicyRedirectionRuleConfigure(rName, "REDIRECT_PREFERRED", `"216196257331289999"`),
+				ExpectError: regexp.MustCompile(`no service edge group with id 216196257331289999 was found`),
+			},
+			{
+				Config:      testAccCheckPolicyRedirectionRuleConfigure(rName, "REDIRECT_ALWAYS", ""),
+				ExpectError: regexp.MustCompile(`at least one service edge group must be set when action is REDIRECT_ALWAYS`),
+			},
+			{
+				Config:      testAccCheckPolicyRedirectionRuleConfigure(rName, "REDIRECT_DEFAULT", serviceEdgeGroupID),
+				ExpectError: regexp.MustCompile(`service_edge_groups can't be set when action is REDIRECT_DEFAULT`),
+			},
+			{
+				Config: testAccCheckPolicyRedirectionRuleConfigure(rName, "REDIRECT_PREFERRED", serviceEdgeGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyRedirectionRuleExists(provider, resourceTypeAndName, 1),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "REDIRECT_PREFERRED"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "service_edge_groups.0.id.#", "1"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyRedirectionRuleConfigure(rName, "REDIRECT_DEFAULT", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyRedirectionRuleExists(provider, resourceTypeAndName, 0),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "REDIRECT_DEFAULT"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "service_edge_groups.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceTypeAndName,
+				ImportState:             true,
+				ImportStateId:           rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule_order"},
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyRedirectionRuleConfigure(rName, action, serviceEdgeGroupIDs string) string {
+	serviceEdgeGroups := ""
+	if serviceEdgeGroupIDs != "" {
+		serviceEdgeGroups = fmt.Sprintf(`
+	service_edge_groups {
+		id = [%s]
+	}`, serviceEdgeGroupIDs)
+	}
+	return fmt.Sprintf(`
+resource "%s" "this" {
+	name                 = "%s"
+	description          = "%s"
+	enabled              = true
+	is_public            = true
+	upgrade_day          = "SUNDAY"
+	upgrade_time_in_secs = "66600"
+	latitude             = "37.3382082"
+	longitude            = "-121.8863286"
+	location             = "San Jose, CA, USA"
+	version_profile_name = "New Release"
+}
+
+resource "%s" "this" {
+	name        = "%s"
+	description = "%s"
+	action      = "%s"
+	operator    = "AND"
+	%s
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "COUNTRY_CODE"
+			lhs         = "CA"
+			rhs         = "true"
+		}
+		operands {
+			object_type = "COUNTRY_CODE"
+			lhs         = "US"
+			rhs         = "true"
+		}
+	}
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_zapp"
+		}
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_exporter"
+		}
+	}
+}
+`, resourcetype.ZPAServiceEdgeGroup, rName, rName, resourcetype.ZPAPolicyRedirectionRule, rName, rName, action, serviceEdgeGroups)
+}
+
+func testAccCheckPolicyRedirectionRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("REDIRECTION_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource REDIRECTION_POLICY. Recevied error: %s", err)
+		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyRedirectionRule {
+				continue
+			}
+			rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
+			if rule != nil {
+				return fmt.Errorf("policy redirection rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyRedirectionRuleExists(provider *schema.Provider, resource string, serviceEdgeGroups int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("didn't find resource: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType("REDIRECTION_POLICY")
+		if err != nil {
+			return fmt.Errorf("failed fetching resource REDIRECTION_POLICY. Recevied error: %s", err)
+		}
+		rule, _, err := apiClient.extendedpolicyrule.GetPolicyRule(policySet.ID, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed fetching resource %s. Recevied error: %s", resource, err)
+		}
+		groups := 0
+		if rule.ServiceEdgeGroups != nil {
+			groups = len(*rule.ServiceEdgeGroups)
+		}
+		if groups != serviceEdgeGroups {
+			return fmt.Errorf("policy redirection rule %s has %d service edge groups, expected %d", rs.Primary.ID, groups, serviceEdgeGroups)
+		}
+		return nil
+	}
+}
+
+func TestExtendedPolicyRuleServiceEdgeGroupsJSON(t *testing.T) {
+	// the rules of the other policy types don't send the service edge groups
+	data, _ := json.Marshal(&extendedPolicyRule{PolicyRule: policysetcontroller.PolicyRule{PolicyType: "ACCESS_POLICY"}})
+	if strings.Contains(string(data), "serviceEdgeGroups") {
+		t.Errorf("expected no service edge groups, got %s", data)
+	}
+	// a REDIRECT_DEFAULT rule sends an empty list to remove its service edge groups
+	data, _ = json.Marshal(&extendedPolicyRule{policyRuleExtensions: policyRuleExtensions{ServiceEdgeGroups: &[]policyRuleServiceEdgeGroup{}}})
+	if !strings.Contains(string(data), `"serviceEdgeGroups":[]`) {
+		t.Errorf("expected an empty list of service edge groups, got %s", data)
+	}
+}
+
+// failingPolicyRules fails every read of a policy rule with err, and counts the updates.
+type failingPolicyRules struct {
+	extendedPolicyRuleService
+	err     error
+	updates int
+}
+
+func (f *failingPolicyRules) GetPolicyRule(policySetID, ruleID string) (*extendedPolicyRule, *http.Response, error) {
+	return nil, nil, f.err
+}
+
+func (f *failingPolicyRules) Update(policySetID, ruleID string, rule *extendedPolicyRule) (*http.Response, error) {
+	f.updates++
+	return f.extendedPolicyRuleService.Update(policySetID, ruleID, rule)
+}
+
+func TestResourcePolicyRedirectionRuleUpdateReportsReadError(t *testing.T) {
+	zClient := newFakeClient()
+	rules := &failingPolicyRules{extendedPolicyRuleService: zClient.extendedpolicyrule, err: io.ErrUnexpectedEOF}
+	zClient.extendedpolicyrule = rules
+	r := resourcePolicyRedirectionRule()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "rule"})
+	d.SetId("1")
+	if diags := r.UpdateContext(context.Background(), d, zClient); !diags.HasError() {
+		t.Error("expected the error of the read to be reported")
+	}
+	if rules.updates != 0 || d.Id() != "1" {
+		t.Errorf("expected the rule to be neither updated nor removed from the state, got %d updates and ID %q", rules.updates, d.Id())
+	}
+}
@@ -0,0 +1,201 @@
+package zpa
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyRule() *schema.Resource {
+	typeSchema := map[string]*schema.Schema{}
+	for _, policyType := range policyRuleTypeNames() {
+		if policyRuleTypes[policyType].attributeSchema == nil {
+			continue
+		}
+		for attribute, s := range policyRuleTypes[policyType].attributeSchema() {
+			s.Required = false
+			s.Optional = true
+			s.Description += " For " + policyType + " rules."
+			typeSchema[attribute] = s
+		}
+	}
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleCreate,
+		ReadContext:   resourcePolicyRuleRead,
+		UpdateContext: resourcePolicyRuleUpdate,
+		DeleteContext: resourcePolicyRuleDelete,
+		CustomizeDiff: customizeDiffPolicyRule,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importPolicyRuleStateContext,
+		},
+
+		Schema: MergeSchema(
+			CommonPolicySchema(), typeSchema, map[string]*schema.Schema{
+				"policy_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "The policy type of the policy set the rule belongs to, one of: " + strings.Join(policyRuleTypeNames(), ", "),
+					ValidateFunc: validation.StringInSlice(policyRuleTypeNames(), false),
+				},
+				"action": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "This is for providing the rule action, the actions depend on the policy type.",
+				},
+				"app_server_groups":    policyRuleGroupsSchema("List of the server group IDs, for ACCESS_POLICY rules."),
+				"app_connector_groups": policyRuleGroupsSchema("List of app-connector IDs, for ACCESS_POLICY rules."),
+				"conditions":           GetPolicyConditionsSchema(allPolicyRuleObjectTypes()),
+			},
+		),
+	}
+}
+
+// customizeDiffPolicyRule checks the action, the operand object types and the type specific attributes against
+// the policy type, then validates the operands like the other rule resources.
+func customizeDiffPolicyRule(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	policyType := d.Get("policy_type").(string)
+	ruleType, ok := policyRuleTypes[policyType]
+	if !ok || !d.NewValueKnown("policy_type") {
+		return nil
+	}
+	if action := d.Get("action").(string); action != "" && d.NewValueKnown("action") && !contains(ruleType.actions, action) {
+		return fmt.Errorf("action %q isn't an action of %s rules, it must be one of %s", action, policyType, quoteJoin(ruleType.actions))
+	}
+	config := d.GetRawConfig()
+	for _, attribute := range policyRuleTypeAttributes() {
+		if contains(ruleType.attributes, attribute) || config.IsNull() {
+			continue
+		}
+		if v := config.GetAttr(attribute); !v.IsNull() && (!v.IsKnown() || !v.CanIterateElements() || v.LengthInt() > 0) {
+			return fmt.Errorf("%s can't be set on %s rules", attribute, policyType)
+		}
+	}
+	if ruleType.attributeSchema != nil && !config.IsNull() {
+		// the attributes the dedicated resource of the type requires are optional in zpa_policy_rule
+		for attribute, s := range ruleType.attributeSchema() {
+			if v := config.GetAttr(attribute); s.Required && v.IsKnown() && (v.IsNull() || v.CanIterateElements() && v.LengthInt() == 0) {
+				return fmt.Errorf("%s must be set on %s rules", attribute, policyType)
+			}
+		}
+	}
+	if ruleType.customizeDiff != nil {
+		if err := ruleType.customizeDiff(d, m.(*Client).withContext(ctx)); err != nil {
+			return err
+		}
+	}
+
+	var errs policyOperandErrors
+	conditions, _ := d.Get("conditions").([]interface{})
+	for i, condition := range conditions {
+		conditionSet, _ := condition.(map[string]interface{})
+		if conditionSet == nil {
+			continue
+		}
+		operands, _ := conditionSet["operands"].([]interface{})
+		for j, operand := range operands {
+			operandSet, _ := operand.(map[string]interface{})
+			objectType, _ := operandSet["object_type"].(string)
+			if operandSet == nil || !policyOperandKnown(d, i, j) || contains(ruleType.objectTypes, objectType) {
+				continue
+			}
+			errs = append(errs, &policyOperandError{
+				key:        fmt.Sprintf("conditions.%d.operands.%d", i, j),
+				attribute:  "object_type",
+				objectType: objectType,
+				expected:   fmt.Sprintf("one of %s for %s rules", quoteJoin(ruleType.objectTypes), policyType),
+				value:      objectType,
+			})
+		}
+	}
+	if err := errs.diffError(); err != nil {
+		return err
+	}
+	return customizeDiffPolicyConditions(ctx, d, m)
+}
+
+// importPolicyRuleStateContext imports a rule by "<policy type>:<rule ID or name>".
+func importPolicyRuleStateContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	zClient := m.(*Client).withContext(ctx)
+	policyType, rule, ok := strings.Cut(d.Id(), ":")
+	if _, known := policyRuleTypes[policyType]; !ok || !known || rule == "" {
+		return nil, fmt.Errorf("the import ID must be <policy type>:<rule ID or name>, with one of the policy types %s", strings.Join(policyRuleTypeNames(), ", "))
+	}
+	_ = d.Set("policy_type", policyType)
+	if _, err := strconv.ParseInt(rule, 10, 64); err == nil {
+		d.SetId(rule)
+		return []*schema.ResourceData{d}, nil
+	}
+	resp, _, err := zClient.policysetcontroller.GetByNameAndTypes(policyRuleImportTypes(policyType), rule)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(resp.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePolicyRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return policyRuleCRUD{policyType: d.Get("policy_type").(string)}.create(ctx, d, m)
+}
+
+func resourcePolicyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return policyRuleCRUD{policyType: d.Get("policy_type").(string)}.read(ctx, d, m)
+}
+
+func resourcePolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return policyRuleCRUD{policyType: d.Get("policy_type").(string)}.update(ctx, d, m)
+}
+
+func resourcePolicyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return policyRuleCRUD{policyType: d.Get("policy_type").(string)}.delete(ctx, d, m)
+}
+
+func expandPolicyRuleAccessAttributes(d *schema.ResourceData, rule *extendedPolicyRule) {
+	rule.AppServerGroups = expandPolicySetControllerAppServerGroups(d)
+	rule.AppConnectorGroups = expandPolicysetControllerAppConnectorGroups(d)
+}
+
+func flattenPolicyRuleAccessAttributes(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("app_server_groups", flattenPolicyRuleServerGroups(rule.AppServerGroups))
+	_ = d.Set("app_connector_groups", flattenPolicyRuleAppConnectorGroups(rule.AppConnectorGroups))
+	return nil
+}
+
+func expandPolicyRuleTimeoutAttributes(d *schema.ResourceData, rule *extendedPolicyRule) {
+	rule.ReauthDefaultRule = d.Get("reauth_default_rule").(bool)
+	rule.ReauthIdleTimeout = d.Get("reauth_idle_timeout").(string)
+	rule.ReauthTimeout = d.Get("reauth_timeout").(string)
+}
+
+func flattenPolicyRuleTimeoutAttributes(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("reauth_default_rule", rule.ReauthDefaultRule)
+	_ = d.Set("reauth_idle_timeout", rule.ReauthIdleTimeout)
+	_ = d.Set("reauth_timeout", rule.ReauthTimeout)
+	return nil
+}
+
+func expandPolicyRuleInspectionAttributes(d *schema.ResourceData, rule *extendedPolicyRule) {
+	rule.ZpnInspectionProfileID = d.Get("zpn_inspection_profile_id").(string)
+}
+
+func flattenPolicyRuleInspectionAttributes(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("zpn_inspection_profile_id", rule.ZpnInspectionProfileID)
+	return nil
+}
+
+func expandPolicyRuleIsolationAttributes(d *schema.ResourceData, rule *extendedPolicyRule) {
+	rule.ZpnIsolationProfileID = d.Get("zpn_isolation_profile_id").(string)
+	rule.ZpnCbiProfileID = d.Get("zpn_cbi_profile_id").(string)
+}
+
+func flattenPolicyRuleIsolationAttributes(d *schema.ResourceData, rule *extendedPolicyRule) diag.Diagnostics {
+	_ = d.Set("zpn_isolation_profile_id", rule.ZpnIsolationProfileID)
+	_ = d.Set("zpn_cbi_profile_id", rule.ZpnCbiProfileID)
+	return nil
+}
@@ -0,0 +1,305 @@
+package zpa
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	client "github.com/zscaler/zscaler-sdk-go/zpa"
+)
+
+// reservedPolicyRuleNames are the rules ZPA manages itself, they are always kept ahead of the other rules of
+// their policy set.
+var reservedPolicyRuleNames = []string{"Zscaler Deception"}
+
+var policyRuleOrderTypes = []string{
+	"ACCESS_POLICY", "GLOBAL_POLICY",
+	"TIMEOUT_POLICY", "REAUTH_POLICY",
+	"CLIENT_FORWARDING_POLICY", "BYPASS_POLICY",
+	"ISOLATION_POLICY", "INSPECTION_POLICY",
+	"SIEM_POLICY", "CREDENTIAL_POLICY", "CAPABILITIES_POLICY",
+	"REDIRECTION_POLICY", "CLIENTLESS_SESSION_PROTECTION_POLICY",
+}
+
+func resourcePolicyRuleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleOrderCreate,
+		ReadContext:   resourcePolicyRuleOrderRead,
+		UpdateContext: resourcePolicyRuleOrderUpdate,
+		DeleteContext: resourcePolicyRuleOrderDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if !contains(policyRuleOrderTypes, d.Id()) {
+					return nil, fmt.Errorf("the import ID must be one of the policy types %s", strings.Join(policyRuleOrderTypes, ", "))
+				}
+				_ = d.Set("policy_type", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The policy type of the policy set whose rules are ordered.",
+				ValidateFunc: validation.StringInSlice(policyRuleOrderTypes, false),
+			},
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The IDs of the rules in the order they are evaluated. Rules of the policy set that aren't listed keep their relative order after the listed ones, and reserved rules such as Zscaler Deception always stay first.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policy_set_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pinned_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the reserved rules kept ahead of the ordered rules.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcePolicyRuleOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policyType := d.Get("policy_type").(string)
+	log.Printf("[INFO] Ordering the rules of the %s policy set\n", policyType)
+	if err := applyPolicyRuleOrder(zClient, policyType, ListToStringSlice(d.Get("rule_ids").([]interface{}))); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyType)
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+func resourcePolicyRuleOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policyType := d.Get("policy_type").(string)
+	current, err := getPolicyRuleOrder(zClient, policyType)
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Removing policy rule order %s from state because the policy set no longer exists in ZPA", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	managed := ListToStringSlice(d.Get("rule_ids").([]interface{}))
+	observed := current.observed(managed)
+	if len(managed) == 0 {
+		// imported, every rule of the policy set becomes managed
+		observed = current.unpinned()
+	} else if !d.IsNewResource() && !isSameSlice(managed, observed) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The rules of the %s policy set were reordered outside of Terraform", policyType),
+			Detail:   current.describeMoves(managed, observed),
+		})
+	}
+
+	_ = d.Set("policy_set_id", current.policySetID)
+	_ = d.Set("rule_ids", observed)
+	_ = d.Set("pinned_rule_ids", current.pinnedIDs())
+	return diags
+}
+
+func resourcePolicyRuleOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
+	policyType := d.Get("policy_type").(string)
+	log.Printf("[INFO] Reordering the rules of the %s policy set\n", policyType)
+	if err := applyPolicyRuleOrder(zClient, policyType, ListToStringSlice(d.Get("rule_ids").([]interface{}))); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+func resourcePolicyRuleOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the rules keep their order, only Terraform stops managing it
+	log.Printf("[INFO] Removing policy rule order %s from state\n", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// policyRuleOrder is the current order of the rules of a policy set, the default rule always stays last and
+// isn't part of it.
+type policyRuleOrder struct {
+	policySetID string
+	ruleIDs     []string
+	pinned      map[string]bool
+}
+
+func getPolicyRuleOrder(zClient *Client, policyType string) (*policyRuleOrder, error) {
+	policySet, _, err := zClient.policysetcontroller.GetByPolicyType(policyType)
+	if err != nil {
+		return nil, err
+	}
+	list, err := getOrderedRules(zClient, policyType)
+	if err != nil {
+		return nil, err
+	}
+	order := &policyRuleOrder{policySetID: policySet.ID, pinned: map[string]bool{}}
+	for _, rule := range list {
+		order.ruleIDs = append(order.ruleIDs, rule.ID)
+		if contains(reservedPolicyRuleNames, rule.Name) {
+			order.pinned[rule.ID] = true
+		}
+	}
+	return order, nil
+}
+
+func (o *policyRuleOrder) pinnedIDs() []string {
+	var ids []string
+	for _, id := range o.ruleIDs {
+		if o.pinned[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (o *policyRuleOrder) unpinned() []string {
+	var ids []string
+	for _, id := range o.ruleIDs {
+		if !o.pinned[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// desired returns the order of every rule: the reserved rules first, then the managed ones, then the others
+// in their current relative order.
+func (o *policyRuleOrder) desired(managed []string) ([]string, error) {
+	exists := map[string]bool{}
+	for _, id := range o.ruleIDs {
+		exists[id] = true
+	}
+	listed := map[string]bool{}
+	for _, id := range managed {
+		switch {
+		case !exists[id]:
+			return nil, fmt.Errorf("rule %s isn't a reorderable rule of the policy set %s", id, o.policySetID)
+		case o.pinned[id]:
+			return nil, fmt.Errorf("rule %s is reserved and always kept first, remove it from rule_ids", id)
+		case listed[id]:
+			return nil, fmt.Errorf("rule %s is listed more than once in rule_ids", id)
+		}
+		listed[id] = true
+	}
+	desired := append(o.pinnedIDs(), managed...)
+	for _, id := range o.ruleIDs {
+		if !o.pinned[id] && !listed[id] {
+			desired = append(desired, id)
+		}
+	}
+	return desired, nil
+}
+
+// observed returns the managed rules in their current order. A reserved rule that ended up behind one of them
+// is kept at its position, so that the plan restores it.
+func (o *policyRuleOrder) observed(managed []string) []string {
+	listed := map[string]bool{}
+	for _, id := range managed {
+		listed[id] = true
+	}
+	var ids []string
+	seenManaged := false
+	for _, id := range o.ruleIDs {
+		if listed[id] && !o.pinned[id] {
+			ids = append(ids, id)
+			seenManaged = true
+		} else if o.pinned[id] && seenManaged {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (o *policyRuleOrder) describeMoves(managed, observed []string) string {
+	position := map[string]int{}
+	for i, id := range observed {
+		position[id] = i + 1
+	}
+	var moves []string
+	for i, id := range managed {
+		if p, ok := position[id]; !ok {
+			moves = append(moves, fmt.Sprintf("rule %s no longer exists", id))
+		} else if p != i+1 {
+			moves = append(moves, fmt.Sprintf("rule %s moved from position %d to %d", id, i+1, p))
+		}
+	}
+	for _, id := range observed {
+		if o.pinned[id] {
+			moves = append(moves, fmt.Sprintf("reserved rule %s is no longer ahead of the ordered rules", id))
+		}
+	}
+	return strings.Join(moves, "\n")
+}
+
+// applyPolicyRuleOrder puts the rules of the policy set in order with the bulk reorder API. When the API
+// doesn't offer it, the rules are moved one at a time in a single pass from the top, a move only shifts the
+// rules below the position it fills.
+func applyPolicyRuleOrder(zClient *Client, policyType string, managed []string) error {
+	current, err := getPolicyRuleOrder(zClient, policyType)
+	if err != nil {
+		return err
+	}
+	desired, err := current.desired(managed)
+	if err != nil {
+		return err
+	}
+	if isSameSlice(current.ruleIDs, desired) {
+		return nil
+	}
+	_, err = zClient.policyrulereorder.BulkReorder(current.policySetID, desired)
+	if err == nil || !bulkReorderUnsupported(err) {
+		return err
+	}
+	log.Printf("[WARN] bulk reorder isn't available, moving the rules of the %s policy set one at a time: %v\n", policyType, err)
+	order := append([]string{}, current.ruleIDs...)
+	for i, id := range desired {
+		if order[i] == id {
+			continue
+		}
+		if _, err := zClient.policysetcontroller.Reorder(current.policySetID, id, i+1); err != nil {
+			return err
+		}
+		for j := i + 1; j < len(order); j++ {
+			if order[j] == id {
+				copy(order[i+1:j+1], order[i:j])
+				order[i] = id
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func bulkReorderUnsupported(err error) bool {
+	respErr, ok := err.(*client.ErrorResponse)
+	if !ok || respErr.Response == nil {
+		return false
+	}
+	switch respErr.Response.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+)
+
+func TestAccPolicyRuleOrderBasic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyRuleOrder + ".this"
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPolicyRuleOrderConfigure(rName, "c", "a", "b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceTypeAndName, "policy_type", "TIMEOUT_POLICY"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "rule_ids.#", "3"),
+					testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "c", "a", "b"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyRuleOrderConfigure(rName, "b", "c", "a"),
+				Check:  testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "b", "c", "a"),
+			},
+			// a rule moved outside of Terraform is put back
+			{
+				PreConfig: func() {
+					apiClient := provider.Meta().(*Client)
+					rule, _, err := apiClient.policysetcontroller.GetByNameAndType("TIMEOUT_POLICY", rName+"-a")
+					if err != nil {
+						t.Fatalf("failed fetching rule %s-a: %v", rName, err)
+					}
+					if _, err := apiClient.policysetcontroller.Reorder(rule.PolicySetID, rule.ID, 1); err != nil {
+						t.Fatalf("failed moving rule %s-a: %v", rName, err)
+					}
+				},
+				Config: testAccCheckPolicyRuleOrderConfigure(rName, "b", "c", "a"),
+				Check:  testAccCheckPolicyRuleOrder(provider, "TIMEOUT_POLICY", rName, "b", "c", "a"),
+			},
+			{
+				ResourceName:      resourceTypeAndName,
+				ImportState:       true,
+				ImportStateId:     "TIMEOUT_POLICY",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckPolicyRuleOrder checks the relative order of the named rules in the policy set.
+func testAccCheckPolicyRuleOrder(provider *schema.Provider, policyType, rName string, suffixes ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		var previous int
+		for _, suffix := range suffixes {
+			rule, _, err := apiClient.policysetcontroller.GetByNameAndType(policyType, rName+"-"+suffix)
+			if err != nil {
+				return fmt.Errorf("failed fetching rule %s-%s. Recevied error: %s", rName, suffix, err)
+			}
+			order, _ := strconv.Atoi(rule.RuleOrder)
+			if order <= previous {
+				return fmt.Errorf("rule %s-%s has order %d, expected it after order %d", rName, suffix, order, previous)
+			}
+			previous = order
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyRuleOrderConfigure(rName string, order ...string) string {
+	config := `
+data "zpa_policy_type" "timeout_policy" {
+	policy_type = "TIMEOUT_POLICY"
+}
+`
+	for _, suffix := range []string{"a", "b", "c"} {
+		config += fmt.Sprintf(`
+resource "%s" "%s" {
+	name                = "%s-%s"
+	action              = "RE_AUTH"
+	reauth_idle_timeout = "600"
+	reauth_timeout      = "172800"
+	operator            = "AND"
+	policy_set_id       = data.zpa_policy_type.timeout_policy.id
+}
+`, resourcetype.ZPAPolicyTimeOutRule, suffix, rName, suffix)
+	}
+	ruleIDs := ""
+	for _, suffix := range order {
+		ruleIDs += fmt.Sprintf("%s.%s.id, ", resourcetype.ZPAPolicyTimeOutRule, suffix)
+	}
+	return config + fmt.Sprintf(`
+resource "%s" "this" {
+	policy_type = "TIMEOUT_POLICY"
+	rule_ids    = [%s]
+}
+`, resourcetype.ZPAPolicyRuleOrder, ruleIDs)
+}
+
+func TestPolicyRuleOrderDesired(t *testing.T) {
+	order := &policyRuleOrder{
+		policySetID: "1",
+		ruleIDs:     []string{"deception", "a", "b", "c", "d"},
+		pinned:      map[string]bool{"deception": true},
+	}
+	desired, err := order.desired([]string{"c", "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isSameSlice(desired, []string{"deception", "c", "a", "b", "d"}) {
+		t.Errorf("unexpected order %v", desired)
+	}
+	for _, managed := range [][]string{{"a", "a"}, {"deception", "a"}, {"e"}} {
+		if _, err := order.desired(managed); err == nil {
+			t.Errorf("expected %v to be rejected", managed)
+		}
+	}
+
+	order.ruleIDs = []string{"a", "deception", "c", "b", "d"}
+	if observed := order.observed([]string{"a", "b", "c"}); !isSameSlice(observed, []string{"a", "deception", "c", "b"}) {
+		t.Errorf("unexpected observed order %v", observed)
+	}
+}
+
+// fakeUnsupportedBulkReorder answers bulk reorder requests like an API without the endpoint.
+type fakeUnsupportedBulkReorder struct {
+	calls int
+}
+
+func (f *fakeUnsupportedBulkReorder) BulkReorder(policySetID string, ruleIDs []string) (*http.Response, error) {
+	f.calls++
+	return nil, fakeAPIError(http.StatusNotFound, http.MethodPut, "policySet/"+policySetID+"/reorder", "", "not found")
+}
+
+func TestApplyPolicyRuleOrderWithoutBulkReorder(t *testing.T) {
+	zClient := newFakeClient()
+	bulk := &fakeUnsupportedBulkReorder{}
+	zClient.policyrulereorder = bulk
+	set, _, _ := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
+	ids := map[string]string{}
+	for _, name := range []string{"Zscaler Deception", "a", "b", "c", "d", "e"} {
+		rule, _, err := zClient.policysetcontroller.Create(&policysetcontroller.PolicyRule{Name: name, PolicySetID: set.ID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids[name] = rule.ID
+	}
+	// the reserved rule has been pushed down
+	if _, err := zClient.policysetcontroller.Reorder(set.ID, ids["Zscaler Deception"], 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := applyPolicyRuleOrder(zClient, "ACCESS_POLICY", []string{ids["e"], ids["c"], ids["a"]}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bulk.calls != 1 {
+		t.Errorf("expected one bulk reorder attempt, got %d", bulk.calls)
+	}
+	current, err := getPolicyRuleOrder(zClient, "ACCESS_POLICY")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{ids["Zscaler Deception"], ids["e"], ids["c"], ids["a"], ids["b"], ids["d"]}
+	if !isSameSlice(current.ruleIDs, expected) {
+		t.Errorf("expected order %v, got %v", expected, current.ruleIDs)
+	}
+}
+
+func TestBulkReorderUnsupported(t *testing.T) {
+	for status, expected := range map[int]bool{
+		http.StatusNotFound:         true,
+		http.StatusMethodNotAllowed: true,
+		http.StatusNotImplemented:   true,
+		http.StatusBadRequest:       false,
+	} {
+		err := fakeAPIError(status, http.MethodPut, "policySet/1/reorder", "", "")
+		if got := bulkReorderUnsupported(err); got != expected {
+			t.Errorf("status %d: expected %v, got %v", status, expected, got)
+		}
+	}
+	if bulkReorderUnsupported(fmt.Errorf("network error")) {
+		t.Error("expected errors without a response to be returned as is")
+	}
+}
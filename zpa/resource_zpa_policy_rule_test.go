@@ -0,0 +1,194 @@
+package zpa
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
+)
+
+func TestAccPolicyRuleBasic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceTypeAndName := resourcetype.ZPAPolicyRule + ".this"
+	redirection := `
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "COUNTRY_CODE"
+			lhs         = "CA"
+			rhs         = "true"
+		}
+		operands {
+			object_type = "CLIENT_TYPE"
+			lhs         = "id"
+			rhs         = "zpn_client_type_zapp"
+		}
+	}`
+
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyRuleDestroy(provider),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckPolicyRuleConfigure(rName, "REDIRECTION_POLICY", "ALLOW", redirection),
+				ExpectError: regexp.MustCompile(`action "ALLOW" isn't an action of REDIRECTION_POLICY rules`),
+			},
+			{
+				Config: testAccCheckPolicyRuleConfigure(rName, "REDIRECTION_POLICY", "REDIRECT_DEFAULT", `
+	conditions {
+		operator = "OR"
+		operands {
+			object_type = "PLATFORM"
+			lhs         = "windows"
+			rhs         = "true"
+		}
+	}`),
+				ExpectError: regexp.MustCompile(`conditions\.0\.operands\.0\.object_type: .*must be one of "CLIENT_TYPE", "COUNTRY_CODE" for REDIRECTION_POLICY rules`),
+			},
+			{
+				Config:      testAccCheckPolicyRuleConfigure(rName, "REDIRECTION_POLICY", "REDIRECT_DEFAULT", `reauth_timeout = "172800"`),
+				ExpectError: regexp.MustCompile(`reauth_timeout can't be set on REDIRECTION_POLICY rules`),
+			},
+			{
+				Config: testAccCheckPolicyRuleConfigure(rName, "REDIRECTION_POLICY", "REDIRECT_DEFAULT", redirection),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "policy_type", "REDIRECTION_POLICY"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "action", "REDIRECT_DEFAULT"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "conditions.0.operands.#", "2"),
+				),
+			},
+			{
+				Config:      testAccCheckPolicyRuleConfigure(rName, "REDIRECTION_POLICY", "REDIRECT_ALWAYS", redirection),
+				ExpectError: regexp.MustCompile(`at least one service edge group must be set when action is REDIRECT_ALWAYS`),
+			},
+			{
+				ResourceName:            resourceTypeAndName,
+				ImportState:             true,
+				ImportStateId:           "REDIRECTION_POLICY:" + rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule_order"},
+			},
+			{
+				Config:      testAccCheckPolicyRuleConfigure(rName, "CREDENTIAL_POLICY", "INJECT_CREDENTIALS", ""),
+				ExpectError: regexp.MustCompile(`credential must be set on CREDENTIAL_POLICY rules`),
+			},
+			{
+				Config: testAccCheckPolicyRuleConfigure(rName, "CAPABILITIES_POLICY", "CHECK_CAPABILITIES", `
+	privileged_capabilities {
+		clipboard_copy = true
+		file_upload    = true
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "policy_type", "CAPABILITIES_POLICY"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.clipboard_copy", "true"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "privileged_capabilities.0.file_download", "false"),
+				),
+			},
+			{
+				Config: testAccCheckPolicyRuleConfigure(rName, "TIMEOUT_POLICY", "RE_AUTH", `
+	privileged_capabilities {
+		clipboard_copy = true
+	}`),
+				ExpectError: regexp.MustCompile(`privileged_capabilities can't be set on TIMEOUT_POLICY rules`),
+			},
+			{
+				Config: testAccCheckPolicyRuleConfigure(rName, "TIMEOUT_POLICY", "RE_AUTH", `
+	reauth_timeout      = "172800"
+	reauth_idle_timeout = "600"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyRuleExists(provider, resourceTypeAndName),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "policy_type", "TIMEOUT_POLICY"),
+					resource.TestCheckResourceAttr(resourceTypeAndName, "reauth_timeout", "172800"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPolicyRuleConfigure(rName, policyType, action, body string) string {
+	return fmt.Sprintf(`
+resource "%s" "this" {
+	name        = "%s"
+	policy_type = "%s"
+	action      = "%s"
+	operator    = "AND"
+	%s
+}
+`, resourcetype.ZPAPolicyRule, rName, policyType, action, body)
+}
+
+func testAccCheckPolicyRuleDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAPolicyRule {
+				continue
+			}
+			policySet, _, err := apiClient.policysetcontroller.GetByPolicyType(rs.Primary.Attributes["policy_type"])
+			if err != nil {
+				return err
+			}
+			rule, _, err := apiClient.policysetcontroller.GetPolicyRule(policySet.ID, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
+			if rule != nil {
+				return fmt.Errorf("policy rule with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyRuleExists(provider *schema.Provider, resource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("didn't find resource: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		apiClient := provider.Meta().(*Client)
+		policySet, _, err := apiClient.policysetcontroller.GetByPolicyType(rs.Primary.Attributes["policy_type"])
+		if err != nil {
+			return err
+		}
+		if _, _, err := apiClient.policysetcontroller.GetPolicyRule(policySet.ID, rs.Primary.ID); err != nil {
+			return fmt.Errorf("failed fetching resource %s. Recevied error: %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func TestPolicyRuleTypesAreValidated(t *testing.T) {
+	zClient := newFakeClient()
+	for policyType, ruleType := range policyRuleTypes {
+		if len(ruleType.actions) == 0 || len(ruleType.objectTypes) == 0 {
+			t.Errorf("%s has no actions or object types", policyType)
+		}
+		if _, _, err := zClient.policysetcontroller.GetByPolicyType(policyType); err != nil {
+			t.Errorf("%s isn't a policy type of the API: %v", policyType, err)
+		}
+		for _, attribute := range ruleType.attributes {
+			if _, ok := resourcePolicyRule().Schema[attribute]; !ok {
+				t.Errorf("%s takes %s, which zpa_policy_rule doesn't have", policyType, attribute)
+			}
+		}
+		if ruleType.attributeSchema != nil {
+			for attribute := range ruleType.attributeSchema() {
+				if !contains(ruleType.attributes, attribute) {
+					t.Errorf("%s has a schema for %s, which isn't one of its attributes", policyType, attribute)
+				}
+			}
+		}
+	}
+}
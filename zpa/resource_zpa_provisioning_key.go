@@ -1,11 +1,11 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/provisioningkey"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,13 +14,14 @@ import (
 
 func resourceProvisioningKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProvisioningKeyCreate,
-		Read:   resourceProvisioningKeyRead,
-		Update: resourceProvisioningKeyUpdate,
-		Delete: resourceProvisioningKeyDelete,
+		CreateContext: resourceProvisioningKeyCreate,
+		ReadContext:   resourceProvisioningKeyRead,
+		UpdateContext: resourceProvisioningKeyUpdate,
+		DeleteContext: resourceProvisioningKeyDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
 				_, associationTypeSet := d.GetOk("association_type")
@@ -143,39 +144,39 @@ func getAssociationType(d *schema.ResourceData) (string, bool) {
 	return value, ok
 }
 
-func resourceProvisioningKeyCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceProvisioningKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	associationType, ok := getAssociationType(d)
 	if !ok {
-		return fmt.Errorf("associationType is required")
+		return diag.Errorf("associationType is required")
 	}
 	req := expandProvisioningKey(d)
 	log.Printf("[INFO] Creating zpa provisining key with request\n%+v\n", req)
 
 	resp, _, err := zClient.provisioningkey.Create(associationType, &req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "provisioning key", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created provisining key  request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceProvisioningKeyRead(d, m)
+	return resourceProvisioningKeyRead(ctx, d, m)
 }
 
-func resourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceProvisioningKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	associationType, ok := getAssociationType(d)
 	if !ok {
-		return fmt.Errorf("associationType is required")
+		return diag.Errorf("associationType is required")
 	}
 	resp, _, err := zClient.provisioningkey.Get(associationType, d.Id())
 	if err != nil {
-		if obj, ok := err.(*client.ErrorResponse); ok && obj.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing provisining key %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting provisining key:\n%+v\n", resp)
@@ -197,39 +198,39 @@ func resourceProvisioningKeyRead(d *schema.ResourceData, m interface{}) error {
 
 }
 
-func resourceProvisioningKeyUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceProvisioningKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	associationType, ok := getAssociationType(d)
 	if !ok {
-		return fmt.Errorf("associationType is required")
+		return diag.Errorf("associationType is required")
 	}
 	id := d.Id()
 	log.Printf("[INFO] Updating provisining key ID: %v\n", id)
 	req := expandProvisioningKey(d)
 	if _, _, err := zClient.provisioningkey.Get(associationType, id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "provisioning key")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.provisioningkey.Update(associationType, id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "provisioning key", d.Get("name").(string)))
 	}
 
-	return resourceProvisioningKeyRead(d, m)
+	return resourceProvisioningKeyRead(ctx, d, m)
 }
 
-func resourceProvisioningKeyDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceProvisioningKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	associationType, ok := getAssociationType(d)
 	if !ok {
-		return fmt.Errorf("associationType is required")
+		return diag.Errorf("associationType is required")
 	}
 	log.Printf("[INFO] Deleting provisining key  ID: %v\n", d.Id())
 
 	if _, err := zClient.provisioningkey.Delete(associationType, d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	log.Printf("[INFO] provisining key  deleted")
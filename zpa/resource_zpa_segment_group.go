@@ -1,26 +1,26 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
-	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
 )
 
 func resourceSegmentGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSegmentGroupCreate,
-		Read:   resourceSegmentGroupRead,
-		Update: resourceSegmentGroupUpdate,
-		Delete: resourceSegmentGroupDelete,
+		CreateContext: resourceSegmentGroupCreate,
+		ReadContext:   resourceSegmentGroupRead,
+		UpdateContext: resourceSegmentGroupUpdate,
+		DeleteContext: resourceSegmentGroupDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -96,35 +96,35 @@ func resourceSegmentGroup() *schema.Resource {
 	}
 }
 
-func resourceSegmentGroupCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceSegmentGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandSegmentGroup(d)
 	log.Printf("[INFO] Creating segment group with request\n%+v\n", req)
 
 	segmentgroup, _, err := zClient.segmentgroup.Create(&req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "segment group", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created segment group request. ID: %v\n", segmentgroup)
 
 	d.SetId(segmentgroup.ID)
-	return resourceSegmentGroupRead(d, m)
+	return resourceSegmentGroupRead(ctx, d, m)
 
 }
 
-func resourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceSegmentGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.segmentgroup.Get(d.Id())
 	if err != nil {
-		if errResp, ok := err.(*client.ErrorResponse); ok && errResp.IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing segment group %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting segment group:\n%+v\n", resp)
@@ -136,7 +136,7 @@ func resourceSegmentGroupRead(d *schema.ResourceData, m interface{}) error {
 	_ = d.Set("policy_migrated", resp.PolicyMigrated)
 	_ = d.Set("tcp_keep_alive_enabled", resp.TcpKeepAliveEnabled)
 	if err := d.Set("applications", flattenSegmentGroupApplicationsSimple(resp)); err != nil {
-		return fmt.Errorf("failed to read applications %s", err)
+		return diag.Errorf("failed to read applications %s", err)
 	}
 	return nil
 }
@@ -151,74 +151,41 @@ func flattenSegmentGroupApplicationsSimple(segmentGroup *segmentgroup.SegmentGro
 
 	return segmentGroupApplications
 }
-func resourceSegmentGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceSegmentGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	id := d.Id()
 	log.Printf("[INFO] Updating segment group ID: %v\n", id)
 	req := expandSegmentGroup(d)
 
 	if _, _, err := zClient.segmentgroup.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "segment group")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.segmentgroup.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "segment group", d.Get("name").(string)))
 	}
 
-	return resourceSegmentGroupRead(d, m)
-}
-
-func detachSegmentGroupFromAllPolicyRules(id string, zClient *Client) {
-	var rules []policysetcontroller.PolicyRule
-	types := []string{"ACCESS_POLICY", "TIMEOUT_POLICY", "SIEM_POLICY", "CLIENT_FORWARDING_POLICY", "INSPECTION_POLICY"}
-	for _, t := range types {
-		policySet, _, err := zClient.policysetcontroller.GetByPolicyType(t)
-		if err != nil {
-			continue
-		}
-		r, _, err := zClient.policysetcontroller.GetAllByType(t)
-		if err != nil {
-			continue
-		}
-		for _, rule := range r {
-			rule.PolicySetID = policySet.ID
-			rules = append(rules, rule)
-		}
-	}
-	for _, rule := range rules {
-		for i, condition := range rule.Conditions {
-			var operands []policysetcontroller.Operands
-			for _, op := range condition.Operands {
-				if op.ObjectType == "APP_GROUP" && op.LHS == "id" && op.RHS == id {
-					continue
-				}
-				operands = append(operands, op)
-			}
-			rule.Conditions[i].Operands = operands
-		}
-		if _, err := zClient.policysetcontroller.Update(rule.PolicySetID, rule.ID, &rule); err != nil {
-			continue
-		}
-	}
+	return resourceSegmentGroupRead(ctx, d, m)
 }
 
-func resourceSegmentGroupDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceSegmentGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting segment group ID: %v\n", d.Id())
-
-	detachSegmentGroupFromAllPolicyRules(d.Id(), zClient)
-
-	if _, err := zClient.segmentgroup.Delete(d.Id()); err != nil {
+	diags := deleteReferencedObject(zClient, objectReference{"segment group", d.Id()}, func() error {
+		_, err := zClient.segmentgroup.Delete(d.Id())
 		return err
+	})
+	if diags.HasError() {
+		return diags
 	}
 	d.SetId("")
 	log.Printf("[INFO] segment group deleted")
-	return nil
+	return diags
 }
 
 func expandSegmentGroup(d *schema.ResourceData) segmentgroup.SegmentGroup {
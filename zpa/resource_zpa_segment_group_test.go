@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -17,15 +18,15 @@ func TestAccResourceSegmentGroupBasic(t *testing.T) {
 	var segmentGroup segmentgroup.SegmentGroup
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPASegmentGroup)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckSegmentGroupDestroy,
+		CheckDestroy: testAccCheckSegmentGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckSegmentGroupConfigure(resourceTypeAndName, generatedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSegmentGroupExists(resourceTypeAndName, &segmentGroup),
+					testAccCheckSegmentGroupExists(provider, resourceTypeAndName, &segmentGroup),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.SegmentGroupDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.SegmentGroupEnabled)),
@@ -36,7 +37,7 @@ func TestAccResourceSegmentGroupBasic(t *testing.T) {
 			{
 				Config: testAccCheckSegmentGroupConfigure(resourceTypeAndName, generatedName, variable.SegmentGroupDescription, variable.SegmentGroupEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckSegmentGroupExists(resourceTypeAndName, &segmentGroup),
+					testAccCheckSegmentGroupExists(provider, resourceTypeAndName, &segmentGroup),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.SegmentGroupDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.SegmentGroupEnabled)),
@@ -46,29 +47,31 @@ func TestAccResourceSegmentGroupBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckSegmentGroupDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckSegmentGroupDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPASegmentGroup {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPASegmentGroup {
+				continue
+			}
 
-		group, _, err := apiClient.segmentgroup.Get(rs.Primary.ID)
+			group, _, err := apiClient.segmentgroup.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if group != nil {
-			return fmt.Errorf("segment group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if group != nil {
+				return fmt.Errorf("segment group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckSegmentGroupExists(resource string, group *segmentgroup.SegmentGroup) resource.TestCheckFunc {
+func testAccCheckSegmentGroupExists(provider *schema.Provider, resource string, group *segmentgroup.SegmentGroup) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -78,7 +81,7 @@ func testAccCheckSegmentGroupExists(resource string, group *segmentgroup.Segment
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedGroup, _, err := apiClient.segmentgroup.Get(rs.Primary.ID)
 
 		if err != nil {
@@ -1,31 +1,26 @@
 package zpa
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
-	"sync"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
-	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
-	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
-	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
 )
 
-var detachLock sync.Mutex
-
 func resourceServerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceServerGroupCreate,
-		Read:   resourceServerGroupRead,
-		Update: resourceServerGroupUpdate,
-		Delete: resourceServerGroupDelete,
+		CreateContext: resourceServerGroupCreate,
+		ReadContext:   resourceServerGroupRead,
+		UpdateContext: resourceServerGroupUpdate,
+		DeleteContext: resourceServerGroupDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -138,41 +133,41 @@ func resourceServerGroup() *schema.Resource {
 	}
 }
 
-func resourceServerGroupCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	req := expandServerGroup(d)
 	log.Printf("[INFO] Creating zpa server group with request\n%+v\n", req)
 	if len(req.Servers) > 0 && req.DynamicDiscovery {
 		log.Printf("[ERROR] An application server can only be attached to a server when DynamicDiscovery is disabled\n")
-		return fmt.Errorf("an application server can only be attached to a server when DynamicDiscovery is disabled")
+		return diag.Errorf("an application server can only be attached to a server when DynamicDiscovery is disabled")
 	}
 	if !req.DynamicDiscovery && len(req.Servers) == 0 {
 		log.Printf("[ERROR] Servers must not be empty when DynamicDiscovery is disabled\n")
-		return fmt.Errorf("servers must not be empty when DynamicDiscovery is disabled")
+		return diag.Errorf("servers must not be empty when DynamicDiscovery is disabled")
 	}
 	resp, _, err := zClient.servergroup.Create(&req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "server group", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created server group request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceServerGroupRead(d, m)
+	return resourceServerGroupRead(ctx, d, m)
 }
 
-func resourceServerGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.servergroup.Get(d.Id())
 	if err != nil {
-		if err.(*client.ErrorResponse).IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing server group %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting server group:\n%+v\n", resp)
@@ -214,130 +209,47 @@ func flattenServerGroupApplicationsSimple(apps []servergroup.Applications) []int
 	result[0] = mapIds
 	return result
 }
-func resourceServerGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	id := d.Id()
 	log.Printf("[INFO] Updating server group ID: %v\n", id)
 	req := expandServerGroup(d)
 	if (d.HasChange("servers") || d.HasChange("dynamic_discovery")) && req.DynamicDiscovery && len(req.Servers) > 0 {
 		log.Printf("[ERROR] Can't update the server group: an application server can only be attached to a server when DynamicDiscovery is disabled\n")
-		return fmt.Errorf("can't perform the changes: an application server can only be attached to a server when DynamicDiscovery is disabled")
+		return diag.Errorf("can't perform the changes: an application server can only be attached to a server when DynamicDiscovery is disabled")
 	}
 	if (d.HasChange("servers") || d.HasChange("dynamic_discovery")) && !req.DynamicDiscovery && len(req.Servers) == 0 {
 		log.Printf("[ERROR] Can't update server group: servers must not be empty when DynamicDiscovery is disabled\n")
-		return fmt.Errorf("can't update server group: servers must not be empty when DynamicDiscovery is disabled")
+		return diag.Errorf("can't update server group: servers must not be empty when DynamicDiscovery is disabled")
 	}
 
 	if _, _, err := zClient.servergroup.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "server group")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.servergroup.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "server group", d.Get("name").(string)))
 	}
-	return resourceServerGroupRead(d, m)
+	return resourceServerGroupRead(ctx, d, m)
 }
 
-func detachServerGroupFromAllAccessPolicyRules(id string, zClient *Client) {
-	accessPolicySet, _, err := zClient.policysetcontroller.GetByPolicyType("ACCESS_POLICY")
-	if err != nil {
-		return
-	}
-	accessPolicyRules, _, err := zClient.policysetcontroller.GetAllByType("ACCESS_POLICY")
-	if err != nil {
-		return
-	}
-	for _, accessPolicyRule := range accessPolicyRules {
-		ids := []policysetcontroller.AppServerGroups{}
-		for _, app := range accessPolicyRule.AppServerGroups {
-			if app.ID == id {
-				continue
-			}
-			ids = append(ids, policysetcontroller.AppServerGroups{
-				ID: app.ID,
-			})
-		}
-		accessPolicyRule.AppServerGroups = ids
-		if _, err := zClient.policysetcontroller.Update(accessPolicySet.ID, accessPolicyRule.ID, &accessPolicyRule); err != nil {
-			continue
-		}
-	}
-}
-
-func detachServerGroupFromAllAppSegments(id string, zClient *Client) {
-
-	apps, _, err := zClient.applicationsegment.GetAll()
-	if err != nil {
-		return
-	}
-	for _, app := range apps {
-		ids := []applicationsegment.AppServerGroups{}
-		for _, app := range app.ServerGroups {
-			if app.ID == id {
-				continue
-			}
-			ids = append(ids, applicationsegment.AppServerGroups{
-				ID: app.ID,
-			})
-		}
-		app.ServerGroups = ids
-		if _, err := zClient.applicationsegment.Update(app.ID, app); err != nil {
-			continue
-		}
-	}
-}
-
-func resourceServerGroupDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting server group ID: %v\n", d.Id())
-	err := detachServerGroupFromAppConnectorGroups(zClient, d.Id())
-	if err != nil {
-		log.Printf("[ERROR] Detaching server group ID: %v from app connector groups failed:%v\n", d.Id(), err)
-	}
-
-	detachServerGroupFromAllAccessPolicyRules(d.Id(), zClient)
-	detachServerGroupFromAllAppSegments(d.Id(), zClient)
-
-	if _, err := zClient.servergroup.Delete(d.Id()); err != nil {
+	diags := deleteReferencedObject(zClient, objectReference{"server group", d.Id()}, func() error {
+		_, err := zClient.servergroup.Delete(d.Id())
 		return err
+	})
+	if diags.HasError() {
+		return diags
 	}
 	d.SetId("")
 	log.Printf("[INFO] server group deleted")
-	return nil
-}
-
-func detachServerGroupFromAppConnectorGroups(client *Client, serverGroupID string) error {
-	log.Printf("[INFO] Detaching Server Group  %s from App Connector Groups\n", serverGroupID)
-	serverGroup, _, err := client.servergroup.Get(serverGroupID)
-	if err != nil {
-		return err
-	}
-	// lock to avoid updating app connector group with a deleted server group ID when running in parallel
-	detachLock.Lock()
-	defer detachLock.Unlock()
-	for _, appConnectorGroup := range serverGroup.AppConnectorGroups {
-		app, _, err := client.appconnectorgroup.Get(appConnectorGroup.ID)
-		if err != nil {
-			continue
-		}
-		appServerGroups := []appconnectorgroup.AppServerGroup{}
-		for _, s := range app.AppServerGroup {
-			if s.ID == serverGroupID {
-				continue
-			}
-			appServerGroups = append(appServerGroups, s)
-		}
-		app.AppServerGroup = appServerGroups
-		_, err = client.appconnectorgroup.Update(app.ID, app)
-		if err != nil {
-			continue
-		}
-	}
-	return nil
+	return diags
 }
 
 func expandServerGroup(d *schema.ResourceData) servergroup.ServerGroup {
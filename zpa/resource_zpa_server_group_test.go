@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -19,15 +20,15 @@ func TestAccResourceServerGroupBasic(t *testing.T) {
 
 	appConnectorGroupTypeAndName, _, appConnectorGroupGeneratedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAAppConnectorGroup)
 	appConnectorGroupHCL := testAccCheckAppConnectorGroupConfigure(appConnectorGroupTypeAndName, appConnectorGroupGeneratedName, variable.AppConnectorDescription, variable.AppConnectorEnabled)
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckServerGroupDestroy,
+		CheckDestroy: testAccCheckServerGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckServerGroupConfigure(serverGroupTypeAndName, serverGroupGeneratedName, serverGroupGeneratedName, serverGroupGeneratedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, variable.ServerGroupEnabled, variable.ServerGroupDynamicDiscovery),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServerGroupExists(serverGroupTypeAndName, &serverGroup),
+					testAccCheckServerGroupExists(provider, serverGroupTypeAndName, &serverGroup),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "name", "tf-acc-test-"+serverGroupGeneratedName),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "description", "tf-acc-test-"+serverGroupGeneratedName),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "enabled", strconv.FormatBool(variable.ServerGroupEnabled)),
@@ -40,7 +41,7 @@ func TestAccResourceServerGroupBasic(t *testing.T) {
 			{
 				Config: testAccCheckServerGroupConfigure(serverGroupTypeAndName, serverGroupGeneratedName, serverGroupGeneratedName, serverGroupGeneratedName, appConnectorGroupHCL, appConnectorGroupTypeAndName, variable.ServerGroupEnabled, variable.ServerGroupDynamicDiscovery),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServerGroupExists(serverGroupTypeAndName, &serverGroup),
+					testAccCheckServerGroupExists(provider, serverGroupTypeAndName, &serverGroup),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "name", "tf-acc-test-"+serverGroupGeneratedName),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "description", "tf-acc-test-"+serverGroupGeneratedName),
 					resource.TestCheckResourceAttr(serverGroupTypeAndName, "enabled", strconv.FormatBool(variable.ServerGroupEnabled)),
@@ -52,29 +53,31 @@ func TestAccResourceServerGroupBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckServerGroupDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckServerGroupDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAServerGroup {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAServerGroup {
+				continue
+			}
 
-		rule, _, err := apiClient.servergroup.Get(rs.Primary.ID)
+			rule, _, err := apiClient.servergroup.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if rule != nil {
-			return fmt.Errorf("server group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if rule != nil {
+				return fmt.Errorf("server group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckServerGroupExists(resource string, rule *servergroup.ServerGroup) resource.TestCheckFunc {
+func testAccCheckServerGroupExists(provider *schema.Provider, resource string, rule *servergroup.ServerGroup) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -84,7 +87,7 @@ func testAccCheckServerGroupExists(resource string, rule *servergroup.ServerGrou
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedGroup, _, err := apiClient.servergroup.Get(rs.Primary.ID)
 
 		if err != nil {
@@ -1,25 +1,27 @@
 package zpa
 
 import (
+	"context"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	client "github.com/zscaler/zscaler-sdk-go/zpa"
 	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgegroup"
 )
 
 func resourceServiceEdgeGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceServiceEdgeGroupCreate,
-		Read:   resourceServiceEdgeGroupRead,
-		Update: resourceServiceEdgeGroupUpdate,
-		Delete: resourceServiceEdgeGroupDelete,
+		CreateContext: resourceServiceEdgeGroupCreate,
+		ReadContext:   resourceServiceEdgeGroupRead,
+		UpdateContext: resourceServiceEdgeGroupUpdate,
+		DeleteContext: resourceServiceEdgeGroupDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				zClient := m.(*Client)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				zClient := m.(*Client).withContext(ctx)
 
 				id := d.Id()
 				_, parseIDErr := strconv.ParseInt(id, 10, 64)
@@ -172,36 +174,36 @@ func resourceServiceEdgeGroup() *schema.Resource {
 	}
 }
 
-func resourceServiceEdgeGroupCreate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServiceEdgeGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	if err := validateAndSetProfileNameID(d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	req := expandServiceEdgeGroup(d)
 	log.Printf("[INFO] Creating zpa service edge group with request\n%+v\n", req)
 
 	resp, _, err := zClient.serviceedgegroup.Create(req)
 	if err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "service edge group", d.Get("name").(string)))
 	}
 	log.Printf("[INFO] Created service edge group request. ID: %v\n", resp)
 	d.SetId(resp.ID)
 
-	return resourceServiceEdgeGroupRead(d, m)
+	return resourceServiceEdgeGroupRead(ctx, d, m)
 }
 
-func resourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServiceEdgeGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	resp, _, err := zClient.serviceedgegroup.Get(d.Id())
 	if err != nil {
-		if err.(*client.ErrorResponse).IsObjectNotFound() {
+		if isNotFoundError(err) {
 			log.Printf("[WARN] Removing service edge group %s from state because it no longer exists in ZPA", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Getting service edge group:\n%+v\n", resp)
@@ -228,36 +230,36 @@ func resourceServiceEdgeGroupRead(d *schema.ResourceData, m interface{}) error {
 
 }
 
-func resourceServiceEdgeGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServiceEdgeGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 	if err := validateAndSetProfileNameID(d); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	id := d.Id()
 	log.Printf("[INFO] Updating service edge group ID: %v\n", id)
 	req := expandServiceEdgeGroup(d)
 
 	if _, _, err := zClient.serviceedgegroup.Get(id); err != nil {
-		if respErr, ok := err.(*client.ErrorResponse); ok && respErr.IsObjectNotFound() {
-			d.SetId("")
-			return nil
+		if isNotFoundError(err) {
+			return notFoundOnUpdate(d, "service edge group")
 		}
+		return diag.FromErr(err)
 	}
 
 	if _, err := zClient.serviceedgegroup.Update(id, &req); err != nil {
-		return err
+		return diag.FromErr(describeAPIError(err, "service edge group", d.Get("name").(string)))
 	}
 
-	return resourceServiceEdgeGroupRead(d, m)
+	return resourceServiceEdgeGroupRead(ctx, d, m)
 }
 
-func resourceServiceEdgeGroupDelete(d *schema.ResourceData, m interface{}) error {
-	zClient := m.(*Client)
+func resourceServiceEdgeGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zClient := m.(*Client).withContext(ctx)
 
 	log.Printf("[INFO] Deleting service edge group ID: %v\n", d.Id())
 
 	if _, err := zClient.serviceedgegroup.Delete(d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	log.Printf("[INFO] service edge group deleted")
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/resourcetype"
 	"github.com/zscaler/terraform-provider-zpa/v2/zpa/common/testing/method"
@@ -17,15 +18,15 @@ func TestAccResourceServiceEdgeGroupBasic(t *testing.T) {
 	var groups serviceedgegroup.ServiceEdgeGroup
 	resourceTypeAndName, _, generatedName := method.GenerateRandomSourcesTypeAndName(resourcetype.ZPAServiceEdgeGroup)
 
-	resource.Test(t, resource.TestCase{
+	provider := Provider()
+	resourceTest(t, provider, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckServiceEdgeGroupDestroy,
+		CheckDestroy: testAccCheckServiceEdgeGroupDestroy(provider),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckServiceEdgeGroupConfigure(resourceTypeAndName, generatedName, variable.ServiceEdgeDescription, variable.ServiceEdgeEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServiceEdgeGroupExists(resourceTypeAndName, &groups),
+					testAccCheckServiceEdgeGroupExists(provider, resourceTypeAndName, &groups),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.ServiceEdgeDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.ServiceEdgeEnabled)),
@@ -41,7 +42,7 @@ func TestAccResourceServiceEdgeGroupBasic(t *testing.T) {
 			{
 				Config: testAccCheckServiceEdgeGroupConfigure(resourceTypeAndName, generatedName, variable.ServiceEdgeDescription, variable.ServiceEdgeEnabled),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckServiceEdgeGroupExists(resourceTypeAndName, &groups),
+					testAccCheckServiceEdgeGroupExists(provider, resourceTypeAndName, &groups),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "name", generatedName),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "description", variable.ServiceEdgeDescription),
 					resource.TestCheckResourceAttr(resourceTypeAndName, "enabled", strconv.FormatBool(variable.ServiceEdgeEnabled)),
@@ -56,29 +57,31 @@ func TestAccResourceServiceEdgeGroupBasic(t *testing.T) {
 	})
 }
 
-func testAccCheckServiceEdgeGroupDestroy(s *terraform.State) error {
-	apiClient := testAccProvider.Meta().(*Client)
+func testAccCheckServiceEdgeGroupDestroy(provider *schema.Provider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		apiClient := provider.Meta().(*Client)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != resourcetype.ZPAServiceEdgeGroup {
-			continue
-		}
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourcetype.ZPAServiceEdgeGroup {
+				continue
+			}
 
-		group, _, err := apiClient.serviceedgegroup.Get(rs.Primary.ID)
+			group, _, err := apiClient.serviceedgegroup.Get(rs.Primary.ID)
 
-		if err == nil {
-			return fmt.Errorf("id %s already exists", rs.Primary.ID)
-		}
+			if err == nil {
+				return fmt.Errorf("id %s already exists", rs.Primary.ID)
+			}
 
-		if group != nil {
-			return fmt.Errorf("service edge group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			if group != nil {
+				return fmt.Errorf("service edge group with id %s exists and wasn't destroyed", rs.Primary.ID)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
-func testAccCheckServiceEdgeGroupExists(resource string, group *serviceedgegroup.ServiceEdgeGroup) resource.TestCheckFunc {
+func testAccCheckServiceEdgeGroupExists(provider *schema.Provider, resource string, group *serviceedgegroup.ServiceEdgeGroup) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
 		if !ok {
@@ -88,7 +91,7 @@ func testAccCheckServiceEdgeGroupExists(resource string, group *serviceedgegroup
 			return fmt.Errorf("no record ID is set")
 		}
 
-		apiClient := testAccProvider.Meta().(*Client)
+		apiClient := provider.Meta().(*Client)
 		receivedGroup, _, err := apiClient.serviceedgegroup.Get(rs.Primary.ID)
 
 		if err != nil {
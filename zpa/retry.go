@@ -0,0 +1,259 @@
+package zpa
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults for the provider level retry settings. The provider disables the SDK backoff and retries the calls
+// itself, see Config.Client. With the default waits, a call gives up after about 3 minutes of retries.
+const (
+	defaultMaxRetries     = 10
+	defaultMinWaitSeconds = 5
+	defaultMaxWaitSeconds = 20
+	defaultRequestTimeout = 240 * time.Second
+)
+
+// ZPA rate limits are 20 GET calls and 10 POST/PUT/DELETE calls per 10 seconds, see https://help.zscaler.com/zpa/about-rate-limiting
+var defaultRetryOnStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryOnErrorIDs are the IDs of the 400 errors that are retried, the API returns them for calls made while a
+// change it just accepted isn't applied everywhere yet. The generic bad.request isn't retried, most of the time
+// the request itself is wrong and sending it again only delays the error.
+var retryOnErrorIDs = []string{"non.restricted.entity.authorization.failed"}
+
+type RetryConfig struct {
+	// Maximum number of retries for a single API call, 0 disables retries
+	MaxRetries int
+
+	// Lower and upper bound of the wait between two attempts
+	MinWait time.Duration
+	MaxWait time.Duration
+
+	// HTTP status codes that are retried. Connection errors other than certificate errors are retried for the
+	// methods the API can apply twice, see shouldRetry
+	RetryOnStatus []int
+}
+
+// retryTransport is the http.RoundTripper shared by every service of the Client. It replays a request when
+// the API answers with one of the configured status codes, honoring the Retry-After header when present
+// and falling back to a jittered exponential backoff otherwise.
+type retryTransport struct {
+	config RetryConfig
+	base   http.RoundTripper
+	// each attempt gets its own timeout, the wait between attempts doesn't count against it
+	timeout time.Duration
+
+	randLock sync.Mutex
+	rand     *rand.Rand
+}
+
+func newRetryTransport(base http.RoundTripper, config RetryConfig) *retryTransport {
+	return &retryTransport{
+		config:  config,
+		base:    base,
+		timeout: defaultRequestTimeout,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	var totalWait time.Duration
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTripAttempt(req, body)
+		if !t.shouldRetry(req, resp, err) || attempt >= t.config.MaxRetries {
+			logRetries(req, attempt, totalWait)
+			return resp, err
+		}
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %v, retrying in %s (attempt %d of %d)\n", req.Method, req.URL.Path, err, wait, attempt+1, t.config.MaxRetries)
+		} else {
+			log.Printf("[WARN] %s %s returned %d, retrying in %s (attempt %d of %d)\n", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.config.MaxRetries)
+			// drain the body so the connection can be reused by the next attempt
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			logRetries(req, attempt, totalWait)
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		totalWait += wait
+	}
+}
+
+// roundTripAttempt sends the request once, with the timeout of an attempt as deadline. The deadline covers
+// reading the body of the response too, it is released when the caller closes the body.
+func (t *retryTransport) roundTripAttempt(req *http.Request, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	attemptReq := req.Clone(ctx)
+	if body != nil {
+		attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := t.base.RoundTrip(attemptReq)
+	if err != nil {
+		cancel()
+		if req.Context().Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			// the attempt timed out, not the call, the error is a connection error that can be retried
+			return nil, fmt.Errorf("%s %s: no response within %s", req.Method, req.URL.Path, t.timeout)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	// never retry a request that was cancelled or timed out by its caller
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		// the API may have applied a request that failed once sent, sending a POST again could create the object
+		// twice, only a request that never reached the API is sent again whatever its method
+		return classifyError(err) == errorClassTransient && (isIdempotent(req.Method) || isDialError(err))
+	}
+	for _, code := range t.config.RetryOnStatus {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return resp.StatusCode == http.StatusBadRequest && contains(retryOnErrorIDs, responseErrorID(resp))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// responseErrorID returns the ID of the error in the body of a response, the body is left for the caller to read.
+func responseErrorID(resp *http.Response) string {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	var body struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return ""
+	}
+	return body.ID
+}
+
+// a certificate that doesn't validate won't validate on the next attempt either
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &invalid) || errors.As(err, &hostname)
+}
+
+// backoff returns the time to wait before the next attempt. A Retry-After header sent with a 429 or 503 wins
+// over the computed backoff, but is still capped by MaxWait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.config.MaxWait {
+				return t.config.MaxWait
+			}
+			return wait
+		}
+	}
+	ceiling := float64(t.config.MinWait) * math.Pow(2, float64(attempt))
+	if ceiling > float64(t.config.MaxWait) {
+		ceiling = float64(t.config.MaxWait)
+	}
+	spread := int64(ceiling) - int64(t.config.MinWait)
+	if spread <= 0 {
+		return time.Duration(ceiling)
+	}
+	t.randLock.Lock()
+	defer t.randLock.Unlock()
+	return t.config.MinWait + time.Duration(t.rand.Int63n(spread+1))
+}
+
+// parseRetryAfter supports both forms of the header: delay in seconds and HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+func logRetries(req *http.Request, retries int, totalWait time.Duration) {
+	if retries == 0 {
+		log.Printf("[DEBUG] %s %s completed without retries\n", req.Method, req.URL.Path)
+		return
+	}
+	log.Printf("[INFO] %s %s completed after %d retries, waited %s in total\n", req.Method, req.URL.Path, retries, totalWait)
+}
@@ -0,0 +1,205 @@
+package zpa
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryClient(config RetryConfig) *http.Client {
+	return &http.Client{Transport: newRetryTransport(http.DefaultTransport, config)}
+}
+
+func TestRetryTransportRetriesOnStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("expected the request body to be replayed, got %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(RetryConfig{MaxRetries: 5, MinWait: time.Millisecond, MaxWait: 5 * time.Millisecond, RetryOnStatus: defaultRetryOnStatus})
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryTransportStopsAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(RetryConfig{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryOnStatus: defaultRetryOnStatus})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryTransportIgnoresOtherStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(RetryConfig{MaxRetries: 5, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryOnStatus: []int{http.StatusTooManyRequests}})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{MinWait: time.Second, MaxWait: 8 * time.Second})
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := transport.backoff(attempt, nil)
+		if wait < time.Second || wait > 8*time.Second {
+			t.Errorf("attempt %d: wait %s out of bounds", attempt, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if wait := transport.backoff(0, resp); wait != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "60")
+	if wait := transport.backoff(0, resp); wait != 8*time.Second {
+		t.Errorf("expected Retry-After to be capped by max wait, got %s", wait)
+	}
+}
+
+func TestRetryTransportRetriesBadRequestErrorIDs(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			_, _ = io.WriteString(w, `{"id": "non.restricted.entity.authorization.failed"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "invalid.rule.order"}`)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(RetryConfig{MaxRetries: 5, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryOnStatus: defaultRetryOnStatus})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	// the error that isn't retried is still returned to the SDK
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "invalid.rule.order") {
+		t.Errorf("expected the body of the last response, got %q", body)
+	}
+}
+
+func TestRetryTransportDoesNotRetryBadRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"id": "bad.request"}`)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(RetryConfig{MaxRetries: 5, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryOnStatus: defaultRetryOnStatus})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryTransportRetriesAttemptsThatTimeOut(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 2 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: time.Millisecond, RetryOnStatus: defaultRetryOnStatus})
+	transport.timeout = 50 * time.Millisecond
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	// the deadline of the attempt stays until the body is read
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("expected the body of the second attempt, got %q", body)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryTransportRetriesConnectionErrorsOfIdempotentMethods(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{RetryOnStatus: defaultRetryOnStatus})
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	for _, test := range []struct {
+		method string
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, reset, true},
+		{http.MethodPut, reset, true},
+		{http.MethodDelete, reset, true},
+		// the API may have created the object before the connection failed
+		{http.MethodPost, reset, false},
+		{http.MethodPost, io.ErrUnexpectedEOF, false},
+		{http.MethodPost, refused, true},
+	} {
+		req, _ := http.NewRequest(test.method, "https://config.private.zscaler.com", nil)
+		if got := transport.shouldRetry(req, nil, test.err); got != test.want {
+			t.Errorf("%s %v: got retry %t, want %t", test.method, test.err, got, test.want)
+		}
+	}
+}
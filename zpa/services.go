@@ -0,0 +1,290 @@
+package zpa
+
+import (
+	"net/http"
+
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentinspection"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/applicationsegmentpra"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/appservercontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/bacertificate"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/browseraccess"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/clienttypes"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/cloudconnectorgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/customerversionprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/enrollmentcert"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/idpcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_custom_controls"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_predefined_controls"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/inspectioncontrol/inspection_profile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/isolationprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/lssconfigcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/machinegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/platforms"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/postureprofile"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/provisioningkey"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/samlattribute"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimattributeheader"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/scimgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/segmentgroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/servergroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgecontroller"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/serviceedgegroup"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/trustednetwork"
+)
+
+// The Client depends on these interfaces rather than on the SDK service structs, so that unit tests can
+// swap in the in-memory fake backend. Each interface mirrors the method set of the matching SDK service.
+
+type appConnectorControllerService interface {
+	Get(appConnectorID string) (*appconnectorcontroller.AppConnector, *http.Response, error)
+	GetByName(appConnectorName string) (*appconnectorcontroller.AppConnector, *http.Response, error)
+	GetAll() ([]appconnectorcontroller.AppConnector, error)
+	Update(appConnectorID string, appConnector appconnectorcontroller.AppConnector) (*appconnectorcontroller.AppConnector, *http.Response, error)
+	Delete(appConnectorID string) (*http.Response, error)
+	BulkDelete(appConnectorIDs []string) (*http.Response, error)
+}
+
+type appConnectorGroupService interface {
+	Get(appConnectorGroupID string) (*appconnectorgroup.AppConnectorGroup, *http.Response, error)
+	GetByName(appConnectorGroupName string) (*appconnectorgroup.AppConnectorGroup, *http.Response, error)
+	Create(appConnectorGroup appconnectorgroup.AppConnectorGroup) (*appconnectorgroup.AppConnectorGroup, *http.Response, error)
+	Update(appConnectorGroupID string, appConnectorGroup *appconnectorgroup.AppConnectorGroup) (*http.Response, error)
+	Delete(appConnectorGroupID string) (*http.Response, error)
+	GetAll() ([]appconnectorgroup.AppConnectorGroup, *http.Response, error)
+}
+
+type applicationSegmentService interface {
+	Get(applicationID string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error)
+	GetByName(appName string) (*applicationsegment.ApplicationSegmentResource, *http.Response, error)
+	Create(appSegment applicationsegment.ApplicationSegmentResource) (*applicationsegment.ApplicationSegmentResource, *http.Response, error)
+	Update(applicationId string, appSegmentRequest applicationsegment.ApplicationSegmentResource) (*http.Response, error)
+	Delete(applicationId string) (*http.Response, error)
+	GetAll() ([]applicationsegment.ApplicationSegmentResource, *http.Response, error)
+}
+
+type applicationSegmentInspectionService interface {
+	Get(id string) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error)
+	GetByName(appSegmentName string) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error)
+	Create(appSegmentInspection applicationsegmentinspection.AppSegmentInspection) (*applicationsegmentinspection.AppSegmentInspection, *http.Response, error)
+	Update(id string, appSegmentInspection *applicationsegmentinspection.AppSegmentInspection) (*http.Response, error)
+	Delete(id string) (*http.Response, error)
+	GetAll() ([]applicationsegmentinspection.AppSegmentInspection, *http.Response, error)
+}
+
+type applicationSegmentPRAService interface {
+	Get(id string) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error)
+	GetByName(BaName string) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error)
+	Create(appSegmentPra applicationsegmentpra.AppSegmentPRA) (*applicationsegmentpra.AppSegmentPRA, *http.Response, error)
+	Update(id string, appSegmentPra *applicationsegmentpra.AppSegmentPRA) (*http.Response, error)
+	Delete(id string) (*http.Response, error)
+	GetAll() ([]applicationsegmentpra.AppSegmentPRA, *http.Response, error)
+}
+
+type appServerControllerService interface {
+	Get(id string) (*appservercontroller.ApplicationServer, *http.Response, error)
+	GetByName(appServerName string) (*appservercontroller.ApplicationServer, *http.Response, error)
+	Create(server appservercontroller.ApplicationServer) (*appservercontroller.ApplicationServer, *http.Response, error)
+	Update(id string, appServer appservercontroller.ApplicationServer) (*http.Response, error)
+	Delete(id string) (*http.Response, error)
+	GetAll() ([]appservercontroller.ApplicationServer, *http.Response, error)
+}
+
+type baCertificateService interface {
+	Get(baCertificateID string) (*bacertificate.BaCertificate, *http.Response, error)
+	GetIssuedByName(CertName string) (*bacertificate.BaCertificate, *http.Response, error)
+	GetAll() ([]bacertificate.BaCertificate, *http.Response, error)
+	Create(baCertificate bacertificate.BaCertificate) (*bacertificate.BaCertificate, *http.Response, error)
+	Update(baCertificateID string, baCertificate *bacertificate.BaCertificate) (*http.Response, error)
+	Delete(baCertificateID string) (*http.Response, error)
+}
+
+type browserAccessService interface {
+	Get(id string) (*browseraccess.BrowserAccess, *http.Response, error)
+	GetByName(BaName string) (*browseraccess.BrowserAccess, *http.Response, error)
+	Create(browserAccess browseraccess.BrowserAccess) (*browseraccess.BrowserAccess, *http.Response, error)
+	Update(id string, browserAccess *browseraccess.BrowserAccess) (*http.Response, error)
+	Delete(id string) (*http.Response, error)
+	GetAll() ([]browseraccess.BrowserAccess, *http.Response, error)
+}
+
+type clientTypesService interface {
+	GetAllClientTypes() (*clienttypes.ClientTypes, *http.Response, error)
+}
+
+type cloudConnectorGroupService interface {
+	Get(cloudConnectorGroupID string) (*cloudconnectorgroup.CloudConnectorGroup, *http.Response, error)
+	GetByName(cloudConnectorGroupName string) (*cloudconnectorgroup.CloudConnectorGroup, *http.Response, error)
+	GetAll() ([]cloudconnectorgroup.CloudConnectorGroup, *http.Response, error)
+}
+
+type customerVersionProfileService interface {
+	Get(versionID string) (*customerversionprofile.CustomerVersionProfile, *http.Response, error)
+	GetByName(versionProfileName string) (*customerversionprofile.CustomerVersionProfile, *http.Response, error)
+	GetAll() ([]customerversionprofile.CustomerVersionProfile, *http.Response, error)
+}
+
+type enrollmentCertService interface {
+	Get(id string) (*enrollmentcert.EnrollmentCert, *http.Response, error)
+	GetByName(certName string) (*enrollmentcert.EnrollmentCert, *http.Response, error)
+	GetAll() ([]enrollmentcert.EnrollmentCert, *http.Response, error)
+}
+
+type idpControllerService interface {
+	Get(IdpID string) (*idpcontroller.IdpController, *http.Response, error)
+	GetByName(idpName string) (*idpcontroller.IdpController, *http.Response, error)
+	GetAll() ([]idpcontroller.IdpController, *http.Response, error)
+}
+
+type inspectionCustomControlsService interface {
+	Get(customID string) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error)
+	GetByName(controlName string) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error)
+	Create(customControls inspection_custom_controls.InspectionCustomControl) (*inspection_custom_controls.InspectionCustomControl, *http.Response, error)
+	Update(customID string, customControls *inspection_custom_controls.InspectionCustomControl) (*http.Response, error)
+	Delete(customID string) (*http.Response, error)
+	GetAll() ([]inspection_custom_controls.InspectionCustomControl, *http.Response, error)
+}
+
+type inspectionPredefinedControlsService interface {
+	Get(controlID string) (*inspection_predefined_controls.PredefinedControls, *http.Response, error)
+	GetAll(version string) ([]inspection_predefined_controls.PredefinedControls, error)
+	GetByName(name, version string) (*inspection_predefined_controls.PredefinedControls, *http.Response, error)
+	GetAllByGroup(version, groupName string) ([]inspection_predefined_controls.PredefinedControls, error)
+}
+
+type inspectionProfileService interface {
+	Get(profileID string) (*inspection_profile.InspectionProfile, *http.Response, error)
+	GetByName(profileName string) (*inspection_profile.InspectionProfile, *http.Response, error)
+	Create(inspectionProfile inspection_profile.InspectionProfile) (*inspection_profile.InspectionProfile, *http.Response, error)
+	Update(profileID string, inspectionProfile *inspection_profile.InspectionProfile) (*http.Response, error)
+	PutAssociate(profileID string, inspectionProfile *inspection_profile.InspectionProfile) (*http.Response, error)
+	PutDeassociate(profileID string, inspectionProfile *inspection_profile.InspectionProfile) (*http.Response, error)
+	Patch(profileID string, inspectionProfile *inspection_profile.InspectionProfile) (*http.Response, error)
+	Delete(profileID string) (*http.Response, error)
+	GetAll() ([]inspection_profile.InspectionProfile, *http.Response, error)
+}
+
+type isolationProfileService interface {
+	Get(profileID string) (*isolationprofile.IsolationProfile, *http.Response, error)
+	GetByName(profileName string) (*isolationprofile.IsolationProfile, *http.Response, error)
+	GetAll() ([]isolationprofile.IsolationProfile, *http.Response, error)
+}
+
+type lssConfigControllerService interface {
+	GetClientTypes() (*lssconfigcontroller.LSSClientTypes, *http.Response, error)
+	Get(lssID string) (*lssconfigcontroller.LSSResource, *http.Response, error)
+	GetByName(lssName string) (*lssconfigcontroller.LSSResource, *http.Response, error)
+	Create(lssConfig *lssconfigcontroller.LSSResource) (*lssconfigcontroller.LSSResource, *http.Response, error)
+	Update(lssID string, lssConfig *lssconfigcontroller.LSSResource) (*http.Response, error)
+	Delete(lssID string) (*http.Response, error)
+	GetAll() ([]lssconfigcontroller.LSSResource, *http.Response, error)
+	GetFormats(logType string) (*lssconfigcontroller.LSSFormats, *http.Response, error)
+	GetStatusCodes() (*lssconfigcontroller.LSSStatusCodes, *http.Response, error)
+}
+
+type machineGroupService interface {
+	Get(machineGroupID string) (*machinegroup.MachineGroup, *http.Response, error)
+	GetByName(machineGroupName string) (*machinegroup.MachineGroup, *http.Response, error)
+	GetAll() ([]machinegroup.MachineGroup, *http.Response, error)
+}
+
+type platformsService interface {
+	GetAllPlatforms() (*platforms.Platforms, *http.Response, error)
+}
+
+type policySetControllerService interface {
+	GetByPolicyType(policyType string) (*policysetcontroller.PolicySet, *http.Response, error)
+	GetPolicyRule(policySetID, ruleId string) (*policysetcontroller.PolicyRule, *http.Response, error)
+	Create(rule *policysetcontroller.PolicyRule) (*policysetcontroller.PolicyRule, *http.Response, error)
+	Update(policySetID, ruleId string, policySetRule *policysetcontroller.PolicyRule) (*http.Response, error)
+	Delete(policySetID, ruleId string) (*http.Response, error)
+	GetByNameAndType(policyType, ruleName string) (*policysetcontroller.PolicyRule, *http.Response, error)
+	GetByNameAndTypes(policyTypes []string, ruleName string) (p *policysetcontroller.PolicyRule, resp *http.Response, err error)
+	Reorder(policySetID, ruleId string, order int) (*http.Response, error)
+	RulesCount() (int, *http.Response, error)
+	GetAllByType(policyType string) ([]policysetcontroller.PolicyRule, *http.Response, error)
+}
+
+type postureProfileService interface {
+	Get(id string) (*postureprofile.PostureProfile, *http.Response, error)
+	GetByPostureUDID(postureUDID string) (*postureprofile.PostureProfile, *http.Response, error)
+	GetByName(postureName string) (*postureprofile.PostureProfile, *http.Response, error)
+	GetAll() ([]postureprofile.PostureProfile, *http.Response, error)
+}
+
+type provisioningKeyService interface {
+	Get(associationType, provisioningKeyID string) (*provisioningkey.ProvisioningKey, *http.Response, error)
+	GetByName(associationType, name string) (*provisioningkey.ProvisioningKey, *http.Response, error)
+	Create(associationType string, provisioningKey *provisioningkey.ProvisioningKey) (*provisioningkey.ProvisioningKey, *http.Response, error)
+	Update(associationType, provisioningKeyID string, provisioningKey *provisioningkey.ProvisioningKey) (*http.Response, error)
+	Delete(associationType, provisioningKeyID string) (*http.Response, error)
+	GetByNameAllAssociations(name string) (p *provisioningkey.ProvisioningKey, assoc_type string, resp *http.Response, err error)
+	GetByIDAllAssociations(id string) (p *provisioningkey.ProvisioningKey, assoc_type string, resp *http.Response, err error)
+	GetAllByAssociationType(associationType string) ([]provisioningkey.ProvisioningKey, error)
+	GetAll() (list []provisioningkey.ProvisioningKey, err error)
+}
+
+type samlAttributeService interface {
+	Get(samlAttributeID string) (*samlattribute.SamlAttribute, *http.Response, error)
+	GetByName(samlAttrName string) (*samlattribute.SamlAttribute, *http.Response, error)
+	GetAll() ([]samlattribute.SamlAttribute, *http.Response, error)
+}
+
+type scimAttributeHeaderService interface {
+	Get(idpId, scimAttrHeaderID string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error)
+	GetValues(idpId, ScimAttrHeaderID string) ([]string, error)
+	GetByName(scimAttributeName, IdpId string) (*scimattributeheader.ScimAttributeHeader, *http.Response, error)
+	GetAllByIdpId(IdpId string) ([]scimattributeheader.ScimAttributeHeader, *http.Response, error)
+}
+
+type scimGroupService interface {
+	Get(scimGroupID string) (*scimgroup.ScimGroup, *http.Response, error)
+	GetByName(scimName, IdpId string) (*scimgroup.ScimGroup, *http.Response, error)
+	GetAllByIdpId(IdpId string) ([]scimgroup.ScimGroup, *http.Response, error)
+}
+
+type segmentGroupService interface {
+	Get(segmentGroupID string) (*segmentgroup.SegmentGroup, *http.Response, error)
+	GetByName(segmentName string) (*segmentgroup.SegmentGroup, *http.Response, error)
+	Create(segmentGroup *segmentgroup.SegmentGroup) (*segmentgroup.SegmentGroup, *http.Response, error)
+	Update(segmentGroupId string, segmentGroupRequest *segmentgroup.SegmentGroup) (*http.Response, error)
+	Delete(segmentGroupId string) (*http.Response, error)
+	GetAll() ([]segmentgroup.SegmentGroup, *http.Response, error)
+}
+
+type serverGroupService interface {
+	Get(groupID string) (*servergroup.ServerGroup, *http.Response, error)
+	GetByName(serverGroupName string) (*servergroup.ServerGroup, *http.Response, error)
+	Create(serverGroup *servergroup.ServerGroup) (*servergroup.ServerGroup, *http.Response, error)
+	Update(groupId string, serverGroup *servergroup.ServerGroup) (*http.Response, error)
+	Delete(groupId string) (*http.Response, error)
+	GetAll() ([]servergroup.ServerGroup, *http.Response, error)
+}
+
+type serviceEdgeControllerService interface {
+	Get(serviceEdgeID string) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error)
+	GetByName(serviceEdgeName string) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error)
+	GetAll() ([]serviceedgecontroller.ServiceEdgeController, *http.Response, error)
+	Update(serviceEdgeID string, serviceEdge serviceedgecontroller.ServiceEdgeController) (*serviceedgecontroller.ServiceEdgeController, *http.Response, error)
+	Delete(serviceEdgeID string) (*http.Response, error)
+	BulkDelete(serviceEdgeIDs []string) (*http.Response, error)
+}
+
+type serviceEdgeGroupService interface {
+	Get(serviceEdgeGroupID string) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error)
+	GetByName(serviceEdgeGroupName string) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error)
+	Create(serviceEdge serviceedgegroup.ServiceEdgeGroup) (*serviceedgegroup.ServiceEdgeGroup, *http.Response, error)
+	Update(serviceEdgeGroupID string, serviceEdge *serviceedgegroup.ServiceEdgeGroup) (*http.Response, error)
+	Delete(serviceEdgeGroupID string) (*http.Response, error)
+	GetAll() ([]serviceedgegroup.ServiceEdgeGroup, *http.Response, error)
+}
+
+type trustedNetworkService interface {
+	Get(networkID string) (*trustednetwork.TrustedNetwork, *http.Response, error)
+	GetByNetID(netID string) (*trustednetwork.TrustedNetwork, *http.Response, error)
+	GetByName(trustedNetworkName string) (*trustednetwork.TrustedNetwork, *http.Response, error)
+	GetAll() ([]trustednetwork.TrustedNetwork, *http.Response, error)
+}
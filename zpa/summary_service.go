@@ -0,0 +1,52 @@
+package zpa
+
+import (
+	"fmt"
+	"net/http"
+
+	gozscaler "github.com/zscaler/zscaler-sdk-go/zpa"
+	"github.com/zscaler/zscaler-sdk-go/zpa/services/common"
+)
+
+const mgmtConfig = "/mgmtconfig/v1/admin/customers/"
+
+// objectSummary is the ID and name of an object listed by a summary endpoint.
+type objectSummary struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// summaryService lists the objects of a summary endpoint the SDK has no service for, such as the ZIA locations
+// and branch connector groups referenced by policy operands. The PRA consoles are listed the same way, by the
+// endpoint of the consoles themselves.
+type summaryService interface {
+	GetAll() ([]objectSummary, *http.Response, error)
+}
+
+type summaryEndpoint struct {
+	client   *gozscaler.Client
+	endpoint string
+}
+
+func newSummaryService(client *gozscaler.Client, endpoint string) *summaryEndpoint {
+	return &summaryEndpoint{client: client, endpoint: endpoint}
+}
+
+func (s *summaryEndpoint) GetAll() ([]objectSummary, *http.Response, error) {
+	relativeURL := mgmtConfig + s.client.Config.CustomerID + s.endpoint
+	return common.GetAllPagesGeneric[objectSummary](s.client, relativeURL, "")
+}
+
+// getSummaryByID returns the object with the given ID from a summary service.
+func getSummaryByID(service summaryService, id string) (*objectSummary, error) {
+	list, _, err := service.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].ID == id {
+			return &list[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no object with id %s was found", id)
+}